@@ -0,0 +1,279 @@
+package aiff
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+
+	"github.com/ECecillo/lib.go.sound/pkg/format"
+)
+
+var (
+	// ErrNotAIFF is returned when the input does not start with a FORM/AIFF
+	// header or lacks its COMM or SSND chunk.
+	ErrNotAIFF = errors.New("not an AIFF file")
+	// ErrUnsupportedFormat is returned when an audio format has no AIFF
+	// representation. AIFF only stores signed integer PCM.
+	ErrUnsupportedFormat = errors.New("unsupported AIFF format")
+)
+
+// Sizes of the COMM chunk payload: the fields of commChunk, and the bound
+// Decode accepts, which leaves room for the AIFF-C extension fields.
+const (
+	commSize         = 18
+	maxCommChunkSize = 1024
+)
+
+// commChunk is the payload of the COMM chunk.
+type commChunk struct {
+	Channels        int16
+	NumSampleFrames uint32
+	SampleSize      int16
+	SampleRate      [10]byte // 80-bit IEEE 754 extended precision
+}
+
+// Writer encodes samples into an AIFF file.
+type Writer struct {
+	Format     format.AudioFormat // One of format.PCM8, format.PCM16 or format.PCM32
+	SampleRate int                // Sampling frequency in Hz
+	Channels   int                // Number of interleaved channels
+}
+
+// NewWriter returns an AIFF writer for the given layout.
+func NewWriter(f format.AudioFormat, sampleRate int, channels int) *Writer {
+	return &Writer{
+		Format:     f,
+		SampleRate: sampleRate,
+		Channels:   channels,
+	}
+}
+
+// Write encodes the FORM header, the COMM chunk and the SSND chunk holding
+// samples, which must already be interleaved when Channels is greater than
+// one, and returns the number of bytes written. Samples are stored as
+// big-endian signed integers.
+func (wr Writer) Write(w io.Writer, samples []float64) (int64, error) {
+	if !Supported(wr.Format) {
+		return 0, fmt.Errorf("%w: %T", ErrUnsupportedFormat, wr.Format)
+	}
+
+	sampleSize := wr.Format.BitDepth() / 8
+	data := make([]byte, 0, 8+len(samples)*sampleSize)
+	data = append(data, make([]byte, 8)...) // SSND offset and block size
+	for _, sample := range samples {
+		data = append(data, toBigEndian(wr.Format, wr.Format.ConvertSample(sample))...)
+	}
+
+	comm := commChunk{
+		Channels:        int16(wr.Channels),
+		NumSampleFrames: uint32(len(samples) / max(wr.Channels, 1)),
+		SampleSize:      int16(wr.Format.BitDepth()),
+		SampleRate:      toExtended(float64(wr.SampleRate)),
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("FORM")
+	_ = binary.Write(&buf, binary.BigEndian, uint32(4+8+commSize+8+len(data)+len(data)%2))
+	buf.WriteString("AIFF")
+	buf.WriteString("COMM")
+	_ = binary.Write(&buf, binary.BigEndian, uint32(commSize))
+	_ = binary.Write(&buf, binary.BigEndian, comm)
+	buf.WriteString("SSND")
+	_ = binary.Write(&buf, binary.BigEndian, uint32(len(data)))
+	buf.Write(data)
+	if len(data)%2 == 1 {
+		buf.WriteByte(0)
+	}
+
+	n, err := w.Write(buf.Bytes())
+	if err != nil {
+		return int64(n), fmt.Errorf("unable to write data, err: %w", err)
+	}
+
+	return int64(n), nil
+}
+
+// Decode reads an AIFF file from r and returns its samples, interleaved
+// when there is more than one channel, along with the Writer describing
+// its layout. Chunks other than COMM and SSND are skipped.
+func Decode(r io.Reader) ([]float64, *Writer, error) {
+	var form [12]byte
+	if _, err := io.ReadFull(r, form[:]); err != nil {
+		return nil, nil, ErrNotAIFF
+	}
+	if string(form[0:4]) != "FORM" || string(form[8:12]) != "AIFF" {
+		return nil, nil, ErrNotAIFF
+	}
+
+	var comm *commChunk
+	var data []byte
+	var found bool
+
+	for comm == nil || !found {
+		var id [4]byte
+		var size uint32
+		if _, err := io.ReadFull(r, id[:]); err != nil {
+			return nil, nil, ErrNotAIFF
+		}
+		if err := binary.Read(r, binary.BigEndian, &size); err != nil {
+			return nil, nil, ErrNotAIFF
+		}
+
+		// Chunks are padded to an even number of bytes. Sizes are handled
+		// in 64 bits so that a forged size cannot wrap around.
+		padded := int64(size) + int64(size%2)
+
+		switch string(id[:]) {
+		case "COMM":
+			if size < commSize || size > maxCommChunkSize {
+				return nil, nil, ErrNotAIFF
+			}
+			payload := make([]byte, padded)
+			if _, err := io.ReadFull(r, payload); err != nil {
+				return nil, nil, ErrNotAIFF
+			}
+			comm = &commChunk{}
+			if err := binary.Read(bytes.NewReader(payload), binary.BigEndian, comm); err != nil {
+				return nil, nil, ErrNotAIFF
+			}
+		case "SSND":
+			var err error
+			if data, err = readSoundData(r, int64(size)); err != nil {
+				return nil, nil, err
+			}
+			found = true
+			if comm == nil {
+				if _, err := io.CopyN(io.Discard, r, int64(size%2)); err != nil {
+					return nil, nil, ErrNotAIFF
+				}
+			}
+		default:
+			if _, err := io.CopyN(io.Discard, r, padded); err != nil {
+				return nil, nil, ErrNotAIFF
+			}
+		}
+	}
+
+	f, err := formatOf(comm.SampleSize)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	decoder, ok := f.(format.Decoder)
+	if !ok {
+		return nil, nil, fmt.Errorf("%w: %T", format.ErrNotDecoder, f)
+	}
+
+	sampleSize := f.BitDepth() / 8
+	samples := make([]float64, len(data)/sampleSize)
+	for i := range samples {
+		raw := toBigEndian(f, data[i*sampleSize:(i+1)*sampleSize])
+		samples[i], err = decoder.Decode(raw)
+		if err != nil {
+			return nil, nil, fmt.Errorf("unable to decode sample %d, err: %w", i, err)
+		}
+	}
+
+	sampleRate := int(math.Round(fromExtended(comm.SampleRate)))
+	return samples, NewWriter(f, sampleRate, int(comm.Channels)), nil
+}
+
+// readSoundData reads the payload of an SSND chunk of the given size,
+// skipping its offset field and the block alignment padding it announces.
+// The sample data is read through a LimitReader so that a forged size
+// cannot allocate more than the file actually holds.
+func readSoundData(r io.Reader, size int64) ([]byte, error) {
+	var fields struct {
+		Offset    uint32
+		BlockSize uint32
+	}
+	if size < 8 {
+		return nil, ErrNotAIFF
+	}
+	if err := binary.Read(r, binary.BigEndian, &fields); err != nil {
+		return nil, ErrNotAIFF
+	}
+
+	remaining := size - 8
+	offset := min(int64(fields.Offset), remaining)
+	if _, err := io.CopyN(io.Discard, r, offset); err != nil {
+		return nil, ErrNotAIFF
+	}
+
+	data, err := io.ReadAll(io.LimitReader(r, remaining-offset))
+	if err != nil || int64(len(data)) < remaining-offset {
+		return nil, ErrNotAIFF
+	}
+	return data, nil
+}
+
+// Supported reports whether f can be stored in an AIFF file.
+func Supported(f format.AudioFormat) bool {
+	switch f.(type) {
+	case format.PCM8, format.PCM16, format.PCM32:
+		return true
+	default:
+		return false
+	}
+}
+
+// formatOf returns the PCM format with the given sample size in bits.
+func formatOf(sampleSize int16) (format.AudioFormat, error) {
+	switch sampleSize {
+	case 8:
+		return format.PCM8{}, nil
+	case 16:
+		return format.PCM16{}, nil
+	case 32:
+		return format.PCM32{}, nil
+	default:
+		return nil, fmt.Errorf("%w: %d bits per sample", ErrUnsupportedFormat, sampleSize)
+	}
+}
+
+// toBigEndian converts one sample between the little-endian encoding of
+// pkg/format and the big-endian encoding of AIFF. The conversion is its own
+// inverse. 8-bit samples are unsigned in pkg/format and signed in AIFF, so
+// their sign bit is flipped.
+func toBigEndian(f format.AudioFormat, b []byte) []byte {
+	result := slices.Clone(b)
+	slices.Reverse(result)
+	if _, ok := f.(format.PCM8); ok {
+		result[0] ^= 0x80
+	}
+	return result
+}
+
+// toExtended encodes a non-negative value as an 80-bit IEEE 754 extended
+// precision number: a 15-bit biased exponent followed by a 64-bit mantissa
+// with an explicit integer bit.
+func toExtended(value float64) [10]byte {
+	var b [10]byte
+	if value <= 0 {
+		return b
+	}
+
+	frac, exp := math.Frexp(value) // value = frac * 2^exp, frac in [0.5, 1)
+	binary.BigEndian.PutUint16(b[0:2], uint16(exp-1+16383))
+	binary.BigEndian.PutUint64(b[2:10], uint64(math.Ldexp(frac, 64)))
+	return b
+}
+
+// fromExtended decodes an 80-bit IEEE 754 extended precision number.
+func fromExtended(b [10]byte) float64 {
+	exp := int(binary.BigEndian.Uint16(b[0:2]) & 0x7FFF)
+	mantissa := binary.BigEndian.Uint64(b[2:10])
+	if exp == 0 && mantissa == 0 {
+		return 0
+	}
+
+	value := math.Ldexp(float64(mantissa), exp-16383-63)
+	if b[0]&0x80 != 0 {
+		value = -value
+	}
+	return value
+}
@@ -0,0 +1,122 @@
+package aiff
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/ECecillo/lib.go.sound/pkg/format"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriter_Header(t *testing.T) {
+	var buf bytes.Buffer
+	n, err := NewWriter(format.PCM16{}, 44100, 2).Write(&buf, []float64{0.5, -0.5, 0.25, -0.25})
+	require.NoError(t, err)
+	require.Equal(t, int64(54+8), n)
+
+	data := buf.Bytes()
+	require.Equal(t, "FORM", string(data[0:4]))
+	require.Equal(t, uint32(54), binary.BigEndian.Uint32(data[4:8]))
+	require.Equal(t, "AIFF", string(data[8:12]))
+	require.Equal(t, "COMM", string(data[12:16]))
+	require.Equal(t, uint32(18), binary.BigEndian.Uint32(data[16:20]))
+	require.Equal(t, uint16(2), binary.BigEndian.Uint16(data[20:22]))
+	require.Equal(t, uint32(2), binary.BigEndian.Uint32(data[22:26]))
+	require.Equal(t, uint16(16), binary.BigEndian.Uint16(data[26:28]))
+
+	// 44100 Hz as an 80-bit extended float.
+	require.Equal(t, []byte{0x40, 0x0E, 0xAC, 0x44, 0, 0, 0, 0, 0, 0}, data[28:38])
+
+	require.Equal(t, "SSND", string(data[38:42]))
+	require.Equal(t, uint32(16), binary.BigEndian.Uint32(data[42:46]))
+
+	// 0.5 is 16383 = 0x3FFF, stored big-endian.
+	require.Equal(t, []byte{0x3F, 0xFF}, data[54:56])
+}
+
+func TestDecode_RoundTrip(t *testing.T) {
+	samples := []float64{0, 0.5, -0.5, 1, -1, 0.25}
+
+	for _, f := range []format.AudioFormat{format.PCM8{}, format.PCM16{}, format.PCM32{}} {
+		for _, rate := range []int{8000, 22050, 44100, 48000, 96000} {
+			var buf bytes.Buffer
+			_, err := NewWriter(f, rate, 2).Write(&buf, samples)
+			require.NoError(t, err)
+
+			decoded, layout, err := Decode(&buf)
+			require.NoError(t, err)
+			require.IsType(t, f, layout.Format)
+			require.Equal(t, rate, layout.SampleRate)
+			require.Equal(t, 2, layout.Channels)
+			require.InDeltaSlice(t, samples, decoded, 1.0/127, "%T", f)
+		}
+	}
+}
+
+func TestDecode_OddLengthAndExtraChunks(t *testing.T) {
+	var buf bytes.Buffer
+	_, err := NewWriter(format.PCM8{}, 8000, 1).Write(&buf, []float64{0.5, -0.5, 0.25})
+	require.NoError(t, err)
+	original := buf.Bytes()
+
+	// An odd-sized SSND chunk is padded; insert a NAME chunk before COMM.
+	var file bytes.Buffer
+	file.Write(original[:12])
+	file.WriteString("NAME")
+	require.NoError(t, binary.Write(&file, binary.BigEndian, uint32(3)))
+	file.Write([]byte{'a', 'b', 'c', 0})
+	file.Write(original[12:])
+
+	decoded, _, err := Decode(&file)
+	require.NoError(t, err)
+	require.InDeltaSlice(t, []float64{0.5, -0.5, 0.25}, decoded, 1.0/127)
+}
+
+func TestExtended(t *testing.T) {
+	for _, value := range []float64{0, 1, 8000, 11025, 44100, 48000, 192000, 0.5} {
+		require.Equal(t, value, fromExtended(toExtended(value)))
+	}
+}
+
+func TestErrors(t *testing.T) {
+	var buf bytes.Buffer
+	_, err := NewWriter(format.Float32{}, 44100, 1).Write(&buf, []float64{0.5})
+	require.ErrorIs(t, err, ErrUnsupportedFormat)
+	require.Zero(t, buf.Len())
+
+	_, _, err = Decode(bytes.NewReader([]byte("RIFF....WAVE")))
+	require.ErrorIs(t, err, ErrNotAIFF)
+
+	_, err = NewWriter(format.PCM16{}, 44100, 1).Write(&buf, []float64{0.5})
+	require.NoError(t, err)
+
+	// Missing SSND chunk.
+	_, _, err = Decode(bytes.NewReader(buf.Bytes()[:38]))
+	require.ErrorIs(t, err, ErrNotAIFF)
+
+	// 24-bit samples are not supported.
+	data := bytes.Clone(buf.Bytes())
+	binary.BigEndian.PutUint16(data[26:28], 24)
+	_, _, err = Decode(bytes.NewReader(data))
+	require.ErrorIs(t, err, ErrUnsupportedFormat)
+
+	// Forged sizes are bounded instead of allocated: a huge chunk before
+	// COMM, an SSND chunk longer than the file and an offset past the end
+	// of the chunk.
+	form := []byte("FORM\x00\x00\x00\x00AIFF")
+	for _, chunk := range []string{
+		"NAME\xff\xff\xff\xff",
+		"SSND\xff\xff\xff\xfe\x00\x00\x00\x00\x00\x00\x00\x00",
+		"COMM\xff\xff\xff\xff",
+	} {
+		_, _, err = Decode(bytes.NewReader(append(bytes.Clone(form), chunk...)))
+		require.ErrorIs(t, err, ErrNotAIFF, "%q", chunk)
+	}
+
+	data = bytes.Clone(buf.Bytes())
+	binary.BigEndian.PutUint32(data[46:50], 0xFFFFFFFF)
+	decoded, _, err := Decode(bytes.NewReader(data))
+	require.NoError(t, err)
+	require.Empty(t, decoded)
+}
@@ -0,0 +1,102 @@
+package analysis
+
+import (
+	"math"
+	"math/cmplx"
+)
+
+// RealCepstrum returns the real cepstrum of samples:
+//
+//	c[q] = IFFT(log|FFT(x)|)
+//
+// samples are zero-padded to a power of two. Periodic structure in the
+// spectrum, such as the harmonics of a pitched sound or the ripple caused
+// by an echo, shows up as a peak at the matching quefrency q (in samples).
+func RealCepstrum(samples []float64) ([]float64, error) {
+	spectrum, err := paddedSpectrum(samples)
+	if err != nil {
+		return nil, err
+	}
+
+	for k, x := range spectrum {
+		spectrum[k] = complex(math.Log(math.Max(cmplx.Abs(x), logFloor)), 0)
+	}
+
+	return IFFT(spectrum)
+}
+
+// ComplexCepstrum returns the complex cepstrum of samples, the inverse
+// transform of the complex logarithm of the spectrum:
+//
+//	ĉ[q] = IFFT(log|X[k]| + j*arg(X[k]))
+//
+// The phase is unwrapped across bins so that the cepstrum keeps enough
+// information to deconvolve the signal. samples are zero-padded to a power
+// of two.
+func ComplexCepstrum(samples []float64) ([]complex128, error) {
+	spectrum, err := paddedSpectrum(samples)
+	if err != nil {
+		return nil, err
+	}
+
+	phases := make([]float64, len(spectrum))
+	for k, x := range spectrum {
+		phases[k] = cmplx.Phase(x)
+	}
+	unwrapPhase(phases)
+
+	for k, x := range spectrum {
+		spectrum[k] = complex(math.Log(math.Max(cmplx.Abs(x), logFloor)), phases[k])
+	}
+
+	return ifft(spectrum), nil
+}
+
+// LifterCepstrum applies a low-quefrency lifter to a real cepstrum: the
+// coefficients below cutoffQuefrency, and their mirror images at the end of
+// the cepstrum, are kept while the rest are zeroed. The result describes
+// the spectral envelope (formants); subtracting it from the input leaves
+// the excitation.
+func LifterCepstrum(cepstrum []float64, cutoffQuefrency int) []float64 {
+	result := make([]float64, len(cepstrum))
+
+	for q, c := range cepstrum {
+		if q < cutoffQuefrency || (q > 0 && len(cepstrum)-q < cutoffQuefrency) {
+			result[q] = c
+		}
+	}
+
+	return result
+}
+
+// paddedSpectrum zero-pads samples to a power of two and returns its FFT.
+func paddedSpectrum(samples []float64) ([]complex128, error) {
+	if len(samples) == 0 {
+		return nil, ErrSilentSignal
+	}
+
+	input := make([]complex128, nextPowerOfTwo(len(samples)))
+	for n, x := range samples {
+		input[n] = complex(x, 0)
+	}
+
+	return fft(input), nil
+}
+
+// unwrapPhase removes the 2π jumps between consecutive phase values in
+// place.
+func unwrapPhase(phases []float64) {
+	var offset float64
+	for k := 1; k < len(phases); k++ {
+		delta := phases[k] + offset - phases[k-1]
+		for delta > math.Pi {
+			offset -= 2 * math.Pi
+			delta -= 2 * math.Pi
+		}
+		for delta < -math.Pi {
+			offset += 2 * math.Pi
+			delta += 2 * math.Pi
+		}
+		phases[k] += offset
+	}
+}
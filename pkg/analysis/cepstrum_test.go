@@ -0,0 +1,115 @@
+package analysis
+
+import (
+	"math"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// echoed returns white noise of the given length mixed with a copy of
+// itself delayed by delay samples.
+func echoed(length, delay int, gain float64) []float64 {
+	rng := rand.New(rand.NewPCG(5, 6))
+	dry := make([]float64, length)
+	for n := range dry {
+		dry[n] = rng.Float64()*2 - 1
+	}
+
+	wet := make([]float64, length+delay)
+	for n, x := range dry {
+		wet[n] += x
+		wet[n+delay] += gain * x
+	}
+	return wet
+}
+
+// peakQuefrency returns the index of the largest value in values[from:to].
+func peakQuefrency(values []float64, from, to int) int {
+	peak := from
+	for q := from; q < to; q++ {
+		if values[q] > values[peak] {
+			peak = q
+		}
+	}
+	return peak
+}
+
+func TestRealCepstrum_Echo(t *testing.T) {
+	for _, delay := range []int{37, 100, 250} {
+		cepstrum, err := RealCepstrum(echoed(1500, delay, 0.6))
+		require.NoError(t, err)
+		require.Len(t, cepstrum, 2048)
+
+		require.Equal(t, delay, peakQuefrency(cepstrum, 10, len(cepstrum)/2))
+	}
+}
+
+func TestComplexCepstrum_Echo(t *testing.T) {
+	delay := 120
+	gain := 0.6
+
+	// A decaying exponential is minimum phase, so its complex cepstrum is
+	// confined to the first few quefrencies.
+	samples := make([]float64, 1024)
+	for n := range 256 {
+		samples[n] += math.Pow(0.5, float64(n))
+		samples[n+delay] += gain * math.Pow(0.5, float64(n))
+	}
+
+	cepstrum, err := ComplexCepstrum(samples)
+	require.NoError(t, err)
+	require.Len(t, cepstrum, 1024)
+
+	// The echo multiplies the spectrum by 1 + a*e^(-jωd), whose complex log
+	// expands into impulses of amplitude a, -a²/2, ... at d, 2d, ...
+	realPart := make([]float64, len(cepstrum))
+	for q, c := range cepstrum {
+		require.InDelta(t, 0.0, imag(c), 1e-9)
+		realPart[q] = real(c)
+	}
+	require.Equal(t, delay, peakQuefrency(realPart, 10, len(realPart)))
+	require.InDelta(t, gain, realPart[delay], 1e-6)
+	require.InDelta(t, -gain*gain/2, realPart[2*delay], 1e-6)
+}
+
+func TestLifterCepstrum(t *testing.T) {
+	cepstrum := []float64{1, 2, 3, 4, 5, 6, 7, 8}
+
+	require.Equal(t, []float64{1, 2, 3, 0, 0, 0, 7, 8}, LifterCepstrum(cepstrum, 3))
+	require.Equal(t, []float64{0, 0, 0, 0, 0, 0, 0, 0}, LifterCepstrum(cepstrum, 0))
+	require.Equal(t, cepstrum, LifterCepstrum(cepstrum, 8))
+}
+
+func TestLifterCepstrum_SeparatesEnvelope(t *testing.T) {
+	cepstrum, err := RealCepstrum(echoed(1500, 200, 0.6))
+	require.NoError(t, err)
+
+	envelope := LifterCepstrum(cepstrum, 50)
+	require.Zero(t, envelope[200])
+
+	excitation := make([]float64, len(cepstrum))
+	for q := range cepstrum {
+		excitation[q] = cepstrum[q] - envelope[q]
+	}
+	require.Equal(t, 200, peakQuefrency(excitation, 0, len(excitation)/2))
+}
+
+func TestCepstrum_Empty(t *testing.T) {
+	_, err := RealCepstrum(nil)
+	require.ErrorIs(t, err, ErrSilentSignal)
+
+	_, err = ComplexCepstrum(nil)
+	require.ErrorIs(t, err, ErrSilentSignal)
+}
+
+func TestUnwrapPhase(t *testing.T) {
+	phases := []float64{3, -3, -2.5, 2.9}
+	unwrapPhase(phases)
+
+	for k := 1; k < len(phases); k++ {
+		require.Less(t, math.Abs(phases[k]-phases[k-1]), math.Pi)
+	}
+	require.InDelta(t, -3+2*math.Pi, phases[1], 1e-12)
+}
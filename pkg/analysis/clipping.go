@@ -0,0 +1,31 @@
+package analysis
+
+import "math"
+
+// ClippingReport summarizes how much of a signal exceeds a ceiling.
+type ClippingReport struct {
+	ClippedSamples int     // Number of samples with |x| > ceiling
+	MaxExceedance  float64 // Largest amount by which |x| exceeds the ceiling
+	ClipFraction   float64 // ClippedSamples / len(samples)
+}
+
+// AnalyzeClipping reports the samples that would clip at ceiling, for
+// instance before writing to a fixed-point format where ceiling is 1.0.
+// Samples exactly at the ceiling are not counted. An empty signal returns
+// a zero report.
+func AnalyzeClipping(samples []float64, ceiling float64) ClippingReport {
+	var report ClippingReport
+	if len(samples) == 0 {
+		return report
+	}
+
+	for _, v := range samples {
+		if over := math.Abs(v) - ceiling; over > 0 {
+			report.ClippedSamples++
+			report.MaxExceedance = max(report.MaxExceedance, over)
+		}
+	}
+	report.ClipFraction = float64(report.ClippedSamples) / float64(len(samples))
+
+	return report
+}
@@ -0,0 +1,40 @@
+package analysis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnalyzeClipping_Sine(t *testing.T) {
+	// 12 samples per period land on multiples of 30°, where |sin| takes
+	// the values 0, 0.5, 0.866 and 1. Scaled by 1.5, only 0.866 and 1
+	// exceed 1.0: 6 samples in every 12.
+	samples := sineWave(4000, 48000, 4800)
+	for i := range samples {
+		samples[i] *= 1.5
+	}
+
+	report := AnalyzeClipping(samples, 1.0)
+
+	require.Equal(t, 2400, report.ClippedSamples)
+	require.InDelta(t, 0.5, report.MaxExceedance, 1e-9)
+	require.InDelta(t, 0.5, report.ClipFraction, 1e-12)
+}
+
+func TestAnalyzeClipping_NoClipping(t *testing.T) {
+	report := AnalyzeClipping([]float64{0.5, -1.0, 1.0, 0}, 1.0)
+	require.Equal(t, ClippingReport{}, report)
+}
+
+func TestAnalyzeClipping_NegativeSamples(t *testing.T) {
+	report := AnalyzeClipping([]float64{-1.25, 0.2, 1.1, -0.9}, 1.0)
+
+	require.Equal(t, 2, report.ClippedSamples)
+	require.InDelta(t, 0.25, report.MaxExceedance, 1e-12)
+	require.InDelta(t, 0.5, report.ClipFraction, 1e-12)
+}
+
+func TestAnalyzeClipping_Empty(t *testing.T) {
+	require.Equal(t, ClippingReport{}, AnalyzeClipping(nil, 1.0))
+}
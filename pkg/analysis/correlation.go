@@ -0,0 +1,96 @@
+package analysis
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrEmptySignal is returned when a correlation input has no samples.
+var ErrEmptySignal = errors.New("signal has no samples")
+
+// CrossCorrelate returns the full cross-correlation of a and b, with
+// len(a)+len(b)-1 values:
+//
+//	c[l] = Σ a[n] * b[n+l]    for l = -(len(a)-1) … len(b)-1
+//
+// stored at index l+len(a)-1. A peak at a positive lag means b lags
+// behind a. The sequence is computed as a product of zero-padded spectra.
+func CrossCorrelate(a, b []float64) ([]float64, error) {
+	if len(a) == 0 || len(b) == 0 {
+		return nil, ErrEmptySignal
+	}
+
+	length := len(a) + len(b) - 1
+	size := nextPowerOfTwo(length)
+
+	// Reversing a turns the correlation into a convolution.
+	reversed := make([]complex128, size)
+	for n, x := range a {
+		reversed[len(a)-1-n] = complex(x, 0)
+	}
+	padded := make([]complex128, size)
+	for n, x := range b {
+		padded[n] = complex(x, 0)
+	}
+
+	ra := fft(reversed)
+	pb := fft(padded)
+	for k := range ra {
+		ra[k] *= pb[k]
+	}
+
+	product := ifft(ra)
+	result := make([]float64, length)
+	for i := range result {
+		result[i] = real(product[i])
+	}
+	return result, nil
+}
+
+// Autocorrelation returns the full autocorrelation of x, with 2*len(x)-1
+// values computed directly:
+//
+//	r[l] = Σ x[n] * x[n+l]    for l = -(len(x)-1) … len(x)-1
+//
+// stored at index l+len(x)-1, so the zero lag sits in the middle.
+func Autocorrelation(x []float64) ([]float64, error) {
+	if len(x) == 0 {
+		return nil, ErrEmptySignal
+	}
+
+	result := make([]float64, 2*len(x)-1)
+	for lag := 0; lag < len(x); lag++ {
+		var sum float64
+		for n := 0; n+lag < len(x); n++ {
+			sum += x[n] * x[n+lag]
+		}
+		result[len(x)-1+lag] = sum
+		result[len(x)-1-lag] = sum
+	}
+	return result, nil
+}
+
+// TimeDelay returns how far b lags behind a, taken from the peak of their
+// cross-correlation. The result is rounded to whole samples and is negative
+// when b leads a. Periodic signals are ambiguous by a multiple of their
+// period, so the delay should stay below half a period.
+func TimeDelay(a, b []float64, sampleRate float64) (time.Duration, error) {
+	if sampleRate <= 0 {
+		return 0, ErrInvalidSampleRate
+	}
+
+	correlation, err := CrossCorrelate(a, b)
+	if err != nil {
+		return 0, err
+	}
+
+	peak := 0
+	for i, v := range correlation {
+		if v > correlation[peak] {
+			peak = i
+		}
+	}
+
+	lag := float64(peak - (len(a) - 1))
+	return time.Duration(lag / sampleRate * float64(time.Second)), nil
+}
@@ -0,0 +1,94 @@
+package analysis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ECecillo/lib.go.sound/pkg/sine"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCrossCorrelate_MatchesAutocorrelation(t *testing.T) {
+	x := []float64{0.5, -1.0, 0.25, 0.75, 0, -0.3, 0.9}
+
+	cross, err := CrossCorrelate(x, x)
+	require.NoError(t, err)
+
+	auto, err := Autocorrelation(x)
+	require.NoError(t, err)
+
+	require.Len(t, cross, 2*len(x)-1)
+	require.InDeltaSlice(t, auto, cross, 1e-12)
+}
+
+func TestCrossCorrelate_Direct(t *testing.T) {
+	a := []float64{1, 2, 3}
+	b := []float64{0, 1, 0.5, -1}
+
+	result, err := CrossCorrelate(a, b)
+	require.NoError(t, err)
+	require.Len(t, result, len(a)+len(b)-1)
+
+	for lag := -(len(a) - 1); lag < len(b); lag++ {
+		var expected float64
+		for n := range a {
+			if n+lag >= 0 && n+lag < len(b) {
+				expected += a[n] * b[n+lag]
+			}
+		}
+		require.InDelta(t, expected, result[lag+len(a)-1], 1e-12, "lag %d", lag)
+	}
+}
+
+func TestTimeDelay(t *testing.T) {
+	const sampleRate = 44100.0
+	burst, err := sine.NewSine(50.0, 100*time.Millisecond).Generate()
+	require.NoError(t, err)
+
+	// A Hann-shaped tone burst followed by silence, so the delayed copy is
+	// not truncated.
+	window := HannWindow(len(burst))
+	samples := make([]float64, 2*len(burst))
+	for n := range burst {
+		samples[n] = burst[n] * window[n]
+	}
+
+	tests := []struct {
+		name  string
+		delay int
+	}{
+		{name: "no delay", delay: 0},
+		{name: "3ms", delay: 132},
+		{name: "one sample", delay: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			delayed := make([]float64, len(samples))
+			copy(delayed[tt.delay:], samples)
+
+			delay, err := TimeDelay(samples, delayed, sampleRate)
+			require.NoError(t, err)
+
+			expected := time.Duration(float64(tt.delay) / sampleRate * float64(time.Second))
+			samplePeriod := float64(time.Second) / sampleRate
+			require.InDelta(t, float64(expected), float64(delay), samplePeriod)
+
+			// Swapping the inputs reverses the sign of the lag.
+			lead, err := TimeDelay(delayed, samples, sampleRate)
+			require.NoError(t, err)
+			require.InDelta(t, float64(-expected), float64(lead), samplePeriod)
+		})
+	}
+}
+
+func TestCorrelation_Errors(t *testing.T) {
+	_, err := CrossCorrelate(nil, []float64{1})
+	require.ErrorIs(t, err, ErrEmptySignal)
+
+	_, err = Autocorrelation(nil)
+	require.ErrorIs(t, err, ErrEmptySignal)
+
+	_, err = TimeDelay([]float64{1}, []float64{1}, 0)
+	require.ErrorIs(t, err, ErrInvalidSampleRate)
+}
@@ -0,0 +1,154 @@
+package analysis
+
+import (
+	"errors"
+	"math"
+	"math/cmplx"
+)
+
+// ErrNotPowerOfTwo is returned when a transform input length is not a
+// power of two.
+var ErrNotPowerOfTwo = errors.New("length must be a power of two")
+
+// ErrLengthMismatch is returned when paired inputs do not have the same
+// length.
+var ErrLengthMismatch = errors.New("inputs must have the same length")
+
+// FFT returns the discrete Fourier transform of samples:
+//
+//	X[k] = Σ x[n] * e^(-j*2π*k*n/N)
+//
+// len(samples) must be a power of two.
+func FFT(samples []float64) ([]complex128, error) {
+	if !isPowerOfTwo(len(samples)) {
+		return nil, ErrNotPowerOfTwo
+	}
+
+	input := make([]complex128, len(samples))
+	for n, x := range samples {
+		input[n] = complex(x, 0)
+	}
+
+	return fft(input), nil
+}
+
+// IFFT returns the real part of the inverse discrete Fourier transform of
+// spectrum, the inverse of FFT:
+//
+//	x[n] = 1/N * Σ X[k] * e^(j*2π*k*n/N)
+//
+// len(spectrum) must be a power of two. The imaginary part, which is zero
+// for spectra with Hermitian symmetry, is discarded.
+func IFFT(spectrum []complex128) ([]float64, error) {
+	if !isPowerOfTwo(len(spectrum)) {
+		return nil, ErrNotPowerOfTwo
+	}
+
+	result := ifft(spectrum)
+
+	samples := make([]float64, len(result))
+	for n, x := range result {
+		samples[n] = real(x)
+	}
+	return samples, nil
+}
+
+// Resynthesize rebuilds a signal from the magnitude and phase of each bin
+// of a full-length spectrum, as produced by FFT:
+//
+//	X[k] = magnitude[k] * e^(j*phase[k])
+//
+// Both slices must have the same power-of-two length.
+func Resynthesize(magnitude, phase []float64) ([]float64, error) {
+	if len(magnitude) != len(phase) {
+		return nil, ErrLengthMismatch
+	}
+
+	spectrum := make([]complex128, len(magnitude))
+	for k := range spectrum {
+		spectrum[k] = cmplx.Rect(magnitude[k], phase[k])
+	}
+
+	return IFFT(spectrum)
+}
+
+// fft is a radix-2 Cooley-Tukey FFT. len(x) must be a power of two.
+func fft(x []complex128) []complex128 {
+	n := len(x)
+	if n == 1 {
+		return []complex128{x[0]}
+	}
+
+	even := make([]complex128, n/2)
+	odd := make([]complex128, n/2)
+	for i := range n / 2 {
+		even[i] = x[2*i]
+		odd[i] = x[2*i+1]
+	}
+
+	e := fft(even)
+	o := fft(odd)
+
+	result := make([]complex128, n)
+	for k := range n / 2 {
+		twiddle := cmplx.Exp(complex(0, -2*math.Pi*float64(k)/float64(n))) * o[k]
+		result[k] = e[k] + twiddle
+		result[k+n/2] = e[k] - twiddle
+	}
+	return result
+}
+
+// ifft is the inverse of fft, computed as conj(fft(conj(X))) / N.
+func ifft(x []complex128) []complex128 {
+	n := len(x)
+	conjugated := make([]complex128, n)
+	for k, v := range x {
+		conjugated[k] = cmplx.Conj(v)
+	}
+
+	result := fft(conjugated)
+	for k, v := range result {
+		result[k] = cmplx.Conj(v) / complex(float64(n), 0)
+	}
+	return result
+}
+
+// isPowerOfTwo reports whether n is a positive power of two.
+func isPowerOfTwo(n int) bool {
+	return n > 0 && n&(n-1) == 0
+}
+
+// nextPowerOfTwo returns the smallest power of two greater than or equal
+// to n.
+func nextPowerOfTwo(n int) int {
+	size := 1
+	for size < n {
+		size <<= 1
+	}
+	return size
+}
+
+// magnitudeSpectrum applies a Hann window to samples, zero-pads them to a
+// power of two and returns the magnitudes of the bins from DC up to
+// Nyquist along with the frequency resolution of one bin in Hz.
+func magnitudeSpectrum(samples []float64, sampleRate float64) ([]float64, float64) {
+	size := nextPowerOfTwo(len(samples))
+	input := make([]complex128, size)
+
+	for n, x := range samples {
+		window := 1.0
+		if len(samples) > 1 {
+			window = 0.5 - 0.5*math.Cos(2*math.Pi*float64(n)/float64(len(samples)-1))
+		}
+		input[n] = complex(x*window, 0)
+	}
+
+	spectrum := fft(input)
+
+	magnitudes := make([]float64, size/2+1)
+	for k := range magnitudes {
+		magnitudes[k] = cmplx.Abs(spectrum[k])
+	}
+
+	return magnitudes, sampleRate / float64(size)
+}
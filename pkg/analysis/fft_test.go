@@ -0,0 +1,93 @@
+package analysis
+
+import (
+	"math"
+	"math/cmplx"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFFT_SineBin(t *testing.T) {
+	samples := make([]float64, 64)
+	for n := range samples {
+		samples[n] = math.Cos(2 * math.Pi * 4 * float64(n) / 64)
+	}
+
+	spectrum, err := FFT(samples)
+	require.NoError(t, err)
+
+	for k, x := range spectrum {
+		switch k {
+		case 4, 60:
+			require.InDelta(t, 32.0, cmplx.Abs(x), 1e-9)
+		default:
+			require.InDelta(t, 0.0, cmplx.Abs(x), 1e-9)
+		}
+	}
+}
+
+func TestIFFT_RoundTrip(t *testing.T) {
+	rng := rand.New(rand.NewPCG(1, 2))
+
+	for _, size := range []int{1, 2, 8, 256, 4096} {
+		samples := make([]float64, size)
+		for n := range samples {
+			samples[n] = rng.Float64()*2 - 1
+		}
+
+		spectrum, err := FFT(samples)
+		require.NoError(t, err)
+
+		restored, err := IFFT(spectrum)
+		require.NoError(t, err)
+		require.Len(t, restored, size)
+
+		for n := range samples {
+			require.InDelta(t, samples[n], restored[n], 1e-10)
+		}
+	}
+}
+
+func TestResynthesize(t *testing.T) {
+	rng := rand.New(rand.NewPCG(3, 4))
+	samples := make([]float64, 512)
+	for n := range samples {
+		samples[n] = rng.Float64()*2 - 1
+	}
+
+	spectrum, err := FFT(samples)
+	require.NoError(t, err)
+
+	magnitude := make([]float64, len(spectrum))
+	phase := make([]float64, len(spectrum))
+	for k, x := range spectrum {
+		magnitude[k], phase[k] = cmplx.Polar(x)
+	}
+
+	restored, err := Resynthesize(magnitude, phase)
+	require.NoError(t, err)
+	require.Len(t, restored, len(samples))
+
+	for n := range samples {
+		require.InDelta(t, samples[n], restored[n], 1e-10)
+	}
+}
+
+func TestTransforms_InvalidLength(t *testing.T) {
+	_, err := FFT(make([]float64, 100))
+	require.ErrorIs(t, err, ErrNotPowerOfTwo)
+
+	_, err = FFT(nil)
+	require.ErrorIs(t, err, ErrNotPowerOfTwo)
+
+	_, err = IFFT(make([]complex128, 3))
+	require.ErrorIs(t, err, ErrNotPowerOfTwo)
+
+	_, err = Resynthesize(make([]float64, 4), make([]float64, 8))
+	require.ErrorIs(t, err, ErrLengthMismatch)
+
+	_, err = Resynthesize(make([]float64, 6), make([]float64, 6))
+	require.ErrorIs(t, err, ErrNotPowerOfTwo)
+}
@@ -0,0 +1,30 @@
+package analysis
+
+import (
+	"math"
+)
+
+// Goertzel returns the magnitude of the DFT of samples at targetFreq, the
+// same value as |FFT(samples)[k]| when targetFreq falls on bin k, without
+// computing the other bins. Each sample costs one multiplication and two
+// additions in the recurrence
+//
+//	s[n] = x[n] + 2*cos(ω)*s[n-1] - s[n-2],  ω = 2π*targetFreq/sampleRate
+//
+// and the magnitude is read from the last two states:
+//
+//	|X|² = s[N-1]² + s[N-2]² - 2*cos(ω)*s[N-1]*s[N-2]
+//
+// targetFreq does not have to fall on a bin. A sine of amplitude A at
+// targetFreq gives a magnitude of about A*N/2.
+func Goertzel(samples []float64, targetFreq, sampleRate float64) float64 {
+	coeff := 2 * math.Cos(2*math.Pi*targetFreq/sampleRate)
+
+	var s1, s2 float64
+	for _, x := range samples {
+		s1, s2 = x+coeff*s1-s2, s1
+	}
+
+	power := s1*s1 + s2*s2 - coeff*s1*s2
+	return math.Sqrt(math.Max(power, 0))
+}
@@ -0,0 +1,71 @@
+package analysis
+
+import (
+	"math/cmplx"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGoertzel_ProportionalToAmplitude(t *testing.T) {
+	sampleRate := 44100.0
+	size := 4410
+
+	for _, amplitude := range []float64{1, 0.5, 0.1} {
+		samples := sineWave(440, sampleRate, size)
+		for n := range samples {
+			samples[n] *= amplitude
+		}
+
+		magnitude := Goertzel(samples, 440, sampleRate)
+		require.InDelta(t, amplitude*float64(size)/2, magnitude, 1e-6*float64(size))
+	}
+}
+
+func TestGoertzel_OtherFrequency(t *testing.T) {
+	sampleRate := 44100.0
+	size := 4410
+	samples := sineWave(440, sampleRate, size)
+
+	// 10 Hz resolution: 1000 Hz and 450 Hz are exact bins away from 440 Hz.
+	require.Less(t, Goertzel(samples, 1000, sampleRate), 1e-6*float64(size))
+	require.Less(t, Goertzel(samples, 450, sampleRate), 1e-6*float64(size))
+}
+
+func TestGoertzel_MatchesFFT(t *testing.T) {
+	sampleRate := 8000.0
+	samples := echoed(4096-100, 100, 0.5)
+
+	spectrum, err := FFT(samples)
+	require.NoError(t, err)
+
+	binWidth := sampleRate / float64(len(samples))
+	for _, k := range []int{0, 1, 17, 500, 2047} {
+		require.InDelta(t, cmplx.Abs(spectrum[k]), Goertzel(samples, float64(k)*binWidth, sampleRate), 1e-6)
+	}
+}
+
+func TestGoertzel_Empty(t *testing.T) {
+	require.Zero(t, Goertzel(nil, 440, 44100))
+}
+
+func BenchmarkGoertzel(b *testing.B) {
+	samples := sineWave(440, 44100, 4096)
+
+	b.Run("Goertzel", func(b *testing.B) {
+		for b.Loop() {
+			Goertzel(samples, 440, 44100)
+		}
+	})
+
+	b.Run("FFT", func(b *testing.B) {
+		bin := 41 // 440 Hz at 44100 Hz with 4096 bins
+		for b.Loop() {
+			spectrum, err := FFT(samples)
+			if err != nil {
+				b.Fatal(err)
+			}
+			_ = cmplx.Abs(spectrum[bin])
+		}
+	})
+}
@@ -0,0 +1,102 @@
+package analysis
+
+import (
+	"errors"
+	"math"
+	"math/cmplx"
+)
+
+// ErrInvalidHarmonicParameters is returned by HarmonicAmplitudes when the
+// fundamental or the number of harmonics is not positive.
+var ErrInvalidHarmonicParameters = errors.New("fundamental and maxHarmonic must be positive")
+
+// harmonicSearchBins is how many bins on each side of the expected
+// position of a harmonic are searched for its peak.
+const harmonicSearchBins = 2
+
+// HarmonicAmplitudes returns the amplitudes of the first maxHarmonic
+// harmonics of fundamental in samples, [H1, H2, ..., Hmax], in the same
+// unit as the samples: a full-scale sine yields 1.
+//
+// The samples are Hann windowed and zero-padded to a power of two. Each
+// harmonic is located at the largest bin near h*fundamental, its exact
+// frequency is refined by fitting a parabola through the log magnitudes of
+// that bin and its neighbours, and the windowed DFT is then evaluated at
+// that fractional bin, which removes the scalloping loss of harmonics
+// falling between bins. Harmonics at or above Nyquist are 0.
+func HarmonicAmplitudes(samples []float64, fundamental float64, sampleRate float64, maxHarmonic int) ([]float64, error) {
+	if len(samples) == 0 {
+		return nil, ErrSilentSignal
+	}
+	if fundamental <= 0 || maxHarmonic <= 0 {
+		return nil, ErrInvalidHarmonicParameters
+	}
+
+	size := nextPowerOfTwo(len(samples))
+	window := HannWindow(len(samples))
+	input := make([]complex128, size)
+
+	var windowSum float64
+	for n, x := range samples {
+		input[n] = complex(x*window[n], 0)
+		windowSum += window[n]
+	}
+
+	spectrum := fft(input)
+	magnitudes := make([]float64, size/2+1)
+	for k := range magnitudes {
+		magnitudes[k] = cmplx.Abs(spectrum[k])
+	}
+
+	binWidth := sampleRate / float64(size)
+	amplitudes := make([]float64, maxHarmonic)
+
+	for h := range amplitudes {
+		expected := int(math.Round(float64(h+1) * fundamental / binWidth))
+		if expected >= len(magnitudes)-1 {
+			break
+		}
+
+		peak := expected
+		for k := max(1, expected-harmonicSearchBins); k <= min(len(magnitudes)-2, expected+harmonicSearchBins); k++ {
+			if magnitudes[k] > magnitudes[peak] {
+				peak = k
+			}
+		}
+
+		freq := (float64(peak) + peakOffset(magnitudes, peak)) * binWidth
+		amplitudes[h] = 2 * cmplx.Abs(dftAt(samples, window, freq/sampleRate)) / windowSum
+	}
+
+	return amplitudes, nil
+}
+
+// peakOffset fits a parabola through the log magnitudes of bins k-1, k
+// and k+1 and returns the position of its vertex relative to k, in bins.
+func peakOffset(magnitudes []float64, k int) float64 {
+	if k <= 0 || k >= len(magnitudes)-1 {
+		return 0
+	}
+
+	a := math.Log(math.Max(magnitudes[k-1], logFloor))
+	b := math.Log(math.Max(magnitudes[k], logFloor))
+	c := math.Log(math.Max(magnitudes[k+1], logFloor))
+
+	denominator := a - 2*b + c
+	if denominator >= 0 {
+		// Not a local maximum, e.g. a missing harmonic in the noise floor.
+		return 0
+	}
+
+	return 0.5 * (a - c) / denominator
+}
+
+// dftAt evaluates the DFT of the windowed samples at the normalized
+// frequency f (cycles per sample), which need not fall on a bin.
+func dftAt(samples, window []float64, f float64) complex128 {
+	var sum complex128
+	for n, x := range samples {
+		sum += complex(x*window[n], 0) * cmplx.Exp(complex(0, -2*math.Pi*f*float64(n)))
+	}
+	return sum
+}
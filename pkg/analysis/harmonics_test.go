@@ -0,0 +1,92 @@
+package analysis
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ECecillo/lib.go.sound/pkg/harmonics"
+)
+
+func sineWave(freq, sampleRate float64, n int) []float64 {
+	samples := make([]float64, n)
+	for i := range samples {
+		samples[i] = math.Sin(2 * math.Pi * freq * float64(i) / sampleRate)
+	}
+	return samples
+}
+
+func TestHarmonicAmplitudes_PureSine(t *testing.T) {
+	sampleRate := 44100.0
+	samples := sineWave(440, sampleRate, 8192)
+
+	amplitudes, err := HarmonicAmplitudes(samples, 440, sampleRate, 6)
+	require.NoError(t, err)
+	require.Len(t, amplitudes, 6)
+
+	require.InDelta(t, 1.0, amplitudes[0], 0.01)
+	for _, a := range amplitudes[1:] {
+		require.InDelta(t, 0.0, a, 0.001)
+	}
+}
+
+func TestHarmonicAmplitudes_SubBinAccuracy(t *testing.T) {
+	sampleRate := 44100.0
+
+	// Sweep the fundamental across a bin so it lands between bins.
+	for _, freq := range []float64{1000, 1001.3, 1002.7, 1003.9} {
+		samples := sineWave(freq, sampleRate, 4096)
+		for n := range samples {
+			samples[n] *= 0.5
+		}
+
+		amplitudes, err := HarmonicAmplitudes(samples, freq, sampleRate, 1)
+		require.NoError(t, err)
+		require.InDelta(t, 0.5, amplitudes[0], 0.01, "frequency %.1f Hz", freq)
+	}
+}
+
+func TestHarmonicAmplitudes_SquareSeries(t *testing.T) {
+	sampleRate := 44100.0
+	fundamental := 220.0
+
+	square := harmonics.NewSquareFromSeries(fundamental, 10, 200*time.Millisecond, harmonics.WithSamplingRate(sampleRate))
+	samples, err := square.Generate()
+	require.NoError(t, err)
+
+	amplitudes, err := HarmonicAmplitudes(samples, fundamental, sampleRate, 9)
+	require.NoError(t, err)
+
+	// Relative to the fundamental the Fourier coefficients are 1/h for odd h.
+	for h, a := range amplitudes {
+		n := float64(h + 1)
+		want := 0.0
+		if (h+1)%2 == 1 {
+			want = 1 / n
+		}
+		require.InDelta(t, want, a/amplitudes[0], 0.01, "harmonic %d", h+1)
+	}
+	require.InDelta(t, 4/math.Pi, amplitudes[0], 0.02)
+}
+
+func TestHarmonicAmplitudes_AboveNyquist(t *testing.T) {
+	amplitudes, err := HarmonicAmplitudes(sineWave(3000, 8000, 2048), 3000, 8000, 3)
+	require.NoError(t, err)
+
+	require.InDelta(t, 1.0, amplitudes[0], 0.01)
+	require.Zero(t, amplitudes[1])
+	require.Zero(t, amplitudes[2])
+}
+
+func TestHarmonicAmplitudes_Errors(t *testing.T) {
+	_, err := HarmonicAmplitudes(nil, 440, 44100, 4)
+	require.ErrorIs(t, err, ErrSilentSignal)
+
+	_, err = HarmonicAmplitudes(make([]float64, 16), 0, 44100, 4)
+	require.ErrorIs(t, err, ErrInvalidHarmonicParameters)
+
+	_, err = HarmonicAmplitudes(make([]float64, 16), 440, 44100, 0)
+	require.ErrorIs(t, err, ErrInvalidHarmonicParameters)
+}
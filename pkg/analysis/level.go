@@ -0,0 +1,31 @@
+package analysis
+
+import "math"
+
+// Peak returns the largest absolute sample value, as a linear amplitude
+// where 1.0 is full scale. An empty signal returns 0. loudness.Peak gives
+// the same measure in dBFS.
+func Peak(samples []float64) float64 {
+	peak := 0.0
+	for _, x := range samples {
+		peak = math.Max(peak, math.Abs(x))
+	}
+	return peak
+}
+
+// RMS returns the root mean square of samples:
+//
+//	RMS = sqrt(Σ x² / N)
+//
+// An empty signal returns 0.
+func RMS(samples []float64) float64 {
+	if len(samples) == 0 {
+		return 0
+	}
+
+	sumSquares := 0.0
+	for _, x := range samples {
+		sumSquares += x * x
+	}
+	return math.Sqrt(sumSquares / float64(len(samples)))
+}
@@ -0,0 +1,26 @@
+package analysis
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPeak(t *testing.T) {
+	require.Equal(t, 0.75, Peak([]float64{0.1, -0.75, 0.5}))
+	require.Equal(t, 0.5, Peak([]float64{0.5, -0.5}))
+	require.Zero(t, Peak(nil))
+}
+
+func TestRMS(t *testing.T) {
+	require.InDelta(t, 0.5, RMS([]float64{0.5, -0.5, 0.5}), 1e-12)
+	require.InDelta(t, math.Sqrt((1+0.25)/2), RMS([]float64{1, -0.5}), 1e-12)
+	require.Zero(t, RMS(nil))
+
+	sine := make([]float64, 1000)
+	for n := range sine {
+		sine[n] = math.Sin(2 * math.Pi * float64(n) / 100)
+	}
+	require.InDelta(t, 1/math.Sqrt2, RMS(sine), 1e-9)
+}
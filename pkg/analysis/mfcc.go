@@ -0,0 +1,73 @@
+package analysis
+
+import (
+	"errors"
+	"math"
+)
+
+// ErrInvalidMFCCParameters is returned by MFCCs when the number of
+// coefficients or filters is not positive, or when more coefficients than
+// filters are requested.
+var ErrInvalidMFCCParameters = errors.New("MFCC requires 0 < numCoeffs <= numFilters")
+
+// logFloor keeps the logarithm finite for filters that receive no energy.
+const logFloor = 1e-10
+
+// MFCCs returns the first numCoeffs Mel-frequency cepstral coefficients of
+// samples. The magnitude spectrum is weighted by numFilters triangular
+// filters spaced evenly on the Mel scale between 0 Hz and sampleRate/2, the
+// log of each filter energy is taken, and a DCT-II decorrelates them.
+func MFCCs(samples []float64, sampleRate float64, numCoeffs, numFilters int) ([]float64, error) {
+	if numCoeffs <= 0 || numFilters <= 0 || numCoeffs > numFilters {
+		return nil, ErrInvalidMFCCParameters
+	}
+	if len(samples) == 0 {
+		return nil, ErrSilentSignal
+	}
+
+	magnitudes, binWidth := magnitudeSpectrum(samples, sampleRate)
+
+	// numFilters triangles need numFilters+2 edges on the Mel scale.
+	maxMel := hzToMel(sampleRate / 2)
+	edges := make([]float64, numFilters+2)
+	for i := range edges {
+		edges[i] = melToHz(maxMel * float64(i) / float64(numFilters+1))
+	}
+
+	logEnergies := make([]float64, numFilters)
+	for m := range numFilters {
+		lower, center, upper := edges[m], edges[m+1], edges[m+2]
+
+		var energy float64
+		for k, magnitude := range magnitudes {
+			freq := float64(k) * binWidth
+			switch {
+			case freq > lower && freq <= center:
+				energy += magnitude * (freq - lower) / (center - lower)
+			case freq > center && freq < upper:
+				energy += magnitude * (upper - freq) / (upper - center)
+			}
+		}
+
+		logEnergies[m] = math.Log(math.Max(energy, logFloor))
+	}
+
+	coeffs := make([]float64, numCoeffs)
+	for n := range coeffs {
+		for m, e := range logEnergies {
+			coeffs[n] += e * math.Cos(math.Pi*float64(n)*(float64(m)+0.5)/float64(numFilters))
+		}
+	}
+
+	return coeffs, nil
+}
+
+// hzToMel converts a frequency to the Mel scale (O'Shaughnessy).
+func hzToMel(hz float64) float64 {
+	return 2595 * math.Log10(1+hz/700)
+}
+
+// melToHz is the inverse of hzToMel.
+func melToHz(mel float64) float64 {
+	return 700 * (math.Pow(10, mel/2595) - 1)
+}
@@ -0,0 +1,62 @@
+package analysis
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/ECecillo/lib.go.sound/pkg/sine"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMFCCs_Deterministic(t *testing.T) {
+	samples, err := sine.NewSine(440.0, 100*time.Millisecond).Generate()
+	require.NoError(t, err)
+
+	first, err := MFCCs(samples, 44100.0, 13, 26)
+	require.NoError(t, err)
+	second, err := MFCCs(samples, 44100.0, 13, 26)
+	require.NoError(t, err)
+
+	require.Len(t, first, 13)
+	require.Equal(t, first, second)
+}
+
+func TestMFCCs_DistinguishesPitch(t *testing.T) {
+	a4, err := sine.NewSine(440.0, 100*time.Millisecond).Generate()
+	require.NoError(t, err)
+	a5, err := sine.NewSine(880.0, 100*time.Millisecond).Generate()
+	require.NoError(t, err)
+
+	low, err := MFCCs(a4, 44100.0, 13, 26)
+	require.NoError(t, err)
+	high, err := MFCCs(a5, 44100.0, 13, 26)
+	require.NoError(t, err)
+
+	different := 0
+	for i := range low {
+		if math.Abs(low[i]-high[i]) > 1.0 {
+			different++
+		}
+	}
+	require.GreaterOrEqual(t, different, 3)
+}
+
+func TestMFCCs_MelScale(t *testing.T) {
+	require.InDelta(t, 1000.0, hzToMel(1000.0), 0.5, "1 kHz sits close to 1000 mel")
+	for _, hz := range []float64{0, 440, 8000, 22050} {
+		require.InDelta(t, hz, melToHz(hzToMel(hz)), 1e-9)
+	}
+}
+
+func TestMFCCs_Errors(t *testing.T) {
+	samples := make([]float64, 1024)
+
+	for _, params := range [][2]int{{0, 26}, {13, 0}, {27, 26}, {-1, 26}} {
+		_, err := MFCCs(samples, 44100.0, params[0], params[1])
+		require.ErrorIs(t, err, ErrInvalidMFCCParameters, "params %v", params)
+	}
+
+	_, err := MFCCs(nil, 44100.0, 13, 26)
+	require.ErrorIs(t, err, ErrSilentSignal)
+}
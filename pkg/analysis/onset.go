@@ -0,0 +1,67 @@
+package analysis
+
+import (
+	"math"
+)
+
+// SpectralFlux returns one onset strength value per hop. Each frame of
+// frameSize samples is windowed and transformed, and the flux is the sum of
+// the magnitude increases from the previous frame:
+//
+//	flux[i] = Σ max(0, |X_i[k]| - |X_{i-1}[k]|)
+//
+// Keeping only the increases (half-wave rectification) makes the function
+// react to notes starting rather than ending. The first frame is compared
+// against silence. It returns nil when frameSize or hopSize is not positive
+// or when samples is shorter than one frame.
+func SpectralFlux(samples []float64, sampleRate float64, frameSize, hopSize int) []float64 {
+	if frameSize <= 0 || hopSize <= 0 || len(samples) < frameSize {
+		return nil
+	}
+
+	var flux []float64
+	var previous []float64
+
+	for start := 0; start+frameSize <= len(samples); start += hopSize {
+		magnitudes, _ := magnitudeSpectrum(samples[start:start+frameSize], sampleRate)
+		if previous == nil {
+			previous = make([]float64, len(magnitudes))
+		}
+
+		var sum float64
+		for k, m := range magnitudes {
+			sum += math.Max(0, m-previous[k])
+		}
+
+		flux = append(flux, sum)
+		previous = magnitudes
+	}
+
+	return flux
+}
+
+// PickOnsets returns the indexes of the local maxima of flux that rise
+// above threshold. Peaks closer than minInterval hops to the previous onset
+// are discarded.
+func PickOnsets(flux []float64, threshold, minInterval float64) []int {
+	var onsets []int
+
+	for i, v := range flux {
+		if v <= threshold {
+			continue
+		}
+		if i > 0 && v < flux[i-1] {
+			continue
+		}
+		if i < len(flux)-1 && v <= flux[i+1] {
+			continue
+		}
+		if len(onsets) > 0 && float64(i-onsets[len(onsets)-1]) < minInterval {
+			continue
+		}
+
+		onsets = append(onsets, i)
+	}
+
+	return onsets
+}
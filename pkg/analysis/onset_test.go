@@ -0,0 +1,70 @@
+package analysis
+
+import (
+	"math"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/ECecillo/lib.go.sound/pkg/sine"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpectralFlux_Beeps(t *testing.T) {
+	// Eight 50ms beeps starting every 125ms, separated by silence.
+	const sampleRate = 44100.0
+	samples := make([]float64, int(sampleRate))
+
+	beep, err := sine.NewSine(1000.0, 50*time.Millisecond).Generate()
+	require.NoError(t, err)
+
+	// Fade the beeps out so their end does not splatter into a click that
+	// would register as an onset of its own.
+	fade := len(beep) / 2
+	for i := range fade {
+		beep[len(beep)-1-i] *= 0.5 - 0.5*math.Cos(math.Pi*float64(i)/float64(fade))
+	}
+
+	spacing := len(samples) / 8
+	for b := range 8 {
+		copy(samples[b*spacing:], beep)
+	}
+
+	hopSize := 512
+	flux := SpectralFlux(samples, sampleRate, 1024, hopSize)
+	require.NotEmpty(t, flux)
+
+	onsets := PickOnsets(flux, 0.3*slices.Max(flux), 4)
+	require.Len(t, onsets, 8)
+
+	// Each onset lands within two hops of the beep start.
+	for b, onset := range onsets {
+		require.InDelta(t, b*spacing, onset*hopSize, float64(2*hopSize), "onset %d", b)
+	}
+}
+
+func TestSpectralFlux_InvalidParameters(t *testing.T) {
+	samples := make([]float64, 1024)
+
+	require.Nil(t, SpectralFlux(samples, 44100.0, 0, 512))
+	require.Nil(t, SpectralFlux(samples, 44100.0, 1024, 0))
+	require.Nil(t, SpectralFlux(samples, 44100.0, 2048, 512))
+}
+
+func TestSpectralFlux_SilenceHasNoFlux(t *testing.T) {
+	flux := SpectralFlux(make([]float64, 8192), 44100.0, 1024, 512)
+
+	require.Len(t, flux, 15)
+	for _, v := range flux {
+		require.Zero(t, v)
+	}
+}
+
+func TestPickOnsets(t *testing.T) {
+	flux := []float64{0, 5, 1, 0, 3, 4, 2, 0, 6, 0, 7, 0}
+
+	require.Equal(t, []int{1, 5, 8, 10}, PickOnsets(flux, 2, 1))
+	require.Equal(t, []int{1, 5, 8}, PickOnsets(flux, 2, 3), "10 is too close to 8")
+	require.Equal(t, []int{8, 10}, PickOnsets(flux, 5, 1))
+	require.Empty(t, PickOnsets(flux, 10, 1))
+}
@@ -0,0 +1,35 @@
+package analysis
+
+import (
+	"math"
+	"math/cmplx"
+	"math/rand/v2"
+)
+
+// RandomizePhase returns a signal with the same magnitude spectrum as
+// samples but with the phase of every bin replaced by a random value drawn
+// from seed. Bins k and N-k get opposite phases so the spectrum keeps its
+// conjugate symmetry and the output stays real:
+//
+//	Y[k] = |X[k]| * e^(jφ_k),    Y[N-k] = conj(Y[k])
+//
+// The DC and Nyquist bins must stay real and are left untouched. The same
+// seed always produces the same output. len(samples) must be a power of
+// two.
+func RandomizePhase(samples []float64, seed int64) ([]float64, error) {
+	spectrum, err := FFT(samples)
+	if err != nil {
+		return nil, err
+	}
+
+	rng := rand.New(rand.NewPCG(uint64(seed), uint64(seed)))
+	n := len(spectrum)
+
+	for k := 1; k < (n+1)/2; k++ {
+		phase := rng.Float64() * 2 * math.Pi
+		spectrum[k] = cmplx.Rect(cmplx.Abs(spectrum[k]), phase)
+		spectrum[n-k] = cmplx.Conj(spectrum[k])
+	}
+
+	return IFFT(spectrum)
+}
@@ -0,0 +1,51 @@
+package analysis
+
+import (
+	"math/cmplx"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRandomizePhase_PreservesMagnitude(t *testing.T) {
+	samples := sineWave(440, 44100, 1024)
+	for n := range samples {
+		samples[n] += 0.3 * float64(n%7) / 7
+	}
+
+	for _, seed := range []int64{0, 1, 42, -7} {
+		randomized, err := RandomizePhase(samples, seed)
+		require.NoError(t, err)
+		require.Len(t, randomized, len(samples))
+
+		input, err := FFT(samples)
+		require.NoError(t, err)
+		output, err := FFT(randomized)
+		require.NoError(t, err)
+
+		for k := range input {
+			require.InDelta(t, cmplx.Abs(input[k]), cmplx.Abs(output[k]), 1e-10, "seed %d bin %d", seed, k)
+		}
+
+		require.NotEqual(t, samples, randomized)
+	}
+}
+
+func TestRandomizePhase_Seed(t *testing.T) {
+	samples := sineWave(1000, 44100, 256)
+
+	first, err := RandomizePhase(samples, 3)
+	require.NoError(t, err)
+	second, err := RandomizePhase(samples, 3)
+	require.NoError(t, err)
+	other, err := RandomizePhase(samples, 4)
+	require.NoError(t, err)
+
+	require.Equal(t, first, second)
+	require.NotEqual(t, first, other)
+}
+
+func TestRandomizePhase_NotPowerOfTwo(t *testing.T) {
+	_, err := RandomizePhase(make([]float64, 100), 1)
+	require.ErrorIs(t, err, ErrNotPowerOfTwo)
+}
@@ -0,0 +1,111 @@
+package analysis
+
+import (
+	"errors"
+)
+
+// ErrSilentSignal is returned when a signal carries no spectral energy.
+var ErrSilentSignal = errors.New("signal is silent")
+
+// ErrInvalidThreshold is returned by SpectralRolloff when the threshold is
+// outside (0, 1].
+var ErrInvalidThreshold = errors.New("threshold must be in (0, 1]")
+
+// SpectralCentroid returns the magnitude-weighted mean frequency of the
+// spectrum of samples, in Hz:
+//
+//	centroid = Σ f[k]*|X[k]| / Σ |X[k]|
+//
+// It correlates with the perceived brightness of a sound.
+func SpectralCentroid(samples []float64, sampleRate float64) (float64, error) {
+	if len(samples) == 0 {
+		return 0, ErrSilentSignal
+	}
+
+	magnitudes, binWidth := magnitudeSpectrum(samples, sampleRate)
+
+	var weighted, total float64
+	for k, m := range magnitudes {
+		weighted += float64(k) * binWidth * m
+		total += m
+	}
+
+	if total == 0 {
+		return 0, ErrSilentSignal
+	}
+
+	return weighted / total, nil
+}
+
+// SpectralRolloff returns the frequency in Hz below which the given
+// fraction (e.g. 0.85) of the total spectral energy is contained.
+func SpectralRolloff(samples []float64, sampleRate float64, threshold float64) (float64, error) {
+	if threshold <= 0 || threshold > 1 {
+		return 0, ErrInvalidThreshold
+	}
+	if len(samples) == 0 {
+		return 0, ErrSilentSignal
+	}
+
+	magnitudes, binWidth := magnitudeSpectrum(samples, sampleRate)
+
+	var total float64
+	for _, m := range magnitudes {
+		total += m * m
+	}
+
+	if total == 0 {
+		return 0, ErrSilentSignal
+	}
+
+	var cumulative float64
+	for k, m := range magnitudes {
+		cumulative += m * m
+		if cumulative >= threshold*total {
+			return float64(k) * binWidth, nil
+		}
+	}
+
+	return float64(len(magnitudes)-1) * binWidth, nil
+}
+
+// PeakFrequency returns the frequency in Hz of the strongest component of
+// samples, ignoring DC. The peak bin is refined with a parabola through
+// its neighbours:
+//
+//	δ = (m[k-1] - m[k+1]) / (2*(m[k-1] - 2*m[k] + m[k+1]))
+//	f = (k + δ) * binWidth
+//
+// which locates a stable sine well within one bin.
+func PeakFrequency(samples []float64, sampleRate float64) (float64, error) {
+	if sampleRate <= 0 {
+		return 0, ErrInvalidSampleRate
+	}
+	if len(samples) == 0 {
+		return 0, ErrSilentSignal
+	}
+
+	magnitudes, binWidth := magnitudeSpectrum(samples, sampleRate)
+
+	peak := 1
+	for k := 2; k < len(magnitudes); k++ {
+		if magnitudes[k] > magnitudes[peak] {
+			peak = k
+		}
+	}
+
+	if peak >= len(magnitudes) || magnitudes[peak] == 0 {
+		return 0, ErrSilentSignal
+	}
+	if peak == len(magnitudes)-1 {
+		return float64(peak) * binWidth, nil
+	}
+
+	left, center, right := magnitudes[peak-1], magnitudes[peak], magnitudes[peak+1]
+	offset := 0.0
+	if denominator := left - 2*center + right; denominator != 0 {
+		offset = 0.5 * (left - right) / denominator
+	}
+
+	return (float64(peak) + offset) * binWidth, nil
+}
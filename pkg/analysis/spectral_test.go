@@ -0,0 +1,101 @@
+package analysis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ECecillo/lib.go.sound/pkg/sine"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpectralCentroid_Sine(t *testing.T) {
+	samples, err := sine.NewSine(440.0, time.Second).Generate()
+	require.NoError(t, err)
+
+	centroid, err := SpectralCentroid(samples, 44100.0)
+	require.NoError(t, err)
+	require.InDelta(t, 440.0, centroid, 5.0)
+}
+
+func TestSpectralCentroid_Brightness(t *testing.T) {
+	low, err := sine.NewSine(200.0, 500*time.Millisecond).Generate()
+	require.NoError(t, err)
+	high, err := sine.NewSine(200.0, 500*time.Millisecond).Generate()
+	require.NoError(t, err)
+
+	partial, err := sine.NewSine(4000.0, 500*time.Millisecond, sine.WithAmplitude(0.5)).Generate()
+	require.NoError(t, err)
+	for i := range high {
+		high[i] += partial[i]
+	}
+
+	lowCentroid, err := SpectralCentroid(low, 44100.0)
+	require.NoError(t, err)
+	highCentroid, err := SpectralCentroid(high, 44100.0)
+	require.NoError(t, err)
+
+	require.Greater(t, highCentroid, lowCentroid)
+}
+
+func TestSpectralCentroid_Silence(t *testing.T) {
+	_, err := SpectralCentroid(make([]float64, 1024), 44100.0)
+	require.ErrorIs(t, err, ErrSilentSignal)
+
+	_, err = SpectralCentroid(nil, 44100.0)
+	require.ErrorIs(t, err, ErrSilentSignal)
+}
+
+func TestSpectralRolloff(t *testing.T) {
+	samples, err := sine.NewSine(1000.0, time.Second).Generate()
+	require.NoError(t, err)
+
+	rolloff, err := SpectralRolloff(samples, 44100.0, 0.85)
+	require.NoError(t, err)
+	require.InDelta(t, 1000.0, rolloff, 5.0)
+
+	// Adding a strong high partial moves most of the energy upwards.
+	partial, err := sine.NewSine(8000.0, time.Second, sine.WithAmplitude(2.0)).Generate()
+	require.NoError(t, err)
+	for i := range samples {
+		samples[i] += partial[i]
+	}
+
+	rolloff, err = SpectralRolloff(samples, 44100.0, 0.85)
+	require.NoError(t, err)
+	require.InDelta(t, 8000.0, rolloff, 5.0)
+}
+
+func TestSpectralRolloff_Errors(t *testing.T) {
+	samples, err := sine.NewSine(1000.0, 100*time.Millisecond).Generate()
+	require.NoError(t, err)
+
+	for _, threshold := range []float64{0, -0.5, 1.5} {
+		_, err := SpectralRolloff(samples, 44100.0, threshold)
+		require.ErrorIs(t, err, ErrInvalidThreshold)
+	}
+
+	_, err = SpectralRolloff(make([]float64, 256), 44100.0, 0.85)
+	require.ErrorIs(t, err, ErrSilentSignal)
+}
+
+func TestPeakFrequency(t *testing.T) {
+	for _, freq := range []float64{55.0, 440.0, 1234.5} {
+		samples, err := sine.NewSine(freq, time.Second).Generate()
+		require.NoError(t, err)
+
+		peak, err := PeakFrequency(samples, 44100.0)
+		require.NoError(t, err)
+		require.InDelta(t, freq, peak, 0.5, "frequency %v", freq)
+	}
+}
+
+func TestPeakFrequency_Errors(t *testing.T) {
+	_, err := PeakFrequency(make([]float64, 256), 44100.0)
+	require.ErrorIs(t, err, ErrSilentSignal)
+
+	_, err = PeakFrequency(nil, 44100.0)
+	require.ErrorIs(t, err, ErrSilentSignal)
+
+	_, err = PeakFrequency(make([]float64, 256), 0)
+	require.ErrorIs(t, err, ErrInvalidSampleRate)
+}
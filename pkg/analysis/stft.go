@@ -0,0 +1,105 @@
+package analysis
+
+import (
+	"errors"
+	"math"
+)
+
+// ErrInvalidHopSize is returned when the STFT hop size is outside
+// (0, windowSize].
+var ErrInvalidHopSize = errors.New("hop size must be in (0, windowSize]")
+
+// HannWindow returns a periodic Hann window of the given size:
+//
+//	w[n] = 0.5 - 0.5*cos(2π*n/size)
+//
+// Shifted copies of it sum to a constant at 50% and 75% overlap, which
+// makes it the usual choice for STFT analysis and resynthesis.
+func HannWindow(size int) []float64 {
+	window := make([]float64, size)
+	for n := range window {
+		window[n] = 0.5 - 0.5*math.Cos(2*math.Pi*float64(n)/float64(size))
+	}
+	return window
+}
+
+// STFT returns the short-time Fourier transform of samples. Frame i is
+// centered on sample i*hopSize, multiplied by window and transformed with
+// an FFT of windowSize bins; samples outside the signal are taken as zero.
+// There are ceil(len(samples)/hopSize) frames.
+//
+// windowSize must be a power of two and len(window) must equal windowSize.
+func STFT(samples []float64, windowSize, hopSize int, window []float64) ([][]complex128, error) {
+	if err := validateSTFT(windowSize, hopSize, window); err != nil {
+		return nil, err
+	}
+
+	numFrames := (len(samples) + hopSize - 1) / hopSize
+	frames := make([][]complex128, numFrames)
+
+	for i := range frames {
+		start := i*hopSize - windowSize/2
+		frame := make([]complex128, windowSize)
+
+		for n := range frame {
+			if idx := start + n; idx >= 0 && idx < len(samples) {
+				frame[n] = complex(samples[idx]*window[n], 0)
+			}
+		}
+
+		frames[i] = fft(frame)
+	}
+
+	return frames, nil
+}
+
+// ISTFT rebuilds numSamples samples from frames produced by STFT with the
+// same windowSize, hopSize and window. Each frame is inverse transformed,
+// multiplied by the window again and overlap-added; the result is divided
+// by the summed squared window so that the round trip is exact wherever
+// the window covers the signal.
+func ISTFT(frames [][]complex128, windowSize, hopSize int, window []float64, numSamples int) ([]float64, error) {
+	if err := validateSTFT(windowSize, hopSize, window); err != nil {
+		return nil, err
+	}
+
+	samples := make([]float64, numSamples)
+	norm := make([]float64, numSamples)
+
+	for i, spectrum := range frames {
+		if len(spectrum) != windowSize {
+			return nil, ErrLengthMismatch
+		}
+
+		start := i*hopSize - windowSize/2
+		frame := ifft(spectrum)
+
+		for n, x := range frame {
+			if idx := start + n; idx >= 0 && idx < numSamples {
+				samples[idx] += real(x) * window[n]
+				norm[idx] += window[n] * window[n]
+			}
+		}
+	}
+
+	for n := range samples {
+		if norm[n] > 1e-10 {
+			samples[n] /= norm[n]
+		}
+	}
+
+	return samples, nil
+}
+
+func validateSTFT(windowSize, hopSize int, window []float64) error {
+	if !isPowerOfTwo(windowSize) {
+		return ErrNotPowerOfTwo
+	}
+	if len(window) != windowSize {
+		return ErrLengthMismatch
+	}
+	if hopSize <= 0 || hopSize > windowSize {
+		return ErrInvalidHopSize
+	}
+	return nil
+}
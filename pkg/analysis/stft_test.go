@@ -0,0 +1,93 @@
+package analysis
+
+import (
+	"math"
+	"math/cmplx"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSTFT_RoundTrip(t *testing.T) {
+	rng := rand.New(rand.NewPCG(7, 8))
+	windowSize := 512
+	hopSize := windowSize / 4
+	window := HannWindow(windowSize)
+
+	for _, numSamples := range []int{100, 4096, 5000} {
+		samples := make([]float64, numSamples)
+		for n := range samples {
+			samples[n] = rng.Float64()*2 - 1
+		}
+
+		frames, err := STFT(samples, windowSize, hopSize, window)
+		require.NoError(t, err)
+		require.Len(t, frames, int(math.Ceil(float64(numSamples)/float64(hopSize))))
+
+		restored, err := ISTFT(frames, windowSize, hopSize, window, numSamples)
+		require.NoError(t, err)
+		require.Len(t, restored, numSamples)
+
+		for n := range samples {
+			require.InDelta(t, samples[n], restored[n], 1e-10)
+		}
+	}
+}
+
+func TestSTFT_TracksFrequency(t *testing.T) {
+	sampleRate := 8000.0
+	windowSize := 256
+	samples := make([]float64, 8000)
+	for n := range samples {
+		freq := 500.0
+		if n >= len(samples)/2 {
+			freq = 2000.0
+		}
+		samples[n] = math.Sin(2 * math.Pi * freq * float64(n) / sampleRate)
+	}
+
+	frames, err := STFT(samples, windowSize, 128, HannWindow(windowSize))
+	require.NoError(t, err)
+
+	peakBin := func(frame []complex128) int {
+		peak := 0
+		for k := range windowSize / 2 {
+			if cmplx.Abs(frame[k]) > cmplx.Abs(frame[peak]) {
+				peak = k
+			}
+		}
+		return peak
+	}
+
+	binWidth := sampleRate / float64(windowSize)
+	require.Equal(t, int(500/binWidth), peakBin(frames[5]))
+	require.Equal(t, int(2000/binWidth), peakBin(frames[len(frames)-5]))
+}
+
+func TestHannWindow_ConstantOverlapAdd(t *testing.T) {
+	window := HannWindow(64)
+	require.Zero(t, window[0])
+	require.InDelta(t, 1.0, window[32], 1e-12)
+
+	for n := range 16 {
+		sum := window[n] + window[n+16] + window[n+32] + window[n+48]
+		require.InDelta(t, 2.0, sum, 1e-12)
+	}
+}
+
+func TestSTFT_InvalidParameters(t *testing.T) {
+	samples := make([]float64, 1000)
+
+	_, err := STFT(samples, 300, 75, HannWindow(300))
+	require.ErrorIs(t, err, ErrNotPowerOfTwo)
+
+	_, err = STFT(samples, 256, 64, HannWindow(128))
+	require.ErrorIs(t, err, ErrLengthMismatch)
+
+	_, err = STFT(samples, 256, 0, HannWindow(256))
+	require.ErrorIs(t, err, ErrInvalidHopSize)
+
+	_, err = ISTFT([][]complex128{make([]complex128, 128)}, 256, 64, HannWindow(256), 1000)
+	require.ErrorIs(t, err, ErrLengthMismatch)
+}
@@ -0,0 +1,134 @@
+package analysis
+
+import (
+	"errors"
+	"math"
+)
+
+// ErrInvalidSampleRate is returned when the sample rate is not positive.
+var ErrInvalidSampleRate = errors.New("sample rate must be positive")
+
+// ErrSampleRateTooLow is returned when the onset frames are too far apart
+// to resolve the fastest tempo searched.
+var ErrSampleRateTooLow = errors.New("sample rate is too low to estimate tempo")
+
+// ErrSignalTooShort is returned when the signal does not hold enough
+// onset frames to cover the slowest tempo searched.
+var ErrSignalTooShort = errors.New("signal is too short to estimate tempo")
+
+const (
+	tempoFrameSize = 1024
+	tempoHopSize   = 256
+	minTempo       = 40.0
+	maxTempo       = 250.0
+	tempoPeakRatio = 0.9
+	tempoSmoothing = 3
+)
+
+// TempoEstimate returns the dominant tempo of samples in beats per minute
+// between 40 and 250 BPM, along with a confidence in [0, 1].
+//
+// The onset strength is computed with SpectralFlux, smoothed with a short
+// triangular kernel so onsets falling between two frames still line up,
+// centered on its mean and autocorrelated. Each lag is normalized by the
+// number of overlapping frames and by the onset energy:
+//
+//	r[l] = (Σ o[n]·o[n+l] / (N-l)) / (Σ o[n]² / N)
+//
+// The shortest lag whose peak reaches 90% of the highest r gives the beat
+// period, which avoids locking onto half the tempo. It is refined with
+// parabolic interpolation, and r at that lag clamped to [0, 1] is the
+// confidence.
+// The signal must cover at least two beats at 40 BPM, and the sample rate
+// must put at least two hops in a beat at 250 BPM, about 2.2 kHz.
+func TempoEstimate(samples []float64, sampleRate float64) (bpm float64, confidence float64, err error) {
+	if sampleRate <= 0 {
+		return 0, 0, ErrInvalidSampleRate
+	}
+
+	frameRate := sampleRate / tempoHopSize
+	minLag := int(math.Floor(60 / maxTempo * frameRate))
+	maxLag := int(math.Ceil(60 / minTempo * frameRate))
+	// Lag 0 is the onset energy itself: the search needs at least one lag
+	// between it and the fastest beat period to find a peak.
+	if minLag < 2 {
+		return 0, 0, ErrSampleRateTooLow
+	}
+
+	onsets := smoothOnsets(SpectralFlux(samples, sampleRate, tempoFrameSize, tempoHopSize))
+	if len(onsets) < 2*maxLag {
+		return 0, 0, ErrSignalTooShort
+	}
+
+	var mean float64
+	for _, v := range onsets {
+		mean += v
+	}
+	mean /= float64(len(onsets))
+
+	var energy float64
+	for i := range onsets {
+		onsets[i] -= mean
+		energy += onsets[i] * onsets[i]
+	}
+	if energy == 0 {
+		return 0, 0, ErrSilentSignal
+	}
+	energy /= float64(len(onsets))
+
+	correlation := make([]float64, maxLag+2)
+	for lag := minLag - 1; lag <= maxLag+1; lag++ {
+		var sum float64
+		for n := 0; n+lag < len(onsets); n++ {
+			sum += onsets[n] * onsets[n+lag]
+		}
+		correlation[lag] = sum / float64(len(onsets)-lag) / energy
+	}
+
+	highest := math.Inf(-1)
+	for lag := minLag; lag <= maxLag; lag++ {
+		highest = math.Max(highest, correlation[lag])
+	}
+
+	// Multiples of the beat period correlate almost as well as the period
+	// itself, so take the shortest peak close to the highest one.
+	best := minLag
+	for lag := minLag; lag <= maxLag; lag++ {
+		r := correlation[lag]
+		if r >= tempoPeakRatio*highest && r >= correlation[lag-1] && r >= correlation[lag+1] {
+			best = lag
+			break
+		}
+	}
+
+	// Parabolic interpolation around the peak for sub-frame precision.
+	prev, peak, next := correlation[best-1], correlation[best], correlation[best+1]
+	offset := 0.0
+	if denom := prev - 2*peak + next; denom < 0 {
+		offset = 0.5 * (prev - next) / denom
+	}
+
+	period := (float64(best) + offset) / frameRate
+	return 60 / period, math.Max(0, math.Min(1, peak)), nil
+}
+
+// smoothOnsets convolves the onset strength with a triangular kernel
+// spanning tempoSmoothing frames on each side.
+func smoothOnsets(onsets []float64) []float64 {
+	smoothed := make([]float64, len(onsets))
+
+	for i := range onsets {
+		var sum, weights float64
+		for k := -tempoSmoothing; k <= tempoSmoothing; k++ {
+			if i+k < 0 || i+k >= len(onsets) {
+				continue
+			}
+			w := float64(tempoSmoothing + 1 - max(k, -k))
+			sum += w * onsets[i+k]
+			weights += w
+		}
+		smoothed[i] = sum / weights
+	}
+
+	return smoothed
+}
@@ -0,0 +1,87 @@
+package analysis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// clickTrack returns seconds of audio with a short decaying click at every
+// beat of the given tempo.
+func clickTrack(bpm, sampleRate, seconds float64) []float64 {
+	samples := make([]float64, int(sampleRate*seconds))
+	period := 60 / bpm * sampleRate
+
+	for beat := 0.0; int(beat*period) < len(samples); beat++ {
+		start := int(beat * period)
+		for i := 0; i < 64 && start+i < len(samples); i++ {
+			samples[start+i] = 1 - float64(i)/64
+		}
+	}
+
+	return samples
+}
+
+func TestTempoEstimate_Clicks(t *testing.T) {
+	const sampleRate = 44100.0
+
+	tests := []struct {
+		name string
+		bpm  float64
+	}{
+		{name: "120 BPM", bpm: 120},
+		{name: "90 BPM", bpm: 90},
+		{name: "174 BPM", bpm: 174},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bpm, confidence, err := TempoEstimate(clickTrack(tt.bpm, sampleRate, 10), sampleRate)
+			require.NoError(t, err)
+			require.InDelta(t, tt.bpm, bpm, 1.0)
+			require.Greater(t, confidence, 0.8)
+			require.LessOrEqual(t, confidence, 1.0)
+		})
+	}
+}
+
+func TestTempoEstimate_NoiseHasLowConfidence(t *testing.T) {
+	const sampleRate = 44100.0
+	samples := make([]float64, int(sampleRate*10))
+	state := uint32(1)
+	for i := range samples {
+		state = state*1664525 + 1013904223
+		samples[i] = float64(state)/float64(1<<32)*2 - 1
+	}
+
+	_, confidence, err := TempoEstimate(samples, sampleRate)
+	require.NoError(t, err)
+	require.Less(t, confidence, 0.5)
+}
+
+func TestTempoEstimate_Errors(t *testing.T) {
+	_, _, err := TempoEstimate(clickTrack(120, 44100, 10), 0)
+	require.ErrorIs(t, err, ErrInvalidSampleRate)
+
+	_, _, err = TempoEstimate(clickTrack(120, 44100, 2), 44100)
+	require.ErrorIs(t, err, ErrSignalTooShort)
+
+	_, _, err = TempoEstimate(make([]float64, 44100*10), 44100)
+	require.ErrorIs(t, err, ErrSilentSignal)
+}
+
+func TestTempoEstimate_LowSampleRate(t *testing.T) {
+	for _, sampleRate := range []float64{4000, 8000} {
+		for _, tempo := range []float64{60, 120} {
+			bpm, _, err := TempoEstimate(clickTrack(tempo, sampleRate, 30), sampleRate)
+			require.NoError(t, err, "%g BPM at %g Hz", tempo, sampleRate)
+			require.InDelta(t, tempo, bpm, 1.0, "%g BPM at %g Hz", tempo, sampleRate)
+		}
+	}
+
+	// Below about 2.2 kHz a hop spans more than half a beat at 250 BPM.
+	for _, sampleRate := range []float64{1, 1000, 2000} {
+		_, _, err := TempoEstimate(clickTrack(120, sampleRate, 30), sampleRate)
+		require.ErrorIs(t, err, ErrSampleRateTooLow, "%g Hz", sampleRate)
+	}
+}
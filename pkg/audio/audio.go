@@ -0,0 +1,92 @@
+package audio
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/ECecillo/lib.go.sound/pkg/format"
+	"github.com/ECecillo/lib.go.sound/pkg/sine"
+	"github.com/ECecillo/lib.go.sound/pkg/wav"
+)
+
+// Audio is a block of samples together with the metadata needed to play or
+// store it.
+type Audio struct {
+	Samples     []float64          // Interleaved when NumChannels > 1
+	SampleRate  float64            // Sampling frequency in Hz
+	NumChannels int                // Number of interleaved channels
+	Format      format.AudioFormat // Encoding used by WriteTo
+}
+
+// NewAudio wraps samples with their metadata.
+func NewAudio(samples []float64, sampleRate float64, numChannels int, f format.AudioFormat) *Audio {
+	return &Audio{
+		Samples:     samples,
+		SampleRate:  sampleRate,
+		NumChannels: numChannels,
+		Format:      f,
+	}
+}
+
+// NewAudioFromSine generates s into a mono Audio with the same sampling
+// rate and format.
+func NewAudioFromSine(s *sine.Sine) (*Audio, error) {
+	samples, err := s.Generate()
+	if err != nil {
+		return nil, fmt.Errorf("unable to generate samples, err: %w", err)
+	}
+
+	return NewAudio(samples, s.SamplingRate, 1, s.Format), nil
+}
+
+// NewAudioFromWAV decodes a WAV file into an Audio.
+func NewAudioFromWAV(r io.Reader) (*Audio, error) {
+	samples, layout, err := wav.Decode(r)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode WAV, err: %w", err)
+	}
+
+	return NewAudio(samples, float64(layout.SampleRate), layout.Channels, layout.Format), nil
+}
+
+// NumSamples returns the number of samples per channel.
+func (a Audio) NumSamples() int {
+	if a.NumChannels <= 0 {
+		return 0
+	}
+	return len(a.Samples) / a.NumChannels
+}
+
+// Duration returns the playing time of the audio.
+func (a Audio) Duration() time.Duration {
+	if a.SampleRate <= 0 {
+		return 0
+	}
+	return time.Duration(float64(a.NumSamples()) / a.SampleRate * float64(time.Second))
+}
+
+// WriteTo encodes the samples with Format and writes them to w, without
+// any header.
+func (a Audio) WriteTo(w io.Writer) (int64, error) {
+	var totalBytesWritten int64
+
+	for _, sample := range a.Samples {
+		n, err := w.Write(a.Format.ConvertSample(sample))
+		totalBytesWritten += int64(n)
+		if err != nil {
+			return totalBytesWritten, fmt.Errorf("unable to write data, err: %w", err)
+		}
+	}
+
+	return totalBytesWritten, nil
+}
+
+var _ io.WriterTo = Audio{}
+
+// WriteNormalized writes a as a WAV file whose peak sits at ceilingdBFS.
+// See wav.Writer.WriteNormalized.
+func WriteNormalized(w io.Writer, a *Audio, ceilingdBFS float64) (int64, error) {
+	layout := wav.NewWriter(a.Format, int(a.SampleRate), a.NumChannels)
+	return layout.WriteNormalized(w, a.Samples, ceilingdBFS)
+}
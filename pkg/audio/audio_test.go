@@ -0,0 +1,142 @@
+package audio
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ECecillo/lib.go.sound/pkg/format"
+	"github.com/ECecillo/lib.go.sound/pkg/sine"
+	"github.com/ECecillo/lib.go.sound/pkg/wav"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAudio_ComputedProperties(t *testing.T) {
+	tests := []struct {
+		name         string
+		audio        *Audio
+		wantSamples  int
+		wantDuration time.Duration
+	}{
+		{
+			name:         "mono",
+			audio:        NewAudio(make([]float64, 44100), 44100, 1, format.PCM16{}),
+			wantSamples:  44100,
+			wantDuration: time.Second,
+		},
+		{
+			name:         "stereo",
+			audio:        NewAudio(make([]float64, 48000), 48000, 2, format.PCM16{}),
+			wantSamples:  24000,
+			wantDuration: 500 * time.Millisecond,
+		},
+		{
+			name:         "empty",
+			audio:        NewAudio(nil, 44100, 1, format.PCM16{}),
+			wantSamples:  0,
+			wantDuration: 0,
+		},
+		{
+			name:         "no channels",
+			audio:        NewAudio(make([]float64, 10), 44100, 0, format.PCM16{}),
+			wantSamples:  0,
+			wantDuration: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.wantSamples, tt.audio.NumSamples())
+			require.Equal(t, tt.wantDuration, tt.audio.Duration())
+		})
+	}
+}
+
+func TestNewAudioFromSine(t *testing.T) {
+	s := sine.NewSine(440, 250*time.Millisecond, sine.WithSamplingRate(8000), sine.WithFormat(format.Float32{}))
+
+	a, err := NewAudioFromSine(s)
+	require.NoError(t, err)
+
+	expected, err := s.Generate()
+	require.NoError(t, err)
+
+	require.Equal(t, expected, a.Samples)
+	require.Equal(t, 8000.0, a.SampleRate)
+	require.Equal(t, 1, a.NumChannels)
+	require.IsType(t, format.Float32{}, a.Format)
+	require.Equal(t, len(a.Samples), a.NumSamples())
+	require.Equal(t, 250*time.Millisecond, a.Duration())
+
+	// WriteTo produces the same bytes as the Sine itself.
+	var fromAudio, fromSine bytes.Buffer
+	_, err = a.WriteTo(&fromAudio)
+	require.NoError(t, err)
+	_, err = s.WriteTo(&fromSine)
+	require.NoError(t, err)
+	require.Equal(t, fromSine.Bytes(), fromAudio.Bytes())
+}
+
+func TestNewAudioFromSine_Error(t *testing.T) {
+	s := sine.NewSine(30000, time.Second, sine.WithNyquistCheck())
+
+	_, err := NewAudioFromSine(s)
+	require.ErrorIs(t, err, sine.ErrNyquistViolation)
+}
+
+func TestNewAudioFromWAV(t *testing.T) {
+	samples := []float64{0, 0.5, -0.5, 0.25}
+
+	var buf bytes.Buffer
+	_, err := wav.NewWriter(format.PCM16{}, 22050, 2).Write(&buf, samples)
+	require.NoError(t, err)
+
+	a, err := NewAudioFromWAV(&buf)
+	require.NoError(t, err)
+
+	require.InDeltaSlice(t, samples, a.Samples, 1e-4)
+	require.Equal(t, 22050.0, a.SampleRate)
+	require.Equal(t, 2, a.NumChannels)
+	require.IsType(t, format.PCM16{}, a.Format)
+	require.Equal(t, 2, a.NumSamples())
+
+	_, err = NewAudioFromWAV(bytes.NewReader([]byte("garbage")))
+	require.ErrorIs(t, err, wav.ErrNotWAV)
+}
+
+type errWriter struct{}
+
+func (errWriter) Write([]byte) (int, error) {
+	return 0, errors.New("boom")
+}
+
+func TestAudio_WriteTo(t *testing.T) {
+	a := NewAudio([]float64{0.5, -0.5, 0}, 44100, 1, format.PCM16{})
+
+	var buf bytes.Buffer
+	n, err := a.WriteTo(&buf)
+	require.NoError(t, err)
+	require.Equal(t, int64(6), n)
+	require.Equal(t, []byte{0xFF, 0x3F, 0x01, 0xC0, 0, 0}, buf.Bytes())
+
+	_, err = a.WriteTo(errWriter{})
+	require.Error(t, err)
+}
+
+func TestWriteNormalized(t *testing.T) {
+	a := NewAudio([]float64{0.1, -0.2, 0.4, -0.4}, 48000, 2, format.PCM16{})
+
+	var buf bytes.Buffer
+	_, err := WriteNormalized(&buf, a, -6)
+	require.NoError(t, err)
+
+	decoded, err := NewAudioFromWAV(&buf)
+	require.NoError(t, err)
+	require.Equal(t, 2, decoded.NumChannels)
+	require.Equal(t, 48000.0, decoded.SampleRate)
+	require.InDelta(t, 0.5012, decoded.Samples[2], 1e-3)
+
+	_, err = WriteNormalized(&buf, NewAudio(make([]float64, 4), 48000, 1, format.PCM16{}), -6)
+	require.ErrorIs(t, err, wav.ErrSilentAudio)
+}
@@ -0,0 +1,159 @@
+package audio
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ECecillo/lib.go.sound/pkg/aiff"
+	"github.com/ECecillo/lib.go.sound/pkg/format"
+	"github.com/ECecillo/lib.go.sound/pkg/wav"
+)
+
+var (
+	// ErrUnsupportedExtension is returned when a file extension does not
+	// map to a known audio file format.
+	ErrUnsupportedExtension = errors.New("unsupported file extension")
+	// ErrUnsupportedRawLayout is returned when writing a raw file for audio
+	// that ReadFile could not read back: raw files carry no header and are
+	// always read as PCM16 mono at 44100 Hz.
+	ErrUnsupportedRawLayout = errors.New("raw files only hold PCM16 mono at 44100 Hz")
+)
+
+// Raw files carry no header, so ReadFile decodes them with the defaults of
+// sine.NewSine.
+const (
+	rawSampleRate  = 44100.0
+	rawNumChannels = 1
+)
+
+var rawFormat = format.PCM16{}
+
+// WriteFile writes audio to path in the file format selected by its
+// extension: ".wav" for WAV, ".aif" or ".aiff" for AIFF and ".raw" or
+// ".pcm" for headerless samples. Raw files are limited to the layout
+// ReadFile assumes, PCM16 mono at 44100 Hz, and other audio is rejected
+// with ErrUnsupportedRawLayout.
+func WriteFile(path string, audio *Audio) (err error) {
+	encode, err := encoderFor(path, audio)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("unable to create file, err: %w", err)
+	}
+	defer func() {
+		if closeErr := file.Close(); closeErr != nil && err == nil {
+			err = fmt.Errorf("unable to close file, err: %w", closeErr)
+		}
+	}()
+
+	buffered := bufio.NewWriter(file)
+	if err := encode(buffered); err != nil {
+		return err
+	}
+
+	if err := buffered.Flush(); err != nil {
+		return fmt.Errorf("unable to flush data, err: %w", err)
+	}
+
+	return nil
+}
+
+// ReadFile reads the audio file at path, choosing the decoder from its
+// extension like WriteFile. Raw files are read as mono PCM16 at 44100 Hz.
+func ReadFile(path string) (*Audio, error) {
+	ext := strings.ToLower(filepath.Ext(path))
+	if !supportedExtension(ext) {
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedExtension, ext)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open file, err: %w", err)
+	}
+	defer file.Close()
+
+	r := bufio.NewReader(file)
+
+	switch ext {
+	case ".wav":
+		return NewAudioFromWAV(r)
+	case ".aif", ".aiff":
+		samples, layout, err := aiff.Decode(r)
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode AIFF, err: %w", err)
+		}
+		return NewAudio(samples, float64(layout.SampleRate), layout.Channels, layout.Format), nil
+	default:
+		data, err := io.ReadAll(r)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read file, err: %w", err)
+		}
+		return decodeRaw(data)
+	}
+}
+
+// encoderFor returns the function writing audio in the file format of path.
+func encoderFor(path string, audio *Audio) (func(io.Writer) error, error) {
+	sampleRate := int(math.Round(audio.SampleRate))
+
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".wav":
+		return func(w io.Writer) error {
+			_, err := wav.NewWriter(audio.Format, sampleRate, audio.NumChannels).Write(w, audio.Samples)
+			return err
+		}, nil
+	case ".aif", ".aiff":
+		if !aiff.Supported(audio.Format) {
+			return nil, fmt.Errorf("%w: %T", aiff.ErrUnsupportedFormat, audio.Format)
+		}
+		return func(w io.Writer) error {
+			_, err := aiff.NewWriter(audio.Format, sampleRate, audio.NumChannels).Write(w, audio.Samples)
+			return err
+		}, nil
+	case ".raw", ".pcm":
+		if _, ok := audio.Format.(format.PCM16); !ok || audio.SampleRate != rawSampleRate || audio.NumChannels != rawNumChannels {
+			return nil, fmt.Errorf("%w: %T, %d channels at %g Hz",
+				ErrUnsupportedRawLayout, audio.Format, audio.NumChannels, audio.SampleRate)
+		}
+		return func(w io.Writer) error {
+			_, err := audio.WriteTo(w)
+			return err
+		}, nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedExtension, ext)
+	}
+}
+
+func supportedExtension(ext string) bool {
+	switch ext {
+	case ".wav", ".aif", ".aiff", ".raw", ".pcm":
+		return true
+	default:
+		return false
+	}
+}
+
+// decodeRaw decodes headerless samples with the raw defaults.
+func decodeRaw(data []byte) (*Audio, error) {
+	sampleSize := rawFormat.BitDepth() / 8
+	samples := make([]float64, len(data)/sampleSize)
+
+	for i := range samples {
+		sample, err := rawFormat.Decode(data[i*sampleSize : (i+1)*sampleSize])
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode sample %d, err: %w", i, err)
+		}
+		samples[i] = sample
+	}
+
+	return NewAudio(samples, rawSampleRate, rawNumChannels, rawFormat), nil
+}
@@ -0,0 +1,96 @@
+package audio
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/ECecillo/lib.go.sound/pkg/format"
+	"github.com/ECecillo/lib.go.sound/pkg/sine"
+)
+
+// Metrics is a snapshot of the statistics gathered by a MetricsWriter.
+type Metrics struct {
+	PeakAmplitude  float64 // Largest absolute sample value
+	RMSAccumulator float64 // Sum of the squared sample values
+	BytesWritten   int64   // Bytes accepted by the destination
+	SamplesWritten int     // Whole samples accepted by the destination
+}
+
+// RMS returns the root mean square of the samples written so far:
+//
+//	RMS = sqrt(RMSAccumulator / SamplesWritten)
+func (m Metrics) RMS() float64 {
+	if m.SamplesWritten == 0 {
+		return 0
+	}
+	return math.Sqrt(m.RMSAccumulator / float64(m.SamplesWritten))
+}
+
+// MetricsWriter forwards an encoded sample stream to another io.Writer and
+// decodes it on the fly to keep running statistics, so a live stream can
+// be monitored without a second pass over the samples. Samples split across
+// two calls to Write are reassembled.
+type MetricsWriter struct {
+	w          io.Writer
+	decoder    format.Decoder
+	sampleSize int
+	metrics    Metrics
+	pending    []byte
+}
+
+// NewMetricsWriter returns a MetricsWriter writing to w a stream encoded
+// with f. It returns format.ErrNotDecoder, before anything is written, when
+// f cannot decode its samples back.
+func NewMetricsWriter(w io.Writer, f format.AudioFormat) (*MetricsWriter, error) {
+	decoder, ok := f.(format.Decoder)
+	if !ok {
+		return nil, fmt.Errorf("%w: %T", format.ErrNotDecoder, f)
+	}
+
+	return &MetricsWriter{w: w, decoder: decoder, sampleSize: f.BitDepth() / 8}, nil
+}
+
+// Write forwards p to the underlying writer and accounts for the bytes it
+// accepted. Write and decode errors are joined, so errors.Is matches
+// either of them.
+func (m *MetricsWriter) Write(p []byte) (int, error) {
+	n, err := m.w.Write(p)
+	m.metrics.BytesWritten += int64(n)
+	m.pending = append(m.pending, p[:n]...)
+
+	for len(m.pending) >= m.sampleSize {
+		sample, decodeErr := m.decoder.Decode(m.pending[:m.sampleSize])
+		if decodeErr != nil {
+			return n, errors.Join(err, fmt.Errorf("unable to decode sample, err: %w", decodeErr))
+		}
+		m.pending = m.pending[m.sampleSize:]
+
+		m.metrics.PeakAmplitude = max(m.metrics.PeakAmplitude, math.Abs(sample))
+		m.metrics.RMSAccumulator += sample * sample
+		m.metrics.SamplesWritten++
+	}
+
+	return n, err
+}
+
+// Metrics returns the statistics gathered so far.
+func (m *MetricsWriter) Metrics() Metrics {
+	return m.metrics
+}
+
+var _ io.Writer = (*MetricsWriter)(nil)
+
+// WriteToWithMetrics writes s encoded with f to w and returns the
+// statistics of the stream. It lives in this package rather than on Sine
+// because pkg/audio already depends on pkg/sine.
+func WriteToWithMetrics(w io.Writer, s *sine.Sine, f format.AudioFormat) (int64, Metrics, error) {
+	mw, err := NewMetricsWriter(w, f)
+	if err != nil {
+		return 0, Metrics{}, err
+	}
+
+	n, err := s.Clone(sine.WithFormat(f)).WriteTo(mw)
+	return n, mw.Metrics(), err
+}
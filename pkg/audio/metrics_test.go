@@ -0,0 +1,127 @@
+package audio
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/ECecillo/lib.go.sound/pkg/analysis"
+	"github.com/ECecillo/lib.go.sound/pkg/format"
+	"github.com/ECecillo/lib.go.sound/pkg/sine"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteToWithMetrics_Sine(t *testing.T) {
+	s := sine.NewSine(440, time.Second, sine.WithAmplitude(0.8))
+
+	samples, err := s.Generate()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		format    format.AudioFormat
+		tolerance float64
+	}{
+		{name: "float64", format: format.Float64{}, tolerance: 1e-12},
+		{name: "pcm16", format: format.PCM16{}, tolerance: 1.0 / 32768},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			n, metrics, err := WriteToWithMetrics(&buf, s, tt.format)
+			require.NoError(t, err)
+
+			require.Equal(t, int64(buf.Len()), n)
+			require.Equal(t, n, metrics.BytesWritten)
+			require.Equal(t, len(samples), metrics.SamplesWritten)
+			require.InDelta(t, analysis.Peak(samples), metrics.PeakAmplitude, tt.tolerance)
+			require.InDelta(t, analysis.RMS(samples), metrics.RMS(), tt.tolerance)
+		})
+	}
+}
+
+func TestMetricsWriter_SplitSamples(t *testing.T) {
+	f := format.PCM16{}
+	stream := append(f.ConvertSample(0.5), f.ConvertSample(-0.25)...)
+
+	var buf bytes.Buffer
+	mw, err := NewMetricsWriter(&buf, f)
+	require.NoError(t, err)
+
+	// Feed the 4 bytes one at a time: each sample straddles two writes.
+	for _, b := range stream {
+		_, err := mw.Write([]byte{b})
+		require.NoError(t, err)
+	}
+
+	metrics := mw.Metrics()
+	require.Equal(t, stream, buf.Bytes())
+	require.Equal(t, 2, metrics.SamplesWritten)
+	require.InDelta(t, 0.5, metrics.PeakAmplitude, 1.0/32768)
+	require.InDelta(t, math.Sqrt((0.25+0.0625)/2), metrics.RMS(), 1.0/32768)
+}
+
+var errDiskFull = errors.New("disk full")
+
+// shortWriter accepts at most limit bytes.
+type shortWriter struct {
+	limit int
+}
+
+func (w *shortWriter) Write(p []byte) (int, error) {
+	n := min(len(p), w.limit)
+	w.limit -= n
+	if n < len(p) {
+		return n, errDiskFull
+	}
+	return n, nil
+}
+
+func TestMetricsWriter_CountsAcceptedBytesOnly(t *testing.T) {
+	mw, err := NewMetricsWriter(&shortWriter{limit: 3}, format.PCM16{})
+	require.NoError(t, err)
+
+	n, err := mw.Write(make([]byte, 8))
+	require.ErrorIs(t, err, errDiskFull)
+	require.Equal(t, 3, n)
+
+	metrics := mw.Metrics()
+	require.Equal(t, int64(3), metrics.BytesWritten)
+	require.Equal(t, 1, metrics.SamplesWritten)
+}
+
+var errDecode = errors.New("corrupt sample")
+
+// failingDecoder is a PCM16 format whose Decode always fails.
+type failingDecoder struct{ format.PCM16 }
+
+func (failingDecoder) Decode([]byte) (float64, error) { return 0, errDecode }
+
+func TestMetricsWriter_JoinsWriteAndDecodeErrors(t *testing.T) {
+	mw, err := NewMetricsWriter(&shortWriter{limit: 3}, failingDecoder{})
+	require.NoError(t, err)
+
+	n, err := mw.Write(make([]byte, 8))
+	require.Equal(t, 3, n)
+	require.ErrorIs(t, err, errDiskFull)
+	require.ErrorIs(t, err, errDecode)
+}
+
+func TestNewMetricsWriter_NotDecoder(t *testing.T) {
+	var buf bytes.Buffer
+	f := struct{ format.AudioFormat }{format.PCM16{}}
+
+	_, err := NewMetricsWriter(&buf, f)
+	require.ErrorIs(t, err, format.ErrNotDecoder)
+
+	_, _, err = WriteToWithMetrics(&buf, sine.NewSine(440, 10*time.Millisecond), f)
+	require.ErrorIs(t, err, format.ErrNotDecoder)
+	require.Zero(t, buf.Len(), "nothing must be written")
+}
+
+func TestMetrics_EmptyRMS(t *testing.T) {
+	require.Zero(t, Metrics{}.RMS())
+}
@@ -0,0 +1,180 @@
+// Package display renders audio signals as text for quick inspection in a
+// terminal.
+package display
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/cmplx"
+	"strings"
+
+	"github.com/ECecillo/lib.go.sound/pkg/analysis"
+)
+
+var (
+	// ErrInvalidSize is returned when the requested plot has no columns or
+	// no rows.
+	ErrInvalidSize = errors.New("width and height must be positive")
+	// ErrInvalidSampleRate is returned when the sample rate is not positive.
+	ErrInvalidSampleRate = errors.New("sample rate must be positive")
+	// ErrEmptySignal is returned when there are no samples to display.
+	ErrEmptySignal = errors.New("signal is empty")
+)
+
+const (
+	// minFrequency and maxFrequency bound the logarithmic frequency axis.
+	minFrequency = 20.0
+	maxFrequency = 20000.0
+	// dynamicRange is the span in dB between a full-height bar and an
+	// empty one.
+	dynamicRange = 60.0
+)
+
+// PrintSpectrum draws the magnitude spectrum of samples as width bars of
+// '#' characters over height rows, followed by a frequency axis and its
+// labels, height+2 lines in total. Column c covers the frequencies
+//
+//	[20 * 1000^(c/width), 20 * 1000^((c+1)/width)) Hz
+//
+// so each octave gets the same room, and shows the strongest bin of that
+// band on a dB scale: the peak of the spectrum fills every row and a bin
+// 60 dB below it or less none. Bands narrower than one FFT bin show the
+// nearest bin. The samples are Hann windowed and zero-padded to a power of
+// two before the FFT.
+func PrintSpectrum(w io.Writer, samples []float64, sampleRate float64, width, height int) error {
+	if width <= 0 || height <= 0 {
+		return ErrInvalidSize
+	}
+	if sampleRate <= 0 {
+		return ErrInvalidSampleRate
+	}
+	if len(samples) == 0 {
+		return ErrEmptySignal
+	}
+
+	magnitudes, binWidth, err := spectrum(samples, sampleRate)
+	if err != nil {
+		return fmt.Errorf("unable to compute spectrum, err: %w", err)
+	}
+
+	levels := bandLevels(magnitudes, binWidth, width)
+
+	var b strings.Builder
+	for row := range height {
+		// Row 0 is the top of the plot and needs the loudest bars.
+		threshold := float64(height-row) / float64(height)
+		for _, level := range levels {
+			if level >= threshold-1e-9 {
+				b.WriteByte('#')
+			} else {
+				b.WriteByte(' ')
+			}
+		}
+		b.WriteByte('\n')
+	}
+	b.WriteString(strings.Repeat("-", width))
+	b.WriteByte('\n')
+	b.WriteString(axisLabels(width))
+	b.WriteByte('\n')
+
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return fmt.Errorf("unable to write spectrum, err: %w", err)
+	}
+	return nil
+}
+
+// spectrum returns the magnitudes of the Hann-windowed samples from DC to
+// Nyquist and the width of one bin in Hz.
+func spectrum(samples []float64, sampleRate float64) ([]float64, float64, error) {
+	size := 1
+	for size < len(samples) {
+		size <<= 1
+	}
+
+	padded := make([]float64, size)
+	window := analysis.HannWindow(len(samples))
+	for n, x := range samples {
+		padded[n] = x * window[n]
+	}
+
+	bins, err := analysis.FFT(padded)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	magnitudes := make([]float64, size/2+1)
+	for k := range magnitudes {
+		magnitudes[k] = cmplx.Abs(bins[k])
+	}
+
+	return magnitudes, sampleRate / float64(size), nil
+}
+
+// bandLevels returns, for each of the width logarithmic bands, the height
+// of its bar in [0, 1].
+func bandLevels(magnitudes []float64, binWidth float64, width int) []float64 {
+	ratio := maxFrequency / minFrequency
+	nyquist := float64(len(magnitudes)-1) * binWidth
+
+	bands := make([]float64, width)
+	var peak float64
+	for c := range bands {
+		low := minFrequency * math.Pow(ratio, float64(c)/float64(width))
+		high := minFrequency * math.Pow(ratio, float64(c+1)/float64(width))
+		if low > nyquist {
+			continue
+		}
+
+		first := int(math.Ceil(low / binWidth))
+		last := min(int(math.Ceil(high/binWidth))-1, len(magnitudes)-1)
+		if first > last {
+			// The band falls between two bins.
+			first = min(int(math.Round(math.Sqrt(low*high)/binWidth)), len(magnitudes)-1)
+			last = first
+		}
+
+		for _, m := range magnitudes[first : last+1] {
+			bands[c] = max(bands[c], m)
+		}
+		peak = max(peak, bands[c])
+	}
+
+	for c, m := range bands {
+		if peak == 0 || m == 0 {
+			bands[c] = 0
+			continue
+		}
+		db := 20 * math.Log10(m/peak)
+		bands[c] = max(0, 1+db/dynamicRange)
+	}
+
+	return bands
+}
+
+// axisLabels returns a line of width characters with frequency labels
+// under their column. Labels that would overlap a previous one or the
+// edges are left out.
+func axisLabels(width int) string {
+	line := []byte(strings.Repeat(" ", width))
+	ratio := maxFrequency / minFrequency
+
+	next := 0
+	for _, label := range []struct {
+		text      string
+		frequency float64
+	}{
+		{"20", 20}, {"100", 100}, {"1k", 1000}, {"10k", 10000}, {"20k", 20000},
+	} {
+		column := int(float64(width) * math.Log(label.frequency/minFrequency) / math.Log(ratio))
+		start := min(column, width-len(label.text))
+		if start < next {
+			continue
+		}
+		copy(line[start:], label.text)
+		next = start + len(label.text) + 1
+	}
+
+	return string(line)
+}
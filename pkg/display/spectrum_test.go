@@ -0,0 +1,111 @@
+package display
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func sineWave(freq, sampleRate float64, n int) []float64 {
+	samples := make([]float64, n)
+	for i := range samples {
+		samples[i] = math.Sin(2 * math.Pi * freq * float64(i) / sampleRate)
+	}
+	return samples
+}
+
+// barHeights returns the number of '#' in each column of the plot rows.
+func barHeights(lines []string, width, height int) []int {
+	heights := make([]int, width)
+	for _, row := range lines[:height] {
+		for c, ch := range row {
+			if ch == '#' {
+				heights[c]++
+			}
+		}
+	}
+	return heights
+}
+
+func TestPrintSpectrum_Sine(t *testing.T) {
+	width, height := 60, 10
+
+	var buf bytes.Buffer
+	require.NoError(t, PrintSpectrum(&buf, sineWave(440, 44100, 44100), 44100, width, height))
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	require.Len(t, lines, height+2)
+	for _, line := range lines {
+		require.Len(t, line, width)
+	}
+
+	// A4 sits at 60 * log(440/20)/log(1000) ≈ column 26.8.
+	heights := barHeights(lines, width, height)
+	tallest := 0
+	for c, h := range heights {
+		if h > heights[tallest] {
+			tallest = c
+		}
+	}
+	require.Equal(t, height, heights[tallest])
+	require.InDelta(t, 26, tallest, 1)
+
+	for c, h := range heights {
+		if c != tallest {
+			require.Less(t, h, height/2, "column %d", c)
+		}
+	}
+
+	require.Equal(t, strings.Repeat("-", width), lines[height])
+	require.True(t, strings.HasPrefix(lines[height+1], "20"))
+	require.True(t, strings.HasSuffix(lines[height+1], "20k"))
+}
+
+func TestPrintSpectrum_TwoTones(t *testing.T) {
+	samples := sineWave(120, 48000, 16384)
+	for n, v := range sineWave(5000, 48000, 16384) {
+		samples[n] += v
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, PrintSpectrum(&buf, samples, 48000, 30, 8))
+	lines := strings.Split(buf.String(), "\n")
+
+	// Both tones reach the top rows, up to the scalloping of the FFT bins.
+	tall := 0
+	for _, h := range barHeights(lines, 30, 8) {
+		if h >= 7 {
+			tall++
+		}
+	}
+	require.Equal(t, 2, tall)
+}
+
+func TestPrintSpectrum_NarrowLabels(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, PrintSpectrum(&buf, sineWave(440, 44100, 4096), 44100, 4, 2))
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	require.Len(t, lines, 4)
+	require.Len(t, lines[3], 4)
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("closed pipe")
+}
+
+func TestPrintSpectrum_Errors(t *testing.T) {
+	samples := sineWave(440, 44100, 1024)
+
+	require.ErrorIs(t, PrintSpectrum(&bytes.Buffer{}, samples, 44100, 0, 10), ErrInvalidSize)
+	require.ErrorIs(t, PrintSpectrum(&bytes.Buffer{}, samples, 44100, 10, 0), ErrInvalidSize)
+	require.ErrorIs(t, PrintSpectrum(&bytes.Buffer{}, samples, 0, 10, 10), ErrInvalidSampleRate)
+	require.ErrorIs(t, PrintSpectrum(&bytes.Buffer{}, nil, 44100, 10, 10), ErrEmptySignal)
+	require.Error(t, PrintSpectrum(failingWriter{}, samples, 44100, 10, 10))
+}
@@ -0,0 +1,125 @@
+// Package dtmf encodes and decodes the Dual-Tone Multi-Frequency signals
+// sent by telephone keypads.
+package dtmf
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/ECecillo/lib.go.sound/pkg/analysis"
+)
+
+var (
+	// ErrInvalidDigit is returned when a character is not on the DTMF
+	// keypad.
+	ErrInvalidDigit = errors.New("invalid DTMF digit")
+	// ErrInvalidSampleRate is returned when the sample rate cannot
+	// represent the highest DTMF tone.
+	ErrInvalidSampleRate = errors.New("sample rate must be above twice the highest DTMF frequency")
+	// ErrAmbiguous is returned by Decode when no single row and column
+	// tone clearly dominate the signal.
+	ErrAmbiguous = errors.New("no dominant DTMF tone pair")
+)
+
+// Row and column frequencies in Hz of the keypad
+//
+//	       1209 1336 1477 1633
+//	697     1    2    3    A
+//	770     4    5    6    B
+//	852     7    8    9    C
+//	941     *    0    #    D
+var (
+	rowFrequencies    = [4]float64{697, 770, 852, 941}
+	columnFrequencies = [4]float64{1209, 1336, 1477, 1633}
+	keypad            = [4][4]byte{
+		{'1', '2', '3', 'A'},
+		{'4', '5', '6', 'B'},
+		{'7', '8', '9', 'C'},
+		{'*', '0', '#', 'D'},
+	}
+)
+
+// dominanceRatio is the factor by which the strongest tone of a group must
+// exceed the others for Decode to accept it, about 6 dB.
+const dominanceRatio = 2.0
+
+// Generate returns duration of the tone pair of digit, the sum of its row
+// and column sines at 0.5 amplitude each so the signal stays within ±1.
+func Generate(digit byte, duration time.Duration, sampleRate float64) ([]float64, error) {
+	if sampleRate <= 2*columnFrequencies[3] {
+		return nil, ErrInvalidSampleRate
+	}
+
+	row, column, ok := position(digit)
+	if !ok {
+		return nil, ErrInvalidDigit
+	}
+
+	low, high := rowFrequencies[row], columnFrequencies[column]
+	result := make([]float64, max(int(sampleRate*duration.Seconds()), 0))
+	for n := range result {
+		t := float64(n) / sampleRate
+		result[n] = 0.5*math.Sin(2*math.Pi*low*t) + 0.5*math.Sin(2*math.Pi*high*t)
+	}
+
+	return result, nil
+}
+
+// Decode returns the digit whose tone pair dominates samples. The energy
+// at each of the 8 DTMF frequencies is measured with the Goertzel
+// algorithm; the strongest row and column tones must each be at least
+// twice as strong as the other tones of their group, otherwise
+// ErrAmbiguous is returned.
+func Decode(samples []float64, sampleRate float64) (byte, error) {
+	if sampleRate <= 2*columnFrequencies[3] {
+		return 0, ErrInvalidSampleRate
+	}
+
+	row, ok := dominant(samples, sampleRate, rowFrequencies)
+	if !ok {
+		return 0, ErrAmbiguous
+	}
+	column, ok := dominant(samples, sampleRate, columnFrequencies)
+	if !ok {
+		return 0, ErrAmbiguous
+	}
+
+	return keypad[row][column], nil
+}
+
+// dominant returns the index of the strongest of frequencies in samples,
+// and whether it stands out from the others by dominanceRatio.
+func dominant(samples []float64, sampleRate float64, frequencies [4]float64) (int, bool) {
+	var magnitudes [4]float64
+	best := 0
+	for i, f := range frequencies {
+		magnitudes[i] = analysis.Goertzel(samples, f, sampleRate)
+		if magnitudes[i] > magnitudes[best] {
+			best = i
+		}
+	}
+
+	if magnitudes[best] == 0 {
+		return 0, false
+	}
+	for i, m := range magnitudes {
+		if i != best && m*dominanceRatio > magnitudes[best] {
+			return 0, false
+		}
+	}
+
+	return best, true
+}
+
+// position returns the keypad row and column of digit.
+func position(digit byte) (row, column int, ok bool) {
+	for r, keys := range keypad {
+		for c, key := range keys {
+			if key == digit {
+				return r, c, true
+			}
+		}
+	}
+	return 0, 0, false
+}
@@ -0,0 +1,74 @@
+package dtmf
+
+import (
+	"math"
+	"math/rand/v2"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecode_RoundTrip(t *testing.T) {
+	for _, digit := range []byte("0123456789*#ABCD") {
+		t.Run(string(digit), func(t *testing.T) {
+			samples, err := Generate(digit, 50*time.Millisecond, 8000)
+			require.NoError(t, err)
+			require.Len(t, samples, 400)
+
+			decoded, err := Decode(samples, 8000)
+			require.NoError(t, err)
+			require.Equal(t, digit, decoded)
+		})
+	}
+}
+
+func TestDecode_Noisy(t *testing.T) {
+	rng := rand.New(rand.NewPCG(1, 1))
+
+	samples, err := Generate('7', 40*time.Millisecond, 44100)
+	require.NoError(t, err)
+	for n := range samples {
+		samples[n] += 0.2 * rng.NormFloat64()
+	}
+
+	decoded, err := Decode(samples, 44100)
+	require.NoError(t, err)
+	require.Equal(t, byte('7'), decoded)
+}
+
+func TestDecode_Ambiguous(t *testing.T) {
+	// Silence.
+	_, err := Decode(make([]float64, 400), 8000)
+	require.ErrorIs(t, err, ErrAmbiguous)
+
+	// Two digits pressed at once share no dominant row.
+	one, err := Generate('1', 50*time.Millisecond, 8000)
+	require.NoError(t, err)
+	nine, err := Generate('9', 50*time.Millisecond, 8000)
+	require.NoError(t, err)
+	for n := range one {
+		one[n] += nine[n]
+	}
+	_, err = Decode(one, 8000)
+	require.ErrorIs(t, err, ErrAmbiguous)
+
+	// A single tone has no column.
+	row := make([]float64, 400)
+	for n := range row {
+		row[n] = math.Sin(2 * math.Pi * 770 * float64(n) / 8000)
+	}
+	_, err = Decode(row, 8000)
+	require.ErrorIs(t, err, ErrAmbiguous)
+}
+
+func TestGenerate_Errors(t *testing.T) {
+	_, err := Generate('E', time.Second, 8000)
+	require.ErrorIs(t, err, ErrInvalidDigit)
+
+	_, err = Generate('1', time.Second, 3000)
+	require.ErrorIs(t, err, ErrInvalidSampleRate)
+
+	_, err = Decode(make([]float64, 10), 0)
+	require.ErrorIs(t, err, ErrInvalidSampleRate)
+}
@@ -0,0 +1,85 @@
+package effects
+
+// CircularBuffer is a fixed-size delay buffer for sample-by-sample
+// processing. It keeps the last size samples written, which can be read
+// back either in order with Read or at a given delay with ReadAt.
+type CircularBuffer struct {
+	size   int
+	buffer []float64
+	read   int // Index of the oldest unread sample
+	write  int // Index the next sample is written to
+	unread int // Number of samples written but not yet returned by Read
+}
+
+// NewCircularBuffer creates a buffer holding size samples, initially all
+// zero.
+func NewCircularBuffer(size int) *CircularBuffer {
+	size = max(size, 1)
+	return &CircularBuffer{
+		size:   size,
+		buffer: make([]float64, size),
+	}
+}
+
+// Size returns the number of samples the buffer holds.
+func (c *CircularBuffer) Size() int {
+	return c.size
+}
+
+// Write stores sample, overwriting the oldest one once the buffer is full.
+// An unread sample that gets overwritten is dropped from Read.
+func (c *CircularBuffer) Write(sample float64) {
+	c.buffer[c.write] = sample
+	c.write = (c.write + 1) % c.size
+
+	if c.unread == c.size {
+		c.read = (c.read + 1) % c.size
+	} else {
+		c.unread++
+	}
+}
+
+// Read returns the oldest sample not read yet, in the order the samples
+// were written, or 0 when every sample has been read.
+func (c *CircularBuffer) Read() float64 {
+	if c.unread == 0 {
+		return 0
+	}
+
+	sample := c.buffer[c.read]
+	c.read = (c.read + 1) % c.size
+	c.unread--
+
+	return sample
+}
+
+// ReadAt returns the sample written delaySamples writes ago: 0 is the last
+// sample written and Size()-1 the oldest one still held. It does not move
+// the Read cursor. It returns ErrDelayOutOfRange when delaySamples is
+// outside [0, Size()).
+func (c *CircularBuffer) ReadAt(delaySamples int) (float64, error) {
+	if delaySamples < 0 || delaySamples >= c.size {
+		return 0, ErrDelayOutOfRange
+	}
+
+	return c.buffer[(c.write-1-delaySamples+2*c.size)%c.size], nil
+}
+
+// Resize changes the size of the buffer, keeping the most recent samples
+// that still fit, together with their unread state.
+func (c *CircularBuffer) Resize(newSize int) {
+	newSize = max(newSize, 1)
+	kept := min(c.size, newSize)
+
+	buffer := make([]float64, newSize)
+	for i := range kept {
+		// The oldest kept sample lands at index 0, the newest at kept-1.
+		buffer[kept-1-i], _ = c.ReadAt(i)
+	}
+
+	c.unread = min(c.unread, kept)
+	c.size = newSize
+	c.buffer = buffer
+	c.write = kept % newSize
+	c.read = (kept - c.unread) % newSize
+}
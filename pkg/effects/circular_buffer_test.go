@@ -0,0 +1,85 @@
+package effects
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCircularBuffer_ReadAt(t *testing.T) {
+	c := NewCircularBuffer(4)
+
+	for _, v := range []float64{1, 2, 3, 4, 5, 6} {
+		c.Write(v)
+	}
+
+	latest, err := c.ReadAt(0)
+	require.NoError(t, err)
+	require.Equal(t, 6.0, latest)
+
+	oldest, err := c.ReadAt(c.Size() - 1)
+	require.NoError(t, err)
+	require.Equal(t, 3.0, oldest)
+
+	_, err = c.ReadAt(4)
+	require.ErrorIs(t, err, ErrDelayOutOfRange)
+	_, err = c.ReadAt(-1)
+	require.ErrorIs(t, err, ErrDelayOutOfRange)
+}
+
+func TestCircularBuffer_RoundTrip(t *testing.T) {
+	const size = 8
+	c := NewCircularBuffer(size)
+
+	for round := range 3 {
+		for i := range size {
+			c.Write(float64(round*size + i))
+		}
+		for i := range size {
+			require.Equal(t, float64(round*size+i), c.Read())
+		}
+		require.Zero(t, c.Read(), "everything has been read")
+	}
+}
+
+func TestCircularBuffer_OverwriteDropsUnread(t *testing.T) {
+	c := NewCircularBuffer(3)
+	for _, v := range []float64{1, 2, 3, 4, 5} {
+		c.Write(v)
+	}
+
+	require.Equal(t, []float64{3, 4, 5}, []float64{c.Read(), c.Read(), c.Read()})
+	require.Zero(t, c.Read())
+}
+
+func TestCircularBuffer_Resize(t *testing.T) {
+	c := NewCircularBuffer(4)
+	for _, v := range []float64{1, 2, 3, 4, 5} {
+		c.Write(v)
+	}
+	require.Equal(t, 2.0, c.Read())
+
+	// Growing keeps every sample and the unread ones.
+	c.Resize(6)
+	require.Equal(t, 6, c.Size())
+	for delay, want := range []float64{5, 4, 3, 2, 0, 0} {
+		got, err := c.ReadAt(delay)
+		require.NoError(t, err)
+		require.Equal(t, want, got)
+	}
+	c.Write(6)
+	require.Equal(t, []float64{3, 4, 5, 6}, []float64{c.Read(), c.Read(), c.Read(), c.Read()})
+
+	// Shrinking keeps the most recent samples.
+	c.Write(7)
+	c.Resize(2)
+	latest, _ := c.ReadAt(0)
+	previous, _ := c.ReadAt(1)
+	require.Equal(t, []float64{7, 6}, []float64{latest, previous})
+	require.Equal(t, 7.0, c.Read())
+	require.Zero(t, c.Read())
+
+	c.Write(8)
+	c.Write(9)
+	require.Equal(t, 8.0, c.Read())
+}
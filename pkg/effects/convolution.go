@@ -0,0 +1,153 @@
+package effects
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ECecillo/lib.go.sound/pkg/analysis"
+)
+
+var (
+	// ErrInvalidBlockSize is returned when a block size is not a positive
+	// power of two.
+	ErrInvalidBlockSize = errors.New("block size must be a power of two")
+	// ErrEmptyImpulseResponse is returned when convolving with an impulse
+	// response that has no samples.
+	ErrEmptyImpulseResponse = errors.New("impulse response is empty")
+)
+
+// Convolve returns the full linear convolution of signal with ir, with
+// len(signal)+len(ir)-1 samples, computed directly:
+//
+//	y[n] = Σ x[k] * h[n-k]
+//
+// It returns nil when either input is empty.
+func Convolve(signal, ir []float64) []float64 {
+	if len(signal) == 0 || len(ir) == 0 {
+		return nil
+	}
+
+	result := make([]float64, len(signal)+len(ir)-1)
+	for k, x := range signal {
+		for m, h := range ir {
+			result[k+m] += x * h
+		}
+	}
+
+	return result
+}
+
+// UniformPartitionedConvolution convolves a stream with a long impulse
+// response block by block. The impulse response is split into partitions of
+// BlockSize samples whose spectra are computed once. Each input block is
+// transformed with the previous one (overlap-save) and pushed into a
+// frequency-domain delay line, so the output of a block is the sum of the
+// products of the P most recent input spectra with the P partitions:
+//
+//	Y = Σ X[b-p] · H[p]    for p = 0 … P-1
+//
+// The work per block only grows linearly with the impulse response length
+// and the latency is BlockSize samples instead of len(ir): input is
+// gathered until a block is full, so each block is transformed once
+// whatever the size of the chunks passed to ProcessBlock.
+type UniformPartitionedConvolution struct {
+	BlockSize int
+
+	partitions [][]complex128 // Spectra of the impulse response blocks
+	history    [][]complex128 // Input spectra, most recent first
+	previous   []float64      // Last input block, first half of the FFT frame
+	pending    []float64      // Input of the block being gathered
+	ready      []float64      // Output computed but not returned yet
+}
+
+// NewUniformPartitionedConvolution prepares the partitions of ir for
+// blocks of blockSize samples, which must be a power of two.
+func NewUniformPartitionedConvolution(ir []float64, blockSize int) (*UniformPartitionedConvolution, error) {
+	if blockSize <= 0 || blockSize&(blockSize-1) != 0 {
+		return nil, ErrInvalidBlockSize
+	}
+	if len(ir) == 0 {
+		return nil, ErrEmptyImpulseResponse
+	}
+
+	count := (len(ir) + blockSize - 1) / blockSize
+	c := &UniformPartitionedConvolution{
+		BlockSize:  blockSize,
+		partitions: make([][]complex128, count),
+		history:    make([][]complex128, count),
+		previous:   make([]float64, blockSize),
+		pending:    make([]float64, 0, blockSize),
+		// The first block of output is the latency of the convolution.
+		ready: make([]float64, blockSize),
+	}
+
+	for p := range count {
+		frame := make([]float64, 2*blockSize)
+		copy(frame, ir[p*blockSize:min((p+1)*blockSize, len(ir))])
+
+		spectrum, err := analysis.FFT(frame)
+		if err != nil {
+			return nil, fmt.Errorf("unable to transform partition %d, err: %w", p, err)
+		}
+		c.partitions[p] = spectrum
+		c.history[p] = make([]complex128, 2*blockSize)
+	}
+
+	return c, nil
+}
+
+// ProcessBlock returns the next len(input) output samples of the stream,
+// delayed by BlockSize samples: the first BlockSize samples are silence.
+// Input may be split into chunks of any length; it is gathered until a
+// block of BlockSize samples is full, and only then transformed. Passing
+// zeros after the end of the signal flushes the tail of the impulse
+// response.
+func (c *UniformPartitionedConvolution) ProcessBlock(input []float64) []float64 {
+	// len(c.ready) + len(c.pending) is always BlockSize between calls, so
+	// ready holds len(input) samples once the input has been gathered.
+	for len(input) > 0 {
+		taken := min(c.BlockSize-len(c.pending), len(input))
+		c.pending = append(c.pending, input[:taken]...)
+		input = input[taken:]
+
+		if len(c.pending) == c.BlockSize {
+			c.ready = append(c.ready, c.processBlock(c.pending)...)
+			c.pending = c.pending[:0]
+		}
+	}
+
+	n := len(c.ready) - (c.BlockSize - len(c.pending))
+	result := append([]float64(nil), c.ready[:n]...)
+	c.ready = c.ready[n:]
+
+	return result
+}
+
+// processBlock runs one full block through the convolution.
+func (c *UniformPartitionedConvolution) processBlock(block []float64) []float64 {
+	frame := make([]float64, 2*c.BlockSize)
+	copy(frame, c.previous)
+	copy(frame[c.BlockSize:], block)
+	copy(c.previous, block)
+
+	// The frame length is a power of two, checked by the constructor.
+	spectrum, _ := analysis.FFT(frame)
+
+	// Shift the delay line, reusing the oldest slot for the new spectrum.
+	oldest := c.history[len(c.history)-1]
+	copy(c.history[1:], c.history[:len(c.history)-1])
+	copy(oldest, spectrum)
+	c.history[0] = oldest
+
+	sum := make([]complex128, 2*c.BlockSize)
+	for p, h := range c.partitions {
+		for k, x := range c.history[p] {
+			sum[k] += x * h[k]
+		}
+	}
+
+	output, _ := analysis.IFFT(sum)
+
+	// The first half is corrupted by circular wrap-around and discarded.
+	return output[c.BlockSize:]
+}
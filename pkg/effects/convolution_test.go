@@ -0,0 +1,157 @@
+package effects
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"github.com/ECecillo/lib.go.sound/pkg/analysis"
+	"github.com/stretchr/testify/require"
+)
+
+// randomSignal returns length samples of reproducible white noise.
+func randomSignal(length int, seed uint64) []float64 {
+	rng := rand.New(rand.NewPCG(seed, seed))
+	samples := make([]float64, length)
+	for i := range samples {
+		samples[i] = rng.Float64()*2 - 1
+	}
+	return samples
+}
+
+func TestConvolve(t *testing.T) {
+	require.Equal(t, []float64{1, 2.5, 4, 1.5}, Convolve([]float64{1, 2, 3}, []float64{1, 0.5}))
+	require.Equal(t, []float64{0, 0, 1, 2}, Convolve([]float64{1, 2}, []float64{0, 0, 1}))
+	require.Nil(t, Convolve(nil, []float64{1}))
+}
+
+func TestUniformPartitionedConvolution_MatchesConvolve(t *testing.T) {
+	tests := []struct {
+		name      string
+		irLength  int
+		blockSize int
+		chunk     int
+	}{
+		{name: "ir shorter than a block", irLength: 20, blockSize: 64, chunk: 64},
+		{name: "ir of several blocks", irLength: 1000, blockSize: 128, chunk: 128},
+		{name: "ir not a multiple of block", irLength: 333, blockSize: 32, chunk: 32},
+		{name: "chunks of several blocks", irLength: 500, blockSize: 16, chunk: 80},
+		{name: "partial chunks", irLength: 333, blockSize: 32, chunk: 50},
+		{name: "chunks shorter than a block", irLength: 200, blockSize: 64, chunk: 7},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ir := randomSignal(tt.irLength, 1)
+			input := randomSignal(3000, 2)
+			expected := Convolve(input, ir)
+
+			conv, err := NewUniformPartitionedConvolution(ir, tt.blockSize)
+			require.NoError(t, err)
+
+			// Feed the signal followed by enough silence to flush the tail
+			// out of the BlockSize samples of latency.
+			stream := make([]float64, len(expected)+tt.blockSize+tt.chunk)
+			copy(stream, input)
+
+			var output []float64
+			for start := 0; start < len(stream); start += tt.chunk {
+				block := stream[start:min(start+tt.chunk, len(stream))]
+				out := conv.ProcessBlock(block)
+				require.Len(t, out, len(block))
+				output = append(output, out...)
+			}
+
+			require.Equal(t, make([]float64, tt.blockSize), output[:tt.blockSize])
+			require.InDeltaSlice(t, expected, output[tt.blockSize:tt.blockSize+len(expected)], 1e-9)
+		})
+	}
+}
+
+func TestUniformPartitionedConvolution_IrregularChunks(t *testing.T) {
+	ir := randomSignal(300, 1)
+	input := randomSignal(2000, 2)
+	expected := Convolve(input, ir)
+
+	conv, err := NewUniformPartitionedConvolution(ir, 32)
+	require.NoError(t, err)
+
+	stream := make([]float64, len(expected)+32)
+	copy(stream, input)
+
+	var output []float64
+	sizes := []int{1, 31, 32, 33, 0, 100, 5}
+	for i := 0; len(output) < len(stream); i++ {
+		block := stream[len(output):min(len(output)+sizes[i%len(sizes)], len(stream))]
+		out := conv.ProcessBlock(block)
+		require.Len(t, out, len(block))
+		output = append(output, out...)
+	}
+
+	require.InDeltaSlice(t, expected, output[32:], 1e-9)
+}
+
+func TestNewUniformPartitionedConvolution_Errors(t *testing.T) {
+	_, err := NewUniformPartitionedConvolution([]float64{1}, 100)
+	require.ErrorIs(t, err, ErrInvalidBlockSize)
+
+	_, err = NewUniformPartitionedConvolution([]float64{1}, 0)
+	require.ErrorIs(t, err, ErrInvalidBlockSize)
+
+	_, err = NewUniformPartitionedConvolution(nil, 64)
+	require.ErrorIs(t, err, ErrEmptyImpulseResponse)
+}
+
+// fullLengthConvolveBlock convolves one block with the whole impulse
+// response in a single FFT, as a non-partitioned convolver would for every
+// block.
+func fullLengthConvolveBlock(block, ir []float64) []float64 {
+	size := 1
+	for size < len(block)+len(ir)-1 {
+		size <<= 1
+	}
+
+	x := make([]float64, size)
+	copy(x, block)
+	h := make([]float64, size)
+	copy(h, ir)
+
+	xs, _ := analysis.FFT(x)
+	hs, _ := analysis.FFT(h)
+	for k := range xs {
+		xs[k] *= hs[k]
+	}
+	y, _ := analysis.IFFT(xs)
+	return y
+}
+
+func BenchmarkUniformPartitionedConvolution_Block(b *testing.B) {
+	ir := randomSignal(44100, 1)
+	block := randomSignal(256, 2)
+	conv, err := NewUniformPartitionedConvolution(ir, len(block))
+	require.NoError(b, err)
+
+	for b.Loop() {
+		conv.ProcessBlock(block)
+	}
+}
+
+func BenchmarkUniformPartitionedConvolution_PartialBlocks(b *testing.B) {
+	ir := randomSignal(44100, 1)
+	chunk := randomSignal(64, 2)
+	conv, err := NewUniformPartitionedConvolution(ir, 256)
+	require.NoError(b, err)
+
+	// Four chunks fill one block, so this costs one block per four calls.
+	for b.Loop() {
+		conv.ProcessBlock(chunk)
+	}
+}
+
+func BenchmarkFullLengthConvolution_Block(b *testing.B) {
+	ir := randomSignal(44100, 1)
+	block := randomSignal(256, 2)
+
+	for b.Loop() {
+		fullLengthConvolveBlock(block, ir)
+	}
+}
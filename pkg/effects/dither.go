@@ -0,0 +1,77 @@
+package effects
+
+import (
+	"math"
+	"math/rand/v2"
+
+	"github.com/ECecillo/lib.go.sound/pkg/format"
+)
+
+// ditherSeed keeps the dither noise reproducible between runs, in the same
+// way the generators always produce identical output for identical input.
+const ditherSeed = 0x5eed
+
+// noiseShapingFilter holds the 9-tap F-weighted error feedback filter of
+// Wannamaker, designed for 44.1 kHz. Sample n is corrected by
+// Σ noiseShapingFilter[k] * e[n-1-k], e being the requantization error.
+var noiseShapingFilter = [...]float64{2.412, -3.370, 3.937, -4.174, 3.353, -2.205, 1.281, -0.569, 0.0847}
+
+// Dither prepares samples for quantization to format by adding TPDF
+// (triangular) dither noise of 2 LSB peak to peak. This trades the
+// harmonic distortion of low level signals for a benign noise floor.
+//
+// When noiseShaping is true the requantization noise, dither included, is
+// shaped by the error feedback loop of noiseShapingFilter:
+//
+//	NTF(z) = 1 - Σ h[k] * z^-(k+1)
+//
+// with h = noiseShapingFilter. Its response follows the F-weighted (inverse
+// equal loudness) curve: at 44.1 or 48 kHz the noise drops by about 17 dB
+// under 5 kHz and 5 dB under 15 kHz, in exchange for more noise above
+// 15 kHz where hearing is least sensitive.
+//
+// The returned samples already sit on the quantization grid of format,
+// offset by half an LSB away from zero so that the truncating Quantize
+// methods land on the intended level. Floating point formats do not need
+// dithering and get an unmodified copy.
+func Dither(samples []float64, f format.AudioFormat, noiseShaping bool) []float64 {
+	result := make([]float64, len(samples))
+
+	switch f.(type) {
+	case format.Float32, format.Float64:
+		copy(result, samples)
+		return result
+	}
+
+	scale := math.Exp2(float64(f.BitDepth()-1)) - 1
+	rng := rand.New(rand.NewPCG(ditherSeed, uint64(f.BitDepth())))
+
+	// history holds the requantization errors of the previous samples, most
+	// recent first, fed back when noise shaping.
+	var history [len(noiseShapingFilter)]float64
+
+	for i, sample := range samples {
+		value := sample * scale
+		if noiseShaping {
+			for k, h := range noiseShapingFilter {
+				value -= h * history[k]
+			}
+		}
+
+		// Sum of two uniform variables in [-0.5, 0.5) gives a triangular
+		// distribution spanning [-1, 1) LSB.
+		tpdf := rng.Float64() + rng.Float64() - 1.0
+
+		level := math.Round(value + tpdf)
+		level = format.Clamp(level, -scale, scale)
+		copy(history[1:], history[:len(history)-1])
+		history[0] = level - value
+
+		if level != 0 {
+			level += math.Copysign(0.5, level)
+		}
+		result[i] = level / scale
+	}
+
+	return result
+}
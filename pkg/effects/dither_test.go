@@ -0,0 +1,99 @@
+package effects
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+
+	"github.com/ECecillo/lib.go.sound/pkg/analysis"
+	"github.com/ECecillo/lib.go.sound/pkg/format"
+	"github.com/stretchr/testify/require"
+)
+
+// lowLevelSine returns n samples of a -50 dBFS sine close to 1 kHz at
+// sampleRate, landing exactly on an FFT bin so that no spectral leakage
+// pollutes the noise measurement.
+func lowLevelSine(n int, sampleRate float64) []float64 {
+	amplitude := math.Pow(10, -50.0/20)
+	bin := math.Round(1000 * float64(n) / sampleRate)
+	samples := make([]float64, n)
+	for i := range samples {
+		samples[i] = amplitude * math.Sin(2*math.Pi*bin*float64(i)/float64(n))
+	}
+	return samples
+}
+
+// inBandNoise quantizes samples to PCM16 and returns the power of the error
+// against reference in the bins below cutoff.
+func inBandNoise(t *testing.T, reference, samples []float64, sampleRate, cutoff float64) float64 {
+	t.Helper()
+
+	pcm := format.PCM16{}
+	n := len(samples)
+
+	errs := make([]float64, n)
+	for i, v := range samples {
+		decoded := float64(pcm.Quantize(v)) / 32767.0
+		errs[i] = decoded - reference[i]
+	}
+
+	spectrum, err := analysis.FFT(errs)
+	require.NoError(t, err)
+	power := 0.0
+	for k := 1; k < n/2 && float64(k)*sampleRate/float64(n) < cutoff; k++ {
+		power += real(spectrum[k] * cmplx.Conj(spectrum[k]))
+	}
+	return power
+}
+
+func TestDither_ImprovesSNR(t *testing.T) {
+	signal := lowLevelSine(1<<16, 44100)
+
+	direct := inBandNoise(t, signal, signal, 44100, 15000)
+	dithered := inBandNoise(t, signal, Dither(signal, format.PCM16{}, false), 44100, 15000)
+
+	require.Less(t, dithered, direct, "TPDF dither should lower the in-band noise of a -50 dBFS sine")
+}
+
+func TestDither_NoiseShapingLowersInBandNoise(t *testing.T) {
+	for _, sampleRate := range []float64{44100, 48000, 96000} {
+		signal := lowLevelSine(1<<16, sampleRate)
+		flat := Dither(signal, format.PCM16{}, false)
+		shaped := Dither(signal, format.PCM16{}, true)
+
+		for _, cutoff := range []float64{5000, 15000} {
+			direct := inBandNoise(t, signal, signal, sampleRate, cutoff)
+			flatNoise := inBandNoise(t, signal, flat, sampleRate, cutoff)
+			shapedNoise := inBandNoise(t, signal, shaped, sampleRate, cutoff)
+
+			// At least 3 dB less noise in band.
+			require.Less(t, shapedNoise, flatNoise/2,
+				"noise shaping should move noise out of the band below %g Hz at %g Hz", cutoff, sampleRate)
+			require.Less(t, shapedNoise, direct, "below %g Hz at %g Hz", cutoff, sampleRate)
+		}
+	}
+}
+
+func TestDither_StaysWithinTwoLSB(t *testing.T) {
+	pcm := format.PCM16{}
+	signal := lowLevelSine(4096, 44100)
+	dithered := Dither(signal, pcm, false)
+
+	for i := range signal {
+		decoded := float64(pcm.Quantize(dithered[i])) / 32767.0
+		require.LessOrEqual(t, math.Abs(decoded-signal[i]), 1.5/32767.0,
+			"sample %d moved by more than the dither range", i)
+	}
+}
+
+func TestDither_Reproducible(t *testing.T) {
+	signal := lowLevelSine(1024, 44100)
+
+	require.Equal(t, Dither(signal, format.PCM16{}, true), Dither(signal, format.PCM16{}, true))
+}
+
+func TestDither_FloatFormatUnchanged(t *testing.T) {
+	signal := lowLevelSine(256, 44100)
+
+	require.Equal(t, signal, Dither(signal, format.Float64{}, false))
+}
@@ -0,0 +1,73 @@
+package effects
+
+import (
+	"math"
+	"time"
+)
+
+// EnvelopeFollower tracks the amplitude contour of a signal. Its output can
+// drive a sidechain compressor or the cutoff of an auto-wah filter.
+type EnvelopeFollower struct {
+	AttackTime  time.Duration // Time constant used while the signal rises
+	ReleaseTime time.Duration // Time constant used while the signal falls
+}
+
+// Follow returns the smoothed absolute envelope of samples using a one-pole
+// IIR filter:
+//
+//	e[n] = alpha*e[n-1] + (1-alpha)*|x[n]|
+//
+// where alpha is the attack coefficient when |x[n]| rises above the
+// envelope and the release coefficient otherwise.
+func (f EnvelopeFollower) Follow(samples []float64, sampleRate float64) []float64 {
+	attack := smoothingCoefficient(f.AttackTime, sampleRate)
+	release := smoothingCoefficient(f.ReleaseTime, sampleRate)
+
+	result := make([]float64, len(samples))
+	envelope := 0.0
+
+	for n, x := range samples {
+		level := math.Abs(x)
+		envelope = followStep(envelope, level, attack, release)
+		result[n] = envelope
+	}
+
+	return result
+}
+
+// FollowRMS works like Follow but smooths the squared signal and returns
+// its square root, giving a true-RMS envelope.
+func (f EnvelopeFollower) FollowRMS(samples []float64, sampleRate float64) []float64 {
+	attack := smoothingCoefficient(f.AttackTime, sampleRate)
+	release := smoothingCoefficient(f.ReleaseTime, sampleRate)
+
+	result := make([]float64, len(samples))
+	power := 0.0
+
+	for n, x := range samples {
+		power = followStep(power, x*x, attack, release)
+		result[n] = math.Sqrt(power)
+	}
+
+	return result
+}
+
+// followStep advances the envelope by one sample, choosing the attack or
+// release coefficient depending on the direction of the input.
+func followStep(envelope, level, attack, release float64) float64 {
+	alpha := release
+	if level > envelope {
+		alpha = attack
+	}
+	return alpha*envelope + (1-alpha)*level
+}
+
+// smoothingCoefficient converts a time constant into the one-pole
+// coefficient exp(-1 / (tau * sampleRate)). A zero duration yields an
+// instantaneous response.
+func smoothingCoefficient(tau time.Duration, sampleRate float64) float64 {
+	if tau <= 0 || sampleRate <= 0 {
+		return 0.0
+	}
+	return math.Exp(-1.0 / (tau.Seconds() * sampleRate))
+}
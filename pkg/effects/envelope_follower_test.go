@@ -0,0 +1,78 @@
+package effects
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// pulse returns a buffer holding a rectangular half-period pulse of the
+// given amplitude followed by silence.
+func pulse(amplitude float64, pulseLength, totalLength int) []float64 {
+	samples := make([]float64, totalLength)
+	for i := range pulseLength {
+		samples[i] = amplitude
+	}
+	return samples
+}
+
+func TestEnvelopeFollower_AttackAndRelease(t *testing.T) {
+	sampleRate := 10000.0
+	follower := EnvelopeFollower{
+		AttackTime:  10 * time.Millisecond,
+		ReleaseTime: 50 * time.Millisecond,
+	}
+
+	attackSamples := int(follower.AttackTime.Seconds() * sampleRate)
+	releaseSamples := int(follower.ReleaseTime.Seconds() * sampleRate)
+	pulseLength := 10 * attackSamples
+
+	envelope := follower.Follow(pulse(1.0, pulseLength, pulseLength+5*releaseSamples), sampleRate)
+
+	// After one attack time constant the envelope covers 1 - 1/e of the step.
+	require.InDelta(t, 1-1/math.E, envelope[attackSamples-1], 0.01)
+	require.Greater(t, envelope[attackSamples-1], 1/math.E, "envelope did not rise within AttackTime")
+
+	peak := envelope[pulseLength-1]
+	require.InDelta(t, 1.0, peak, 1e-3)
+
+	// After one release time constant the envelope has decayed to 1/e.
+	released := envelope[pulseLength+releaseSamples-1]
+	require.InDelta(t, peak/math.E, released, 0.01)
+	require.LessOrEqual(t, released, peak/math.E+1e-3, "envelope did not fall within ReleaseTime")
+}
+
+func TestEnvelopeFollower_RectifiesInput(t *testing.T) {
+	follower := EnvelopeFollower{}
+	input := []float64{0.5, -0.75, 0.0, -1.0}
+
+	require.Equal(t, []float64{0.5, 0.75, 0.0, 1.0}, follower.Follow(input, 44100))
+}
+
+func TestEnvelopeFollower_FollowRMS(t *testing.T) {
+	sampleRate := 44100.0
+	follower := EnvelopeFollower{
+		AttackTime:  5 * time.Millisecond,
+		ReleaseTime: 5 * time.Millisecond,
+	}
+
+	// A steady 1 kHz sine should settle to an RMS of 1/sqrt(2).
+	samples := make([]float64, int(sampleRate))
+	for i := range samples {
+		samples[i] = math.Sin(2 * math.Pi * 1000 * float64(i) / sampleRate)
+	}
+
+	envelope := follower.FollowRMS(samples, sampleRate)
+	for _, v := range envelope[len(envelope)/2:] {
+		require.InDelta(t, 1/math.Sqrt2, v, 0.05)
+	}
+}
+
+func TestEnvelopeFollower_EmptyInput(t *testing.T) {
+	follower := EnvelopeFollower{AttackTime: time.Millisecond, ReleaseTime: time.Millisecond}
+
+	require.Empty(t, follower.Follow(nil, 44100))
+	require.Empty(t, follower.FollowRMS(nil, 44100))
+}
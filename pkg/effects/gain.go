@@ -0,0 +1,10 @@
+package effects
+
+// Gain returns a copy of samples multiplied by the linear gain.
+func Gain(samples []float64, gain float64) []float64 {
+	result := make([]float64, len(samples))
+	for i, x := range samples {
+		result[i] = x * gain
+	}
+	return result
+}
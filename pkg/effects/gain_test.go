@@ -0,0 +1,15 @@
+package effects
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGain(t *testing.T) {
+	samples := []float64{0.5, -0.25, 0, 1}
+
+	require.Equal(t, []float64{1, -0.5, 0, 2}, Gain(samples, 2))
+	require.Equal(t, []float64{0.5, -0.25, 0, 1}, samples, "input must not be modified")
+	require.Empty(t, Gain(nil, 2))
+}
@@ -0,0 +1,71 @@
+package effects
+
+import (
+	"math"
+	"time"
+
+	"github.com/ECecillo/lib.go.sound/pkg/units"
+)
+
+// LookaheadLimiter keeps a signal below a ceiling by turning the gain down
+// ahead of each peak instead of clipping it.
+type LookaheadLimiter struct {
+	Ceiling       float64       // Maximum output level in dBFS
+	LookaheadTime time.Duration // How long before a peak the gain starts to fall
+	ReleaseTime   time.Duration // Time constant of the gain recovery after a peak
+}
+
+// Apply returns samples limited to Ceiling. For every sample the gain
+// needed to keep it under the ceiling is computed, in dB. The gain curve is
+// the minimum of that requirement over the next LookaheadTime, released
+// with a one-pole filter of time constant ReleaseTime and finally averaged
+// over LookaheadTime. Every averaged value only involves windows that
+// contain the sample, so the gain never exceeds its requirement, and the
+// gain reduction is spread as a ramp starting LookaheadTime before the
+// peak. Since the whole signal is available, the output is not delayed.
+func (l LookaheadLimiter) Apply(samples []float64, sampleRate float64) []float64 {
+	lookahead := int(l.LookaheadTime.Seconds() * sampleRate)
+	release := smoothingCoefficient(l.ReleaseTime, sampleRate)
+	ceiling := units.DBFSToLinear(l.Ceiling)
+
+	required := make([]float64, len(samples))
+	for n, x := range samples {
+		if level := math.Abs(x); level > ceiling {
+			required[n] = units.LinearToDB(ceiling / level)
+		}
+	}
+
+	// Minimum requirement over [n, n+lookahead], then released.
+	held := make([]float64, len(samples))
+	previous := 0.0
+	for n := range held {
+		target := 0.0
+		for k := n; k <= n+lookahead && k < len(required); k++ {
+			target = math.Min(target, required[k])
+		}
+
+		if target > previous {
+			target = release*previous + (1-release)*target
+		}
+		held[n] = target
+		previous = target
+	}
+
+	// Average over [n-lookahead, n]. Samples before the start count as
+	// requiring no reduction.
+	result := make([]float64, len(samples))
+	var sum float64
+	for n, x := range samples {
+		sum += held[n]
+		if n > lookahead {
+			sum -= held[n-lookahead-1]
+		}
+
+		gain := units.DBToLinear(sum / float64(lookahead+1))
+
+		// Guard against the rounding of the dB conversions.
+		result[n] = math.Max(-ceiling, math.Min(ceiling, x*gain))
+	}
+
+	return result
+}
@@ -0,0 +1,76 @@
+package effects
+
+import (
+	"math"
+	"math/rand/v2"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ECecillo/lib.go.sound/pkg/units"
+)
+
+// burstySignal returns a 1 kHz sine whose level jumps between quiet and
+// loud sections, with a few isolated spikes.
+func burstySignal(sampleRate float64) []float64 {
+	rng := rand.New(rand.NewPCG(9, 10))
+	samples := make([]float64, int(sampleRate))
+	for n := range samples {
+		level := 0.3
+		if (n/4410)%2 == 1 {
+			level = 1.5
+		}
+		samples[n] = level * math.Sin(2*math.Pi*1000*float64(n)/sampleRate)
+	}
+	for range 5 {
+		samples[rng.IntN(len(samples))] = 2.0
+	}
+	return samples
+}
+
+func TestLookaheadLimiter_NeverExceedsCeiling(t *testing.T) {
+	sampleRate := 44100.0
+	limiter := LookaheadLimiter{Ceiling: -1, LookaheadTime: 5 * time.Millisecond, ReleaseTime: 50 * time.Millisecond}
+
+	output := limiter.Apply(burstySignal(sampleRate), sampleRate)
+
+	ceiling := units.DBFSToLinear(-1)
+	for n, v := range output {
+		require.LessOrEqual(t, math.Abs(v), ceiling, "sample %d", n)
+	}
+}
+
+func TestLookaheadLimiter_SmoothGain(t *testing.T) {
+	sampleRate := 44100.0
+	limiter := LookaheadLimiter{Ceiling: -1, LookaheadTime: 5 * time.Millisecond, ReleaseTime: 50 * time.Millisecond}
+
+	// Measure the gain curve on a constant signal with a loud section.
+	input := make([]float64, int(sampleRate))
+	for n := range input {
+		input[n] = 0.5
+		if n >= 10000 && n < 20000 {
+			input[n] = 1.5
+		}
+	}
+	output := limiter.Apply(input, sampleRate)
+
+	previous := 0.0
+	for n := range input {
+		gainDB := units.LinearToDB(output[n] / input[n])
+		require.InDelta(t, previous, gainDB, 0.1, "gain step at sample %d", n)
+		previous = gainDB
+	}
+
+	// The gain starts falling before the loud section and recovers after.
+	require.Less(t, output[9999], 0.5)
+	require.InDelta(t, units.DBFSToLinear(-1), output[15000], 1e-9)
+	require.InDelta(t, 0.5, output[len(output)-1], 1e-3)
+}
+
+func TestLookaheadLimiter_QuietSignalUntouched(t *testing.T) {
+	limiter := LookaheadLimiter{Ceiling: -3, LookaheadTime: time.Millisecond, ReleaseTime: 10 * time.Millisecond}
+	input := []float64{0.1, -0.2, 0.3, -0.4}
+
+	require.Equal(t, input, limiter.Apply(input, 44100))
+}
@@ -0,0 +1,97 @@
+package effects
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/cmplx"
+
+	"github.com/ECecillo/lib.go.sound/pkg/analysis"
+)
+
+var (
+	// ErrLengthMismatch is returned when two signals that are combined
+	// sample by sample have different lengths.
+	ErrLengthMismatch = errors.New("signals must have the same length")
+	// ErrInvalidAmount is returned when a morph amount lies outside [0, 1].
+	ErrInvalidAmount = errors.New("amount must be in [0, 1]")
+)
+
+// Morph cross-fades linearly from a to b:
+//
+//	y[n] = (1-amount)*a[n] + amount*b[n]
+//
+// An amount of 0 returns a copy of a and 1 a copy of b. a and b must have
+// the same length.
+func Morph(a, b []float64, amount float64) ([]float64, error) {
+	if err := validateMorph(a, b, amount); err != nil {
+		return nil, err
+	}
+
+	result := make([]float64, len(a))
+	for n := range result {
+		result[n] = (1-amount)*a[n] + amount*b[n]
+	}
+
+	return result, nil
+}
+
+// SpectralMorph blends the timbres of a and b rather than their
+// waveforms. Both signals are analyzed with a Hann-windowed STFT of
+// fftSize bins and 75% overlap; in each bin the log-magnitudes are
+// interpolated and the phase moves from a towards b along the shortest
+// arc:
+//
+//	|Y| = exp((1-amount)*ln|A| + amount*ln|B|) = |A|^(1-amount) * |B|^amount
+//	∠Y = ∠A + amount*wrap(∠B - ∠A)
+//
+// where wrap brings an angle back to (-π, π]. Interpolating in the log
+// domain makes the spectral envelope slide from one sound to the other
+// instead of superimposing them. fftSize must be a power of two and a and
+// b must have the same length.
+func SpectralMorph(a, b []float64, amount float64, fftSize int) ([]float64, error) {
+	if err := validateMorph(a, b, amount); err != nil {
+		return nil, err
+	}
+
+	hopSize := max(fftSize/4, 1)
+	window := analysis.HannWindow(fftSize)
+
+	framesA, err := analysis.STFT(a, fftSize, hopSize, window)
+	if err != nil {
+		return nil, fmt.Errorf("unable to analyze first signal, err: %w", err)
+	}
+	framesB, err := analysis.STFT(b, fftSize, hopSize, window)
+	if err != nil {
+		return nil, fmt.Errorf("unable to analyze second signal, err: %w", err)
+	}
+
+	for i, frame := range framesA {
+		for k, x := range frame {
+			y := framesB[i][k]
+
+			magnitude := math.Pow(cmplx.Abs(x), 1-amount) * math.Pow(cmplx.Abs(y), amount)
+			delta := cmplx.Phase(y) - cmplx.Phase(x)
+			delta -= 2 * math.Pi * math.Round(delta/(2*math.Pi))
+
+			frame[k] = cmplx.Rect(magnitude, cmplx.Phase(x)+amount*delta)
+		}
+	}
+
+	result, err := analysis.ISTFT(framesA, fftSize, hopSize, window, len(a))
+	if err != nil {
+		return nil, fmt.Errorf("unable to resynthesize signal, err: %w", err)
+	}
+
+	return result, nil
+}
+
+func validateMorph(a, b []float64, amount float64) error {
+	if len(a) != len(b) {
+		return ErrLengthMismatch
+	}
+	if amount < 0 || amount > 1 {
+		return ErrInvalidAmount
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package effects
+
+import (
+	"testing"
+
+	"github.com/ECecillo/lib.go.sound/pkg/analysis"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMorph_Endpoints(t *testing.T) {
+	a := randomSignal(512, 1)
+	b := randomSignal(512, 2)
+
+	output, err := Morph(a, b, 0)
+	require.NoError(t, err)
+	require.Equal(t, a, output)
+
+	output, err = Morph(a, b, 1)
+	require.NoError(t, err)
+	require.Equal(t, b, output)
+
+	output, err = Morph(a, b, 0.25)
+	require.NoError(t, err)
+	for n := range output {
+		require.InDelta(t, 0.75*a[n]+0.25*b[n], output[n], 1e-12)
+	}
+}
+
+func TestSpectralMorph_SameSignal(t *testing.T) {
+	x := randomSignal(4096, 3)
+
+	output, err := SpectralMorph(x, x, 0.5, 512)
+	require.NoError(t, err)
+	require.Len(t, output, len(x))
+
+	for n := range x {
+		require.InDelta(t, x[n], output[n], 1e-9, "sample %d", n)
+	}
+}
+
+func TestSpectralMorph_CentroidMovesContinuously(t *testing.T) {
+	sampleRate := 44100.0
+	// A dark and a bright noise: their spectral envelopes overlap
+	// everywhere, with opposite tilts.
+	a := randomSignal(8192, 4)
+	b := randomSignal(8192, 5)
+	for n := len(a) - 1; n > 0; n-- {
+		b[n] -= b[n-1]
+	}
+	for n := 1; n < len(a); n++ {
+		a[n] += 0.9 * a[n-1]
+	}
+
+	centroidA, err := analysis.SpectralCentroid(a, sampleRate)
+	require.NoError(t, err)
+	centroidB, err := analysis.SpectralCentroid(b, sampleRate)
+	require.NoError(t, err)
+
+	previous := 0.0
+	for step := 0; step <= 10; step++ {
+		amount := float64(step) / 10
+
+		output, err := SpectralMorph(a, b, amount, 1024)
+		require.NoError(t, err)
+
+		centroid, err := analysis.SpectralCentroid(output, sampleRate)
+		require.NoError(t, err)
+
+		switch step {
+		case 0:
+			require.InEpsilon(t, centroidA, centroid, 0.01)
+		case 10:
+			require.InEpsilon(t, centroidB, centroid, 0.01)
+		default:
+			require.Greater(t, centroid, previous, "amount %.1f", amount)
+			// No single step jumps over most of the range.
+			require.Less(t, centroid-previous, 0.25*(centroidB-centroidA), "amount %.1f", amount)
+		}
+		previous = centroid
+	}
+}
+
+func TestMorph_Errors(t *testing.T) {
+	_, err := Morph(make([]float64, 4), make([]float64, 5), 0.5)
+	require.ErrorIs(t, err, ErrLengthMismatch)
+
+	_, err = SpectralMorph(make([]float64, 4), make([]float64, 4), 1.5, 4)
+	require.ErrorIs(t, err, ErrInvalidAmount)
+
+	_, err = SpectralMorph(make([]float64, 64), make([]float64, 64), 0.5, 48)
+	require.ErrorIs(t, err, analysis.ErrNotPowerOfTwo)
+}
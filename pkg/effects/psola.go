@@ -0,0 +1,67 @@
+package effects
+
+import (
+	"errors"
+	"math"
+
+	"github.com/ECecillo/lib.go.sound/pkg/analysis"
+)
+
+var (
+	// ErrInvalidPitchPeriod is returned when the pitch period is not a
+	// positive number of samples.
+	ErrInvalidPitchPeriod = errors.New("pitch period must be positive")
+	// ErrInvalidSampleRate is returned when the sample rate is not positive.
+	ErrInvalidSampleRate = errors.New("sample rate must be positive")
+)
+
+// PSOLAPitchShift shifts the pitch of a periodic signal by semitoneDelta
+// semitones with Pitch-Synchronous Overlap-Add, keeping its duration and
+// its formants.
+//
+// Analysis marks are placed every pitchPeriod samples. Synthesis marks are
+// placed every pitchPeriod/ratio samples, with
+//
+//	ratio = 2^(semitoneDelta/12)
+//
+// and each one receives the Hann-windowed grain of 2*pitchPeriod samples
+// centered on the nearest analysis mark. Every grain still holds a single
+// period of the source, so the spectral envelope is preserved while the
+// repetition rate, the perceived pitch, follows the synthesis marks.
+//
+// Hann windows of 2*pitchPeriod samples spaced by pitchPeriod add up to
+// one, so a shift of 0 reproduces the input. Closer synthesis marks overlap
+// ratio times more and the grains are scaled by 1/ratio so that the windows
+// still add up to one on average.
+func PSOLAPitchShift(samples []float64, semitoneDelta float64, sampleRate float64, pitchPeriod int) ([]float64, error) {
+	if pitchPeriod <= 0 {
+		return nil, ErrInvalidPitchPeriod
+	}
+	if sampleRate <= 0 {
+		return nil, ErrInvalidSampleRate
+	}
+
+	ratio := math.Exp2(semitoneDelta / 12)
+	hop := float64(pitchPeriod) / ratio
+	window := analysis.HannWindow(2 * pitchPeriod)
+
+	result := make([]float64, len(samples))
+
+	for mark := 0.0; mark < float64(len(samples)+pitchPeriod); mark += hop {
+		synthesis := int(math.Round(mark))
+		analysisMark := int(math.Round(mark/float64(pitchPeriod))) * pitchPeriod
+
+		for i, w := range window {
+			offset := i - pitchPeriod
+			out := synthesis + offset
+			in := analysisMark + offset
+			if out < 0 || out >= len(result) || in < 0 || in >= len(samples) {
+				continue
+			}
+
+			result[out] += w * samples[in] / ratio
+		}
+	}
+
+	return result, nil
+}
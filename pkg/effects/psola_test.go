@@ -0,0 +1,69 @@
+package effects
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ECecillo/lib.go.sound/pkg/analysis"
+	"github.com/stretchr/testify/require"
+)
+
+// pulseTrain returns a voice-like signal: a decaying 1 kHz resonance
+// restarted every period samples.
+func pulseTrain(period, length int, sampleRate float64) []float64 {
+	samples := make([]float64, length)
+	for n := range samples {
+		t := float64(n%period) / sampleRate
+		samples[n] = math.Exp(-t*800) * math.Sin(2*math.Pi*1000*t)
+	}
+	return samples
+}
+
+// dominantPeriod returns the lag of the highest autocorrelation peak in
+// [minLag, maxLag].
+func dominantPeriod(t *testing.T, samples []float64, minLag, maxLag int) int {
+	t.Helper()
+	correlation, err := analysis.Autocorrelation(samples)
+	require.NoError(t, err)
+
+	zero := len(samples) - 1
+	best := minLag
+	for lag := minLag; lag <= maxLag; lag++ {
+		if correlation[zero+lag] > correlation[zero+best] {
+			best = lag
+		}
+	}
+	return best
+}
+
+func TestPSOLAPitchShift_Identity(t *testing.T) {
+	const sampleRate = 44100.0
+	input := pulseTrain(200, 4000, sampleRate)
+
+	output, err := PSOLAPitchShift(input, 0, sampleRate, 200)
+	require.NoError(t, err)
+	require.InDeltaSlice(t, input, output, 1e-9)
+}
+
+func TestPSOLAPitchShift_Octave(t *testing.T) {
+	const sampleRate = 44100.0
+	input := pulseTrain(200, 8000, sampleRate)
+	require.Equal(t, 200, dominantPeriod(t, input, 60, 300))
+
+	up, err := PSOLAPitchShift(input, 12, sampleRate, 200)
+	require.NoError(t, err)
+	require.Len(t, up, len(input))
+	require.Equal(t, 100, dominantPeriod(t, up, 60, 300))
+
+	down, err := PSOLAPitchShift(input, -12, sampleRate, 200)
+	require.NoError(t, err)
+	require.Equal(t, 400, dominantPeriod(t, down, 300, 600))
+}
+
+func TestPSOLAPitchShift_Errors(t *testing.T) {
+	_, err := PSOLAPitchShift([]float64{1}, 0, 44100, 0)
+	require.ErrorIs(t, err, ErrInvalidPitchPeriod)
+
+	_, err = PSOLAPitchShift([]float64{1}, 0, 0, 100)
+	require.ErrorIs(t, err, ErrInvalidSampleRate)
+}
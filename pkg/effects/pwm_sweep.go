@@ -0,0 +1,70 @@
+package effects
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/ECecillo/lib.go.sound/pkg/wave"
+)
+
+// ErrInvalidDutyRange is returned when the duty cycle bounds are not
+// ordered or fall outside (0, 1).
+var ErrInvalidDutyRange = errors.New("duty cycle range must satisfy 0 < DutyMin <= DutyMax < 1")
+
+// PWMSweep is a pulse oscillator whose duty cycle is swept by a sine LFO
+// between DutyMin and DutyMax, giving the moving, chorus-like timbre of
+// classic analog pads.
+type PWMSweep struct {
+	BaseFreq     float64       // Frequency of the pulse in Hz
+	LFORate      float64       // Frequency of the duty cycle sweep in Hz
+	DutyMin      float64       // Lowest duty cycle reached by the sweep
+	DutyMax      float64       // Highest duty cycle reached by the sweep
+	Duration     time.Duration // Duration of the signal
+	SamplingRate float64       // Sampling frequency in Hz
+}
+
+// NewPWMSweep returns a sweep sampled at 44.1 kHz.
+func NewPWMSweep(baseFreq, lfoRate, dutyMin, dutyMax float64, duration time.Duration) *PWMSweep {
+	return &PWMSweep{
+		BaseFreq:     baseFreq,
+		LFORate:      lfoRate,
+		DutyMin:      dutyMin,
+		DutyMax:      dutyMax,
+		Duration:     duration,
+		SamplingRate: 44100.0,
+	}
+}
+
+// Generate runs two phase accumulators: one for the audio pulse and one
+// for the LFO, which sets the duty cycle of each sample to
+//
+//	duty = DutyMin + (DutyMax-DutyMin) * (1 + sin(2π*lfoPhase)) / 2
+//
+// The pulse itself is the PolyBLEP waveform of wave.Pulse.
+func (p PWMSweep) Generate() ([]float64, error) {
+	if p.DutyMin <= 0 || p.DutyMax >= 1 || p.DutyMin > p.DutyMax {
+		return nil, ErrInvalidDutyRange
+	}
+
+	totalSamples := max(int(p.SamplingRate*p.Duration.Seconds()), 0)
+	result := make([]float64, totalSamples)
+
+	increment := p.BaseFreq / p.SamplingRate
+	lfoIncrement := p.LFORate / p.SamplingRate
+
+	phase, lfoPhase := 0.0, 0.0
+	for i := range result {
+		sweep := (1 + math.Sin(2*math.Pi*lfoPhase)) / 2
+		duty := p.DutyMin + (p.DutyMax-p.DutyMin)*sweep
+
+		result[i] = wave.PulseAt(phase, increment, duty)
+
+		phase += increment
+		phase -= math.Floor(phase)
+		lfoPhase += lfoIncrement
+		lfoPhase -= math.Floor(lfoPhase)
+	}
+
+	return result, nil
+}
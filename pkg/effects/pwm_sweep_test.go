@@ -0,0 +1,70 @@
+package effects
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+	"time"
+
+	"github.com/ECecillo/lib.go.sound/pkg/analysis"
+	"github.com/ECecillo/lib.go.sound/pkg/wave"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPWMSweep_ConstantDutyMatchesPulse(t *testing.T) {
+	sweep := NewPWMSweep(220.0, 3.0, 0.3, 0.3, 200*time.Millisecond)
+
+	samples, err := sweep.Generate()
+	require.NoError(t, err)
+
+	expected, err := wave.NewPulse(220.0, 0.3, 200*time.Millisecond).Generate()
+	require.NoError(t, err)
+
+	require.Equal(t, expected, samples)
+}
+
+func TestPWMSweep_SpectrumMoves(t *testing.T) {
+	// With a 1 Hz LFO the duty cycle peaks at 0.5 after 250ms, where the
+	// pulse is a square without even harmonics, and bottoms out at 0.1
+	// after 750ms, where harmonic k is proportional to sin(0.1πk)/k and the
+	// second harmonic is almost as strong as the fundamental.
+	sweep := NewPWMSweep(441.0, 1.0, 0.1, 0.5, time.Second)
+	samples, err := sweep.Generate()
+	require.NoError(t, err)
+
+	secondHarmonicRatio := func(center int) float64 {
+		frame := 2048
+		input := make([]float64, frame)
+		for n := range input {
+			window := 0.5 - 0.5*math.Cos(2*math.Pi*float64(n)/float64(frame-1))
+			input[n] = samples[center-frame/2+n] * window
+		}
+		spectrum, err := analysis.FFT(input)
+		require.NoError(t, err)
+
+		peak := func(freq float64) float64 {
+			bin := int(math.Round(freq * float64(frame) / sweep.SamplingRate))
+			best := 0.0
+			for k := bin - 2; k <= bin+2; k++ {
+				best = math.Max(best, cmplx.Abs(spectrum[k]))
+			}
+			return best
+		}
+		return peak(2*441.0) / peak(441.0)
+	}
+
+	square := secondHarmonicRatio(11025)
+	narrow := secondHarmonicRatio(33075)
+
+	require.Less(t, square, 0.2)
+	require.Greater(t, narrow, 0.8)
+}
+
+func TestPWMSweep_InvalidDutyRange(t *testing.T) {
+	ranges := [][2]float64{{0, 0.5}, {0.2, 1}, {0.6, 0.4}, {-0.1, 0.5}}
+
+	for _, r := range ranges {
+		_, err := NewPWMSweep(220.0, 1.0, r[0], r[1], time.Second).Generate()
+		require.ErrorIs(t, err, ErrInvalidDutyRange, "range %v", r)
+	}
+}
@@ -0,0 +1,51 @@
+package effects
+
+import (
+	"math"
+)
+
+// SampleHold latches signal on every tick of a clock running at clockHz and
+// holds the value until the next tick, producing the staircase of an
+// analog sample-and-hold. Ticks fall every sampleRate/clockHz samples,
+// starting on the first sample. A non-positive clock only ticks once.
+func SampleHold(signal []float64, clockHz float64, sampleRate float64) []float64 {
+	result := make([]float64, len(signal))
+	if len(signal) == 0 {
+		return result
+	}
+
+	interval := math.Inf(1)
+	if clockHz > 0 {
+		interval = sampleRate / clockHz
+	}
+
+	latched := signal[0]
+	next := interval
+	for n, x := range signal {
+		// Latch on the first sample at or after the next tick.
+		if float64(n) >= next {
+			latched = x
+			next = (math.Floor(float64(n)/interval) + 1) * interval
+		}
+		result[n] = latched
+	}
+
+	return result
+}
+
+// TrackHold follows input while gate is non-zero and freezes the last
+// tracked value while it is zero. The output starts at zero until the gate
+// first opens; samples beyond the end of gate are held.
+func TrackHold(input, gate []float64) []float64 {
+	result := make([]float64, len(input))
+
+	held := 0.0
+	for n, x := range input {
+		if n < len(gate) && gate[n] != 0 {
+			held = x
+		}
+		result[n] = held
+	}
+
+	return result
+}
@@ -0,0 +1,49 @@
+package effects
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func ramp(length int) []float64 {
+	samples := make([]float64, length)
+	for i := range samples {
+		samples[i] = float64(i)
+	}
+	return samples
+}
+
+func TestSampleHold_Staircase(t *testing.T) {
+	// A 100 Hz clock at 8 kHz ticks every 80 samples.
+	output := SampleHold(ramp(800), 100.0, 8000.0)
+	require.Len(t, output, 800)
+
+	for n, v := range output {
+		require.Equal(t, float64(n/80*80), v, "sample %d", n)
+	}
+}
+
+func TestSampleHold_FractionalInterval(t *testing.T) {
+	// Ticks every 2.5 samples latch samples 0, 3, 5, 8, 10, ...
+	output := SampleHold(ramp(11), 4.0, 10.0)
+	require.Equal(t, []float64{0, 0, 0, 3, 3, 5, 5, 5, 8, 8, 10}, output)
+}
+
+func TestSampleHold_EdgeCases(t *testing.T) {
+	require.Empty(t, SampleHold(nil, 100.0, 8000.0))
+	require.Equal(t, []float64{3, 3, 3}, SampleHold([]float64{3, 4, 5}, 0, 8000.0))
+
+	// A clock as fast as the sample rate passes the signal through.
+	require.Equal(t, ramp(10), SampleHold(ramp(10), 8000.0, 8000.0))
+}
+
+func TestTrackHold(t *testing.T) {
+	input := []float64{1, 2, 3, 4, 5, 6, 7}
+	gate := []float64{0, 1, 1, 0, 0, 1, 0}
+
+	require.Equal(t, []float64{0, 2, 3, 3, 3, 6, 6}, TrackHold(input, gate))
+
+	// Past the end of the gate the latch holds.
+	require.Equal(t, []float64{1, 2, 2, 2}, TrackHold([]float64{1, 2, 3, 4}, []float64{1, 1}))
+}
@@ -0,0 +1,93 @@
+package effects
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/cmplx"
+
+	"github.com/ECecillo/lib.go.sound/pkg/analysis"
+)
+
+var (
+	// ErrNoiseProfileTooShort is returned when the noise profile does not
+	// cover a single analysis frame.
+	ErrNoiseProfileTooShort = errors.New("noise profile is shorter than one frame")
+	// ErrInvalidSubtraction is returned when the over-subtraction factor or
+	// the spectral floor is negative.
+	ErrInvalidSubtraction = errors.New("alpha and beta must not be negative")
+)
+
+// subtractionFrameTime is the approximate duration in seconds of the
+// spectral subtraction frames, rounded up to a power of two samples.
+const subtractionFrameTime = 0.02
+
+// SpectralSubtract removes stationary background noise from noisy. The
+// noise magnitude spectrum |N(f)| is averaged over the frames of
+// noiseProfile, a stretch of noise-only audio. Each frame of noisy is then
+// attenuated bin by bin and rebuilt with its original phase:
+//
+//	|Y(f)| = max(|X(f)| - alpha*|N(f)|, beta*|N(f)|)
+//
+// alpha above 1 over-subtracts to hide the residual noise, while the
+// spectral floor beta limits the "musical noise" left by bins dropping to
+// zero. Frames last about 20 ms with 75% overlap.
+func SpectralSubtract(noisy, noiseProfile []float64, sampleRate float64, alpha, beta float64) ([]float64, error) {
+	if sampleRate <= 0 {
+		return nil, ErrInvalidSampleRate
+	}
+	if alpha < 0 || beta < 0 {
+		return nil, ErrInvalidSubtraction
+	}
+
+	frameSize := 1
+	for frameSize < int(sampleRate*subtractionFrameTime) {
+		frameSize <<= 1
+	}
+	hopSize := frameSize / 4
+	window := analysis.HannWindow(frameSize)
+
+	if len(noiseProfile) < frameSize {
+		return nil, ErrNoiseProfileTooShort
+	}
+
+	// Only the frames fully inside the profile are averaged, the first and
+	// last STFT frames being partly zero-padded.
+	noiseFrames, err := analysis.STFT(noiseProfile, frameSize, hopSize, window)
+	if err != nil {
+		return nil, fmt.Errorf("unable to analyze noise profile, err: %w", err)
+	}
+	noise := make([]float64, frameSize)
+	count := 0
+	for i, frame := range noiseFrames {
+		center := i * hopSize
+		if center < frameSize/2 || center+frameSize/2 > len(noiseProfile) {
+			continue
+		}
+		for k, x := range frame {
+			noise[k] += cmplx.Abs(x)
+		}
+		count++
+	}
+	for k := range noise {
+		noise[k] /= float64(count)
+	}
+
+	frames, err := analysis.STFT(noisy, frameSize, hopSize, window)
+	if err != nil {
+		return nil, fmt.Errorf("unable to analyze signal, err: %w", err)
+	}
+
+	for _, frame := range frames {
+		for k, x := range frame {
+			magnitude := math.Max(cmplx.Abs(x)-alpha*noise[k], beta*noise[k])
+			frame[k] = cmplx.Rect(magnitude, cmplx.Phase(x))
+		}
+	}
+
+	result, err := analysis.ISTFT(frames, frameSize, hopSize, window, len(noisy))
+	if err != nil {
+		return nil, fmt.Errorf("unable to resynthesize signal, err: %w", err)
+	}
+	return result, nil
+}
@@ -0,0 +1,72 @@
+package effects
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// snr returns the ratio in dB between the power of clean and the power of
+// the difference between processed and clean.
+func snr(clean, processed []float64) float64 {
+	var signal, noise float64
+	for n := range clean {
+		signal += clean[n] * clean[n]
+		diff := processed[n] - clean[n]
+		noise += diff * diff
+	}
+	return 10 * math.Log10(signal/noise)
+}
+
+func TestSpectralSubtract_ImprovesSNR(t *testing.T) {
+	const sampleRate = 44100.0
+
+	clean := make([]float64, int(sampleRate))
+	for n := range clean {
+		clean[n] = 0.5 * math.Sin(2*math.Pi*1000*float64(n)/sampleRate)
+	}
+
+	noise := randomSignal(len(clean), 7)
+	noisy := make([]float64, len(clean))
+	for n := range noisy {
+		noisy[n] = clean[n] + 0.3*noise[n]
+	}
+
+	profile := randomSignal(int(sampleRate/2), 8)
+	for n := range profile {
+		profile[n] *= 0.3
+	}
+
+	before := snr(clean, noisy)
+	denoised, err := SpectralSubtract(noisy, profile, sampleRate, 2, 0.01)
+	require.NoError(t, err)
+	require.Len(t, denoised, len(noisy))
+
+	after := snr(clean, denoised)
+	require.Greater(t, after-before, 10.0, "SNR went from %.1f dB to %.1f dB", before, after)
+}
+
+func TestSpectralSubtract_NoSubtraction(t *testing.T) {
+	signal := randomSignal(4096, 1)
+	profile := randomSignal(2048, 2)
+
+	// Without subtraction and with a zero floor the signal is rebuilt as is.
+	result, err := SpectralSubtract(signal, profile, 44100, 0, 0)
+	require.NoError(t, err)
+	require.InDeltaSlice(t, signal, result, 1e-9)
+}
+
+func TestSpectralSubtract_Errors(t *testing.T) {
+	signal := randomSignal(4096, 1)
+	profile := randomSignal(2048, 2)
+
+	_, err := SpectralSubtract(signal, profile[:100], 44100, 1, 0)
+	require.ErrorIs(t, err, ErrNoiseProfileTooShort)
+
+	_, err = SpectralSubtract(signal, profile, 0, 1, 0)
+	require.ErrorIs(t, err, ErrInvalidSampleRate)
+
+	_, err = SpectralSubtract(signal, profile, 44100, -1, 0)
+	require.ErrorIs(t, err, ErrInvalidSubtraction)
+}
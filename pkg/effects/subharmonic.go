@@ -0,0 +1,81 @@
+package effects
+
+import (
+	"errors"
+	"time"
+
+	"github.com/ECecillo/lib.go.sound/pkg/filter"
+)
+
+var (
+	// ErrInvalidFundamental is returned when a fundamental frequency is not
+	// between 0 and Nyquist.
+	ErrInvalidFundamental = errors.New("fundamental must be between 0 and Nyquist")
+	// ErrInvalidMix is returned when a mix amount lies outside [0, 1].
+	ErrInvalidMix = errors.New("mix must be in [0, 1]")
+)
+
+// subHarmonicOrder is the order of the Linkwitz-Riley low-passes used to
+// isolate the fundamental before the divider and to smooth the divided
+// square wave. At order 8 the third harmonic of the square, 1.5 times the
+// fundamental, ends up about 24 dB below the sub-harmonic.
+const subHarmonicOrder = 8
+
+// SubHarmonic adds a tone one octave below fundamental, the way analog
+// bass enhancers do:
+//
+//  1. the input is low-passed at 1.5*fundamental so only the fundamental
+//     drives the zero crossing detector;
+//  2. a flip-flop toggles on every rising zero crossing, giving a ±1
+//     square wave at fundamental/2;
+//  3. the square is scaled by the envelope of the input so the sub follows
+//     its dynamics, then low-passed at fundamental to keep only its first
+//     harmonic;
+//  4. the sub is added to the input: y[n] = x[n] + mix*sub[n].
+//
+// The sub comes out at about the level of the input. mix must be in
+// [0, 1], a mix of 0 returns a copy of samples.
+func SubHarmonic(samples []float64, fundamental float64, sampleRate, mix float64) ([]float64, error) {
+	if sampleRate <= 0 {
+		return nil, ErrInvalidSampleRate
+	}
+	if fundamental <= 0 || 1.5*fundamental >= sampleRate/2 {
+		return nil, ErrInvalidFundamental
+	}
+	if mix < 0 || mix > 1 {
+		return nil, ErrInvalidMix
+	}
+
+	result := append([]float64(nil), samples...)
+	if mix == 0 {
+		return result, nil
+	}
+
+	detector, _, err := filter.NewLinkwitzRiley(1.5*fundamental, sampleRate, subHarmonicOrder)
+	if err != nil {
+		return nil, err
+	}
+	smoothing, _, err := filter.NewLinkwitzRiley(fundamental, sampleRate, subHarmonicOrder)
+	if err != nil {
+		return nil, err
+	}
+
+	follower := EnvelopeFollower{AttackTime: 5 * time.Millisecond, ReleaseTime: 50 * time.Millisecond}
+	envelope := follower.Follow(samples, sampleRate)
+
+	isolated := filter.ProcessCascade(detector, samples)
+	square := make([]float64, len(samples))
+	state := 1.0
+	for n, x := range isolated {
+		if n > 0 && isolated[n-1] < 0 && x >= 0 {
+			state = -state
+		}
+		square[n] = state * envelope[n]
+	}
+
+	for n, v := range filter.ProcessCascade(smoothing, square) {
+		result[n] += mix * v
+	}
+
+	return result, nil
+}
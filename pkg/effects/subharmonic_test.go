@@ -0,0 +1,73 @@
+package effects
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ECecillo/lib.go.sound/pkg/analysis"
+	"github.com/stretchr/testify/require"
+)
+
+func sineSignal(freq, sampleRate float64, length int) []float64 {
+	samples := make([]float64, length)
+	for n := range samples {
+		samples[n] = 0.5 * math.Sin(2*math.Pi*freq*float64(n)/sampleRate)
+	}
+	return samples
+}
+
+func TestSubHarmonic_OctaveBelow(t *testing.T) {
+	sampleRate := 44100.0
+
+	for _, fundamental := range []float64{80.0, 110.0, 196.0} {
+		input := sineSignal(fundamental, sampleRate, 44100)
+
+		output, err := SubHarmonic(input, fundamental, sampleRate, 1)
+		require.NoError(t, err)
+		require.Len(t, output, len(input))
+
+		sub := make([]float64, len(input))
+		for n := range sub {
+			sub[n] = output[n] - input[n]
+		}
+
+		peak, err := analysis.PeakFrequency(sub, sampleRate)
+		require.NoError(t, err)
+		require.InDelta(t, fundamental/2, peak, 1.0, "fundamental %v", fundamental)
+
+		// The sub settles at a level comparable to the input.
+		var power float64
+		for _, v := range sub[len(sub)/2:] {
+			power += v * v
+		}
+		rms := math.Sqrt(power / float64(len(sub)/2))
+		require.InDelta(t, 0.5/math.Sqrt2, rms, 0.15, "fundamental %v", fundamental)
+	}
+}
+
+func TestSubHarmonic_ZeroMix(t *testing.T) {
+	input := sineSignal(100, 44100, 4410)
+
+	output, err := SubHarmonic(input, 100, 44100, 0)
+	require.NoError(t, err)
+	require.Equal(t, input, output)
+
+	output[0] = 42
+	require.NotEqual(t, 42.0, input[0], "output must not alias the input")
+}
+
+func TestSubHarmonic_Errors(t *testing.T) {
+	input := sineSignal(100, 44100, 256)
+
+	_, err := SubHarmonic(input, 100, 0, 0.5)
+	require.ErrorIs(t, err, ErrInvalidSampleRate)
+
+	_, err = SubHarmonic(input, 0, 44100, 0.5)
+	require.ErrorIs(t, err, ErrInvalidFundamental)
+
+	_, err = SubHarmonic(input, 20000, 44100, 0.5)
+	require.ErrorIs(t, err, ErrInvalidFundamental)
+
+	_, err = SubHarmonic(input, 100, 44100, 1.5)
+	require.ErrorIs(t, err, ErrInvalidMix)
+}
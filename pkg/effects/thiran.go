@@ -0,0 +1,129 @@
+package effects
+
+import (
+	"errors"
+	"math"
+)
+
+var (
+	// ErrDelayOutOfRange is returned when a delay does not fit in a delay
+	// line or buffer.
+	ErrDelayOutOfRange = errors.New("delay out of range")
+	// ErrInvalidOrder is returned when the order of an interpolator is not
+	// positive.
+	ErrInvalidOrder = errors.New("order must be positive")
+)
+
+// ThiranDelayLine delays a signal by a fractional number of samples. The
+// delay is split between an integer tap into a circular buffer and a
+// Thiran all-pass filter of order Order, whose group delay is maximally
+// flat at DC. Unlike linear interpolation it keeps a flat magnitude
+// response at every frequency.
+type ThiranDelayLine struct {
+	MaxDelay int // Longest delay in samples
+	Order    int // Order of the all-pass interpolator, typically 2 or 3
+
+	buffer []float64
+	write  int
+	tap    int
+
+	coefficients []float64 // a[0..Order], a[0] = 1
+	inputs       []float64 // All-pass input history, most recent first
+	outputs      []float64 // All-pass output history, most recent first
+}
+
+// NewThiranDelayLine creates a delay line able to delay by up to maxDelay
+// samples, initially set to a delay of order samples. It returns
+// ErrInvalidOrder when order is not positive and ErrDelayOutOfRange when
+// maxDelay is shorter than that initial delay.
+func NewThiranDelayLine(maxDelay, order int) (*ThiranDelayLine, error) {
+	if order < 1 {
+		return nil, ErrInvalidOrder
+	}
+	if maxDelay < order {
+		return nil, ErrDelayOutOfRange
+	}
+
+	d := &ThiranDelayLine{
+		MaxDelay: maxDelay,
+		Order:    order,
+		buffer:   make([]float64, maxDelay+1),
+		inputs:   make([]float64, order),
+		outputs:  make([]float64, order),
+	}
+	d.coefficients = thiranCoefficients(float64(order), order)
+
+	return d, nil
+}
+
+// SetDelay sets the total delay in samples. The all-pass filter takes a
+// delay D in [Order-0.5, Order+0.5), where it is stable, and the integer
+// tap provides the rest, so fractionalSamples must lie between Order-0.5
+// and MaxDelay. The coefficients of a Thiran all-pass of order N are
+//
+//	a[k] = (-1)^k * C(N, k) * Π_{n=0..N} (D - N + n) / (D - N + k + n)
+//
+// The filter state is kept, so changing the delay while processing does
+// not reset the line.
+func (d *ThiranDelayLine) SetDelay(fractionalSamples float64) error {
+	if fractionalSamples < float64(d.Order)-0.5 || fractionalSamples > float64(d.MaxDelay) {
+		return ErrDelayOutOfRange
+	}
+
+	tap := int(math.Floor(fractionalSamples+0.5)) - d.Order
+	tap = min(tap, d.MaxDelay)
+
+	d.tap = tap
+	d.coefficients = thiranCoefficients(fractionalSamples-float64(tap), d.Order)
+
+	return nil
+}
+
+// Process pushes sample into the line and returns the delayed output.
+func (d *ThiranDelayLine) Process(sample float64) float64 {
+	d.buffer[d.write] = sample
+	read := (d.write - d.tap + len(d.buffer)) % len(d.buffer)
+	x := d.buffer[read]
+	d.write = (d.write + 1) % len(d.buffer)
+
+	// H(z) = (a[N] + a[N-1] z^-1 + ... + a[0] z^-N) / (a[0] + a[1] z^-1 + ... + a[N] z^-N)
+	n := d.Order
+	y := d.coefficients[n] * x
+	for k := 1; k <= n; k++ {
+		y += d.coefficients[n-k]*d.inputs[k-1] - d.coefficients[k]*d.outputs[k-1]
+	}
+
+	if n > 0 {
+		copy(d.inputs[1:], d.inputs[:n-1])
+		copy(d.outputs[1:], d.outputs[:n-1])
+		d.inputs[0] = x
+		d.outputs[0] = y
+	}
+
+	return y
+}
+
+// thiranCoefficients returns the denominator coefficients a[0..order] of
+// the Thiran all-pass with delay delay.
+func thiranCoefficients(delay float64, order int) []float64 {
+	a := make([]float64, order+1)
+	a[0] = 1
+	binomial := float64(order)
+
+	for k := 1; k <= order; k++ {
+		product := 1.0
+		for n := 0; n <= order; n++ {
+			product *= (delay - float64(order) + float64(n)) / (delay - float64(order) + float64(k) + float64(n))
+		}
+
+		sign := 1.0
+		if k%2 == 1 {
+			sign = -1.0
+		}
+		a[k] = sign * binomial * product
+
+		binomial = binomial * float64(order-k) / float64(k+1)
+	}
+
+	return a
+}
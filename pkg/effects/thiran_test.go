@@ -0,0 +1,117 @@
+package effects
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// newThiranDelayLine creates a delay line and fails the test on error.
+func newThiranDelayLine(t *testing.T, maxDelay, order int) *ThiranDelayLine {
+	t.Helper()
+
+	line, err := NewThiranDelayLine(maxDelay, order)
+	require.NoError(t, err)
+	return line
+}
+
+func TestThiranDelayLine_IntegerDelay(t *testing.T) {
+	for _, order := range []int{1, 2, 3} {
+		for _, delay := range []int{order, 7, 32} {
+			line := newThiranDelayLine(t, 32, order)
+			require.NoError(t, line.SetDelay(float64(delay)))
+
+			input := make([]float64, 100)
+			for n := range input {
+				input[n] = math.Sin(float64(n)*0.37) + 0.1*float64(n%5)
+			}
+
+			for n, x := range input {
+				want := 0.0
+				if n >= delay {
+					want = input[n-delay]
+				}
+				require.InDelta(t, want, line.Process(x), 1e-12, "order %d, delay %d, sample %d", order, delay, n)
+			}
+		}
+	}
+}
+
+// phaseDelay returns the delay in seconds of a sine at freq through the
+// line, measured by correlating the steady-state output with a quadrature
+// pair.
+func phaseDelay(line *ThiranDelayLine, freq, sampleRate float64) float64 {
+	omega := 2 * math.Pi * freq / sampleRate
+	var re, im float64
+	for n := range 44100 {
+		y := line.Process(math.Sin(omega * float64(n)))
+		if n >= 4410 {
+			re += y * math.Sin(omega*float64(n))
+			im += y * math.Cos(omega*float64(n))
+		}
+	}
+
+	// y = sin(ω(n - τ)) correlates as cos(ωτ) with sin and -sin(ωτ) with cos.
+	return math.Atan2(-im, re) / omega / sampleRate
+}
+
+func TestThiranDelayLine_FractionalPhaseDelay(t *testing.T) {
+	sampleRate := 44100.0
+
+	for _, order := range []int{1, 2, 3} {
+		line := newThiranDelayLine(t, 64, order)
+		require.NoError(t, line.SetDelay(10))
+		whole := phaseDelay(line, 1000, sampleRate)
+
+		line = newThiranDelayLine(t, 64, order)
+		require.NoError(t, line.SetDelay(10.5))
+		half := phaseDelay(line, 1000, sampleRate)
+
+		require.InDelta(t, 10/sampleRate, whole, 1e-9)
+		require.InDelta(t, 0.5/sampleRate, half-whole, 1e-3/sampleRate, "order %d", order)
+	}
+}
+
+func TestThiranDelayLine_AllPass(t *testing.T) {
+	line := newThiranDelayLine(t, 16, 3)
+	require.NoError(t, line.SetDelay(5.3))
+
+	// A Thiran filter only shifts the phase: the energy of a long impulse
+	// response equals the energy of the impulse.
+	var energy float64
+	for n := range 4096 {
+		x := 0.0
+		if n == 0 {
+			x = 1
+		}
+		y := line.Process(x)
+		energy += y * y
+	}
+	require.InDelta(t, 1.0, energy, 1e-9)
+}
+
+func TestThiranDelayLine_SetDelayRange(t *testing.T) {
+	line := newThiranDelayLine(t, 8, 2)
+
+	require.ErrorIs(t, line.SetDelay(1.4), ErrDelayOutOfRange)
+	require.ErrorIs(t, line.SetDelay(8.1), ErrDelayOutOfRange)
+	require.NoError(t, line.SetDelay(1.5))
+	require.NoError(t, line.SetDelay(8))
+}
+
+func TestNewThiranDelayLine_Errors(t *testing.T) {
+	for _, order := range []int{0, -1} {
+		_, err := NewThiranDelayLine(8, order)
+		require.ErrorIs(t, err, ErrInvalidOrder, "order %d", order)
+	}
+
+	_, err := NewThiranDelayLine(2, 3)
+	require.ErrorIs(t, err, ErrDelayOutOfRange)
+
+	_, err = NewThiranDelayLine(-1, 1)
+	require.ErrorIs(t, err, ErrDelayOutOfRange)
+
+	_, err = NewThiranDelayLine(3, 3)
+	require.NoError(t, err)
+}
@@ -0,0 +1,68 @@
+package effects
+
+import (
+	"math"
+)
+
+// WowFlutter simulates the pitch instability of analog tape: a slow drift
+// (wow) and a faster wobble (flutter), each driven by its own sine LFO.
+type WowFlutter struct {
+	WowRate      float64 // Wow LFO frequency in Hz, typically below 4 Hz
+	WowDepth     float64 // Peak wow pitch deviation in cents
+	FlutterRate  float64 // Flutter LFO frequency in Hz, typically 4 to 100 Hz
+	FlutterDepth float64 // Peak flutter pitch deviation in cents
+}
+
+// Apply reads samples through a variable-speed read pointer trailing the
+// write position by a modulated delay, interpolating linearly between
+// neighbouring samples. An LFO of depth c cents and rate f moves the delay
+// by up to
+//
+//	D = (2^(c/1200) - 1) * sampleRate / (2π*f)
+//
+// samples, so that the slope of the delay, hence the pitch deviation,
+// peaks at c cents. The read pointer is held back by the sum of both
+// depths so that it never runs ahead of the input.
+func (wf WowFlutter) Apply(samples []float64, sampleRate float64) []float64 {
+	wow := modulationDepth(wf.WowDepth, wf.WowRate, sampleRate)
+	flutter := modulationDepth(wf.FlutterDepth, wf.FlutterRate, sampleRate)
+	base := wow + flutter
+
+	result := make([]float64, len(samples))
+
+	for n := range samples {
+		t := float64(n) / sampleRate
+		delay := base +
+			wow*math.Sin(2*math.Pi*wf.WowRate*t) +
+			flutter*math.Sin(2*math.Pi*wf.FlutterRate*t)
+
+		result[n] = readInterpolated(samples, float64(n)-delay)
+	}
+
+	return result
+}
+
+// modulationDepth converts a pitch deviation in cents at the given LFO rate
+// into the amplitude, in samples, of the delay modulation.
+func modulationDepth(cents, rate, sampleRate float64) float64 {
+	if cents == 0 || rate <= 0 {
+		return 0
+	}
+	return (math.Pow(2, math.Abs(cents)/1200) - 1) * sampleRate / (2 * math.Pi * rate)
+}
+
+// readInterpolated returns samples at a fractional position using linear
+// interpolation. Positions outside the slice read as silence.
+func readInterpolated(samples []float64, position float64) float64 {
+	i := int(math.Floor(position))
+	frac := position - float64(i)
+
+	at := func(k int) float64 {
+		if k < 0 || k >= len(samples) {
+			return 0
+		}
+		return samples[k]
+	}
+
+	return at(i) + frac*(at(i+1)-at(i))
+}
@@ -0,0 +1,68 @@
+package effects
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/ECecillo/lib.go.sound/pkg/sine"
+	"github.com/stretchr/testify/require"
+)
+
+// periodFrequencies returns the frequency of every period of samples,
+// measured between interpolated rising zero crossings.
+func periodFrequencies(samples []float64, sampleRate float64) []float64 {
+	var crossings []float64
+	for n := 1; n < len(samples); n++ {
+		if samples[n-1] < 0 && samples[n] >= 0 {
+			crossings = append(crossings, float64(n-1)+samples[n-1]/(samples[n-1]-samples[n]))
+		}
+	}
+
+	frequencies := make([]float64, 0, len(crossings))
+	for i := 1; i < len(crossings); i++ {
+		frequencies = append(frequencies, sampleRate/(crossings[i]-crossings[i-1]))
+	}
+	return frequencies
+}
+
+func TestWowFlutter_ZeroDepthCopies(t *testing.T) {
+	input, err := sine.NewSine(1000.0, 100*time.Millisecond).Generate()
+	require.NoError(t, err)
+
+	wf := WowFlutter{WowRate: 0.5, FlutterRate: 10}
+	output := wf.Apply(input, 44100.0)
+
+	require.Equal(t, input, output)
+	output[0] = 42
+	require.NotEqual(t, input[0], output[0], "Apply must return a copy")
+}
+
+func TestWowFlutter_PitchVariation(t *testing.T) {
+	sampleRate := 44100.0
+	input, err := sine.NewSine(1000.0, 2*time.Second).Generate()
+	require.NoError(t, err)
+
+	wf := WowFlutter{WowRate: 1.0, WowDepth: 50.0, FlutterRate: 8.0, FlutterDepth: 5.0}
+	output := wf.Apply(input, sampleRate)
+	require.Len(t, output, len(input))
+
+	// Skip the start, where the read pointer is still before the input.
+	frequencies := periodFrequencies(output[4410:], sampleRate)
+	lowest, highest := frequencies[0], frequencies[0]
+	for _, f := range frequencies {
+		lowest = math.Min(lowest, f)
+		highest = math.Max(highest, f)
+	}
+
+	// 55 cents around 1 kHz is about ±32 Hz.
+	require.Greater(t, highest-lowest, 40.0)
+	require.InDelta(t, 1000.0*math.Pow(2, 55.0/1200), highest, 5.0)
+	require.InDelta(t, 1000.0*math.Pow(2, -55.0/1200), lowest, 5.0)
+
+	// The unmodulated sine holds its pitch.
+	steady := periodFrequencies(input, sampleRate)
+	for _, f := range steady {
+		require.InDelta(t, 1000.0, f, 0.01)
+	}
+}
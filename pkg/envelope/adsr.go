@@ -0,0 +1,62 @@
+package envelope
+
+import (
+	"time"
+)
+
+// ADSR is a classic attack-decay-sustain-release envelope with linear
+// stages. The level rises from 0 to 1 during Attack, falls to Sustain during
+// Decay and stays there while the note is held; once the note is released
+// it falls back to 0 during Release.
+type ADSR struct {
+	Attack  time.Duration
+	Decay   time.Duration
+	Sustain float64 // Level held after the decay, in [0, 1]
+	Release time.Duration
+}
+
+// NewADSR creates an ADSR envelope.
+func NewADSR(attack, decay time.Duration, sustain float64, release time.Duration) *ADSR {
+	return &ADSR{
+		Attack:  attack,
+		Decay:   decay,
+		Sustain: sustain,
+		Release: release,
+	}
+}
+
+// Level returns the envelope level elapsed after the start of a note held
+// for noteLength. The release stage starts from whatever level was reached
+// when the note was released.
+func (a *ADSR) Level(elapsed, noteLength time.Duration) float64 {
+	if elapsed < 0 {
+		return 0
+	}
+
+	if elapsed < noteLength {
+		return a.gateLevel(elapsed)
+	}
+
+	released := elapsed - noteLength
+	if released >= a.Release {
+		return 0
+	}
+
+	start := a.gateLevel(noteLength)
+	return start * (1 - released.Seconds()/a.Release.Seconds())
+}
+
+// gateLevel returns the level elapsed after the start of a note that is
+// still held.
+func (a *ADSR) gateLevel(elapsed time.Duration) float64 {
+	if elapsed < a.Attack {
+		return elapsed.Seconds() / a.Attack.Seconds()
+	}
+
+	elapsed -= a.Attack
+	if elapsed < a.Decay {
+		return 1 - (1-a.Sustain)*elapsed.Seconds()/a.Decay.Seconds()
+	}
+
+	return a.Sustain
+}
@@ -0,0 +1,49 @@
+package envelope
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestADSR_Level(t *testing.T) {
+	adsr := NewADSR(10*time.Millisecond, 20*time.Millisecond, 0.5, 100*time.Millisecond)
+	noteLength := 200 * time.Millisecond
+
+	tests := []struct {
+		elapsed time.Duration
+		want    float64
+	}{
+		{elapsed: -time.Millisecond, want: 0},
+		{elapsed: 0, want: 0},
+		{elapsed: 5 * time.Millisecond, want: 0.5},
+		{elapsed: 10 * time.Millisecond, want: 1},
+		{elapsed: 20 * time.Millisecond, want: 0.75},
+		{elapsed: 30 * time.Millisecond, want: 0.5},
+		{elapsed: 150 * time.Millisecond, want: 0.5},
+		{elapsed: 250 * time.Millisecond, want: 0.25},
+		{elapsed: 300 * time.Millisecond, want: 0},
+		{elapsed: time.Second, want: 0},
+	}
+
+	for _, tt := range tests {
+		require.InDelta(t, tt.want, adsr.Level(tt.elapsed, noteLength), 1e-12, "at %v", tt.elapsed)
+	}
+}
+
+func TestADSR_ReleaseDuringAttack(t *testing.T) {
+	adsr := NewADSR(100*time.Millisecond, 0, 1, 100*time.Millisecond)
+
+	// Released halfway through the attack, the release starts from 0.5.
+	require.InDelta(t, 0.5, adsr.Level(50*time.Millisecond, 50*time.Millisecond), 1e-12)
+	require.InDelta(t, 0.25, adsr.Level(100*time.Millisecond, 50*time.Millisecond), 1e-12)
+}
+
+func TestADSR_ZeroStages(t *testing.T) {
+	adsr := NewADSR(0, 0, 0.8, 0)
+
+	require.Equal(t, 0.8, adsr.Level(0, time.Second))
+	require.Equal(t, 0.8, adsr.Level(500*time.Millisecond, time.Second))
+	require.Zero(t, adsr.Level(time.Second, time.Second))
+}
@@ -0,0 +1,96 @@
+package envelope
+
+import (
+	"math"
+	"time"
+)
+
+// CurveType selects how a Segment moves from its start level to its target.
+type CurveType int
+
+const (
+	// Linear moves at a constant rate.
+	Linear CurveType = iota
+	// Exponential changes by a constant ratio per unit of time, fast at first
+	// for a decay and slow at first for a rise, like an analog RC envelope.
+	Exponential
+	// Logarithmic is the mirror image of Exponential: a decay starts slowly
+	// and accelerates towards its target.
+	Logarithmic
+)
+
+// minLevel is the level used in place of zero by the Exponential and
+// Logarithmic curves, which cannot reach zero (-100 dB).
+const minLevel = 1e-5
+
+// Segment is one stage of an Envelope.
+type Segment struct {
+	Duration    time.Duration // Time taken to reach TargetLevel
+	TargetLevel float64       // Level at the end of the segment
+	Curve       CurveType     // Shape of the transition
+}
+
+// Envelope is a piecewise gain curve starting at StartLevel and moving
+// through each of its Segments in turn. The level of the last segment is
+// held once all segments are done.
+type Envelope struct {
+	StartLevel float64
+	Segments   []Segment
+}
+
+// NewEnvelope creates an Envelope starting at startLevel.
+func NewEnvelope(startLevel float64, segments ...Segment) *Envelope {
+	return &Envelope{
+		StartLevel: startLevel,
+		Segments:   segments,
+	}
+}
+
+// Apply multiplies samples by the envelope and returns the result. The
+// envelope is evaluated at the end of each sample period, so the last
+// sample of a segment is exactly at its TargetLevel.
+func (e *Envelope) Apply(samples []float64, sampleRate float64) []float64 {
+	result := make([]float64, len(samples))
+
+	level := e.StartLevel
+	n := 0
+
+	for _, segment := range e.Segments {
+		length := int(math.Round(segment.Duration.Seconds() * sampleRate))
+
+		for i := 0; i < length && n < len(samples); i++ {
+			x := float64(i+1) / float64(length)
+			result[n] = samples[n] * segment.valueAt(level, x)
+			n++
+		}
+
+		level = segment.TargetLevel
+	}
+
+	for ; n < len(samples); n++ {
+		result[n] = samples[n] * level
+	}
+
+	return result
+}
+
+// valueAt returns the level of the segment at the normalized position x in
+// [0, 1], starting from the level from.
+//
+//	Linear:      from + (to - from)*x
+//	Exponential: from * (to/from)^x
+//	Logarithmic: from + to - from * (to/from)^(1-x)
+func (s Segment) valueAt(from, x float64) float64 {
+	to := s.TargetLevel
+
+	switch s.Curve {
+	case Exponential:
+		from, to = math.Max(from, minLevel), math.Max(to, minLevel)
+		return from * math.Pow(to/from, x)
+	case Logarithmic:
+		from, to = math.Max(from, minLevel), math.Max(to, minLevel)
+		return from + to - from*math.Pow(to/from, 1-x)
+	default:
+		return from + (to-from)*x
+	}
+}
@@ -0,0 +1,94 @@
+package envelope
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func ones(n int) []float64 {
+	samples := make([]float64, n)
+	for i := range samples {
+		samples[i] = 1
+	}
+	return samples
+}
+
+func TestEnvelope_ExponentialDecay(t *testing.T) {
+	sampleRate := 1000.0
+	env := NewEnvelope(1.0, Segment{Duration: time.Second, TargetLevel: 0.01, Curve: Exponential})
+
+	result := env.Apply(ones(1000), sampleRate)
+
+	for n, v := range result {
+		tt := float64(n+1) / sampleRate
+		require.InDelta(t, math.Pow(0.01, tt), v, 1e-12)
+	}
+	require.InDelta(t, 0.01, result[len(result)-1], 1e-6)
+}
+
+func TestEnvelope_Curves(t *testing.T) {
+	sampleRate := 100.0
+
+	tests := []struct {
+		name  string
+		curve CurveType
+		// halfway is the expected level midway through a 1 → 0.01 decay.
+		halfway float64
+	}{
+		{name: "linear", curve: Linear, halfway: 0.505},
+		{name: "exponential", curve: Exponential, halfway: 0.1},
+		{name: "logarithmic", curve: Logarithmic, halfway: 0.91},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := NewEnvelope(1.0, Segment{Duration: time.Second, TargetLevel: 0.01, Curve: tt.curve})
+			result := env.Apply(ones(100), sampleRate)
+
+			require.InDelta(t, tt.halfway, result[49], 1e-9)
+			require.InDelta(t, 0.01, result[99], 1e-9)
+
+			for n := 1; n < len(result); n++ {
+				require.Less(t, result[n], result[n-1], "decay must be monotonic")
+			}
+		})
+	}
+}
+
+func TestEnvelope_Piecewise(t *testing.T) {
+	sampleRate := 1000.0
+	env := NewEnvelope(0,
+		Segment{Duration: 10 * time.Millisecond, TargetLevel: 1, Curve: Linear},
+		Segment{Duration: 100 * time.Millisecond, TargetLevel: 0.5, Curve: Exponential},
+		Segment{Duration: 50 * time.Millisecond, TargetLevel: 0, Curve: Linear},
+	)
+
+	result := env.Apply(ones(200), sampleRate)
+
+	require.InDelta(t, 0.1, result[0], 1e-12)
+	require.InDelta(t, 1.0, result[9], 1e-12)
+	require.InDelta(t, 0.5, result[109], 1e-12)
+	require.InDelta(t, 0.25, result[134], 1e-12)
+	require.InDelta(t, 0.0, result[159], 1e-12)
+
+	// The last level is held after the envelope ends.
+	for _, v := range result[160:] {
+		require.Zero(t, v)
+	}
+}
+
+func TestEnvelope_ScalesInput(t *testing.T) {
+	env := NewEnvelope(0.5)
+	require.Equal(t, []float64{0.5, -1, 0.25}, env.Apply([]float64{1, -2, 0.5}, 44100))
+}
+
+func TestEnvelope_ExponentialFromZero(t *testing.T) {
+	env := NewEnvelope(0, Segment{Duration: time.Second, TargetLevel: 1, Curve: Exponential})
+	result := env.Apply(ones(100), 100)
+
+	require.Greater(t, result[0], 0.0)
+	require.InDelta(t, 1.0, result[99], 1e-12)
+}
@@ -0,0 +1,60 @@
+package filter
+
+import (
+	"math"
+)
+
+// AllPass is a Schroeder all-pass filter. It lets every frequency through
+// at unity gain and only alters the phase, which makes it useful as a phase
+// equalizer or as a diffusion stage in a reverb.
+type AllPass struct {
+	Delay int     // Delay in samples (D)
+	Gain  float64 // Feedback gain (g), should stay within (-1, 1) for stability
+}
+
+// NewAllPass returns an all-pass filter with the given delay and gain.
+func NewAllPass(delay int, gain float64) *AllPass {
+	return &AllPass{
+		Delay: delay,
+		Gain:  gain,
+	}
+}
+
+// Process filters the given samples and returns a new slice holding the
+// output. The filter state starts at zero on every call:
+//
+//	y[n] = -g*x[n] + x[n-D] + g*y[n-D]
+func (a AllPass) Process(samples []float64) []float64 {
+	result := make([]float64, len(samples))
+
+	// Without delay the filter degenerates to a pass-through.
+	if a.Delay <= 0 {
+		copy(result, samples)
+		return result
+	}
+
+	for n, x := range samples {
+		y := -a.Gain * x
+		if n >= a.Delay {
+			y += samples[n-a.Delay] + a.Gain*result[n-a.Delay]
+		}
+		result[n] = y
+	}
+
+	return result
+}
+
+// PhaseResponse returns the phase shift in radians introduced at freq for
+// the given sample rate. The returned value is unwrapped so it varies
+// continuously with the frequency.
+func (a AllPass) PhaseResponse(freq, sampleRate float64) float64 {
+	if a.Delay <= 0 {
+		return 0.0
+	}
+
+	theta := 2 * math.Pi * freq / sampleRate * float64(a.Delay)
+
+	// H(e^jw) = e^{-jθ} * conj(A) / A with A = 1 - g*e^{-jθ}, hence the
+	// phase is -θ - 2*arg(A).
+	return -theta - 2*math.Atan2(a.Gain*math.Sin(theta), 1-a.Gain*math.Cos(theta))
+}
@@ -0,0 +1,103 @@
+package filter
+
+import (
+	"math"
+	"math/cmplx"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/ECecillo/lib.go.sound/pkg/analysis"
+	"github.com/stretchr/testify/require"
+)
+
+// fftOf returns the FFT of samples, whose length must be a power of
+// two.
+func fftOf(t *testing.T, samples []float64) []complex128 {
+	t.Helper()
+
+	result, err := analysis.FFT(samples)
+	require.NoError(t, err)
+	return result
+}
+
+func TestAllPass_FlatMagnitudeResponse(t *testing.T) {
+	tests := []struct {
+		name  string
+		delay int
+		gain  float64
+	}{
+		{name: "short_delay", delay: 1, gain: 0.5},
+		{name: "schroeder_diffuser", delay: 113, gain: 0.7},
+		{name: "negative_gain", delay: 37, gain: -0.6},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ap := AllPass{Delay: tt.delay, Gain: tt.gain}
+
+			// White noise followed by enough silence for the filter tail to
+			// decay, so the circular spectrum equals the linear one.
+			rng := rand.New(rand.NewPCG(1, 2))
+			input := make([]float64, 1<<14)
+			for i := range 1024 {
+				input[i] = rng.Float64()*2 - 1
+			}
+
+			output := ap.Process(input)
+
+			in := fftOf(t, input)
+			out := fftOf(t, output)
+
+			for k := range len(in) / 2 {
+				if cmplx.Abs(in[k]) < 1e-3 {
+					continue
+				}
+				gain := cmplx.Abs(out[k]) / cmplx.Abs(in[k])
+				require.InDelta(t, 1.0, gain, 1e-6, "magnitude is not flat at bin %d", k)
+			}
+		})
+	}
+}
+
+func TestAllPass_PhaseResponseIsContinuous(t *testing.T) {
+	ap := AllPass{Delay: 5, Gain: 0.7}
+	sampleRate := 44100.0
+
+	require.Equal(t, 0.0, ap.PhaseResponse(0, sampleRate), "no phase shift expected at DC")
+
+	previous := ap.PhaseResponse(0, sampleRate)
+	for freq := 1.0; freq <= sampleRate/2; freq++ {
+		phase := ap.PhaseResponse(freq, sampleRate)
+		require.Less(t, math.Abs(phase-previous), 0.1, "phase jumps at %f Hz", freq)
+		require.LessOrEqual(t, phase, previous, "phase should decrease monotonically at %f Hz", freq)
+		previous = phase
+	}
+
+	// An all-pass of delay D accumulates -D*pi radians at Nyquist.
+	require.InDelta(t, -5*math.Pi, ap.PhaseResponse(sampleRate/2, sampleRate), 1e-9)
+}
+
+func TestAllPass_PhaseResponseMatchesTransferFunction(t *testing.T) {
+	ap := AllPass{Delay: 3, Gain: 0.4}
+	sampleRate := 48000.0
+
+	for _, freq := range []float64{100, 1000, 5000, 12000, 20000} {
+		w := 2 * math.Pi * freq / sampleRate
+		z := cmplx.Exp(complex(0, -w*float64(ap.Delay)))
+		h := (complex(-ap.Gain, 0) + z) / (1 - complex(ap.Gain, 0)*z)
+
+		got := ap.PhaseResponse(freq, sampleRate)
+		// Compare the wrapped angles.
+		diff := math.Remainder(got-cmplx.Phase(h), 2*math.Pi)
+		require.InDelta(t, 0.0, diff, 1e-9, "phase mismatch at %f Hz", freq)
+		require.InDelta(t, 1.0, cmplx.Abs(h), 1e-12)
+	}
+}
+
+func TestAllPass_ZeroDelayPassesThrough(t *testing.T) {
+	ap := AllPass{Delay: 0, Gain: 0.5}
+	input := []float64{1, -0.5, 0.25, 0}
+
+	require.Equal(t, input, ap.Process(input))
+	require.Equal(t, 0.0, ap.PhaseResponse(1000, 44100))
+}
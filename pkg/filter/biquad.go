@@ -0,0 +1,79 @@
+package filter
+
+import (
+	"math"
+	"math/cmplx"
+)
+
+// Biquad is a second-order IIR section in direct form I. The coefficients
+// are normalized so that a0 equals 1:
+//
+//	y[n] = b0*x[n] + b1*x[n-1] + b2*x[n-2] - a1*y[n-1] - a2*y[n-2]
+type Biquad struct {
+	B0, B1, B2 float64 // Feed-forward coefficients
+	A1, A2     float64 // Feedback coefficients
+}
+
+// NewBiquad returns a biquad with the given normalized coefficients.
+func NewBiquad(b0, b1, b2, a1, a2 float64) *Biquad {
+	return &Biquad{
+		B0: b0,
+		B1: b1,
+		B2: b2,
+		A1: a1,
+		A2: a2,
+	}
+}
+
+// Process filters the given samples and returns a new slice holding the
+// output. The filter state starts at zero on every call.
+func (b Biquad) Process(samples []float64) []float64 {
+	result := make([]float64, len(samples))
+
+	var x1, x2, y1, y2 float64
+	for n, x := range samples {
+		y := b.B0*x + b.B1*x1 + b.B2*x2 - b.A1*y1 - b.A2*y2
+		result[n] = y
+
+		x2, x1 = x1, x
+		y2, y1 = y1, y
+	}
+
+	return result
+}
+
+// MagnitudeResponse returns the linear gain of the filter at freq for the
+// given sample rate.
+func (b Biquad) MagnitudeResponse(freq, sampleRate float64) float64 {
+	w := 2 * math.Pi * freq / sampleRate
+	z1 := cmplx.Exp(complex(0, -w))
+	z2 := z1 * z1
+
+	num := complex(b.B0, 0) + complex(b.B1, 0)*z1 + complex(b.B2, 0)*z2
+	den := 1 + complex(b.A1, 0)*z1 + complex(b.A2, 0)*z2
+
+	return cmplx.Abs(num / den)
+}
+
+// PhaseResponse returns the phase shift in radians, in (-π, π], introduced
+// at freq for the given sample rate.
+func (b Biquad) PhaseResponse(freq, sampleRate float64) float64 {
+	w := 2 * math.Pi * freq / sampleRate
+	z1 := cmplx.Exp(complex(0, -w))
+	z2 := z1 * z1
+
+	num := complex(b.B0, 0) + complex(b.B1, 0)*z1 + complex(b.B2, 0)*z2
+	den := 1 + complex(b.A1, 0)*z1 + complex(b.A2, 0)*z2
+
+	return cmplx.Phase(num / den)
+}
+
+// ProcessCascade runs samples through each section in turn, as needed by
+// higher-order designs built from several biquads.
+func ProcessCascade(sections []*Biquad, samples []float64) []float64 {
+	result := append([]float64(nil), samples...)
+	for _, section := range sections {
+		result = section.Process(result)
+	}
+	return result
+}
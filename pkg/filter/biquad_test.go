@@ -0,0 +1,63 @@
+package filter
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBiquad_IdentityPassesThrough(t *testing.T) {
+	b := NewBiquad(1, 0, 0, 0, 0)
+	input := []float64{1, -0.5, 0.25, 0}
+
+	require.Equal(t, input, b.Process(input))
+	require.InDelta(t, 1.0, b.MagnitudeResponse(1000, 44100), 1e-12)
+}
+
+func TestBiquad_ImpulseResponse(t *testing.T) {
+	b := Biquad{B0: 0.5, B1: 0.25, B2: 0.125, A1: -0.5, A2: 0.25}
+
+	output := b.Process([]float64{1, 0, 0, 0})
+
+	// y0 = b0, y1 = b1 - a1*y0, y2 = b2 - a1*y1 - a2*y0, y3 = -a1*y2 - a2*y1
+	expected := []float64{0.5, 0.5, 0.25, 0}
+	require.InDeltaSlice(t, expected, output, 1e-12)
+}
+
+func TestBiquad_MagnitudeResponseMatchesProcess(t *testing.T) {
+	b := Biquad{B0: 0.2, B1: 0.4, B2: 0.2, A1: -0.6, A2: 0.2}
+	sampleRate := 48000.0
+	freq := 1500.0
+
+	// Drive the filter with a long sine and measure the steady-state
+	// amplitude over a whole number of periods.
+	input := make([]float64, 48000)
+	for n := range input {
+		input[n] = math.Sin(2 * math.Pi * freq * float64(n) / sampleRate)
+	}
+	output := b.Process(input)
+
+	steady := output[len(output)/2:]
+	power := 0.0
+	for _, v := range steady {
+		power += v * v
+	}
+	amplitude := math.Sqrt(2 * power / float64(len(steady)))
+
+	require.InDelta(t, b.MagnitudeResponse(freq, sampleRate), amplitude, 1e-6)
+}
+
+func TestBiquad_PhaseResponse(t *testing.T) {
+	sampleRate := 48000.0
+
+	// A one-sample delay shifts a frequency f by -2πf/sampleRate.
+	delay := NewBiquad(0, 1, 0, 0, 0)
+	require.InDelta(t, -2*math.Pi*1000/sampleRate, delay.PhaseResponse(1000, sampleRate), 1e-12)
+
+	identity := NewBiquad(1, 0, 0, 0, 0)
+	require.Zero(t, identity.PhaseResponse(5000, sampleRate))
+
+	inverter := NewBiquad(-1, 0, 0, 0, 0)
+	require.InDelta(t, math.Pi, inverter.PhaseResponse(5000, sampleRate), 1e-12)
+}
@@ -0,0 +1,106 @@
+package filter
+
+import (
+	"errors"
+	"math"
+)
+
+var (
+	// ErrInvalidCutoff is returned when a cutoff frequency is not strictly
+	// between 0 and Nyquist.
+	ErrInvalidCutoff = errors.New("cutoff must be between 0 and Nyquist")
+	// ErrInvalidRipple is returned when a passband ripple is not positive.
+	ErrInvalidRipple = errors.New("ripple must be positive")
+)
+
+// NewChebyshev1LowPass designs a Chebyshev type I low-pass filter of the
+// given order as a cascade of biquads. The gain ripples between -rippleDB
+// and 0 dB up to cutoffHz, then falls faster than a Butterworth filter of
+// the same order. The analog prototype poles are
+//
+//	p[k] = -sinh(μ)*sin(θ[k]) + j*cosh(μ)*cos(θ[k])
+//	θ[k] = (2k-1)π/(2*order),  μ = asinh(1/ε)/order,  ε = sqrt(10^(rippleDB/10) - 1)
+//
+// Each conjugate pair becomes a second-order section, plus a first-order
+// section for odd orders, mapped with the bilinear transform prewarped at
+// cutoffHz.
+func NewChebyshev1LowPass(cutoffHz, rippleDB, sampleRate float64, order int) ([]*Biquad, error) {
+	return chebyshev1(cutoffHz, rippleDB, sampleRate, order, false)
+}
+
+// NewChebyshev1HighPass designs the Chebyshev type I high-pass filter
+// obtained from the same prototype as NewChebyshev1LowPass through the
+// low-pass to high-pass transform s -> 1/s. Its magnitude response is the
+// mirror image of the low-pass one around cutoffHz on the prewarped
+// frequency axis: it ripples above cutoffHz and falls off below.
+func NewChebyshev1HighPass(cutoffHz, rippleDB, sampleRate float64, order int) ([]*Biquad, error) {
+	return chebyshev1(cutoffHz, rippleDB, sampleRate, order, true)
+}
+
+func chebyshev1(cutoffHz, rippleDB, sampleRate float64, order int, highPass bool) ([]*Biquad, error) {
+	if order < 1 {
+		return nil, ErrInvalidOrder
+	}
+	if cutoffHz <= 0 || cutoffHz >= sampleRate/2 {
+		return nil, ErrInvalidCutoff
+	}
+	if rippleDB <= 0 {
+		return nil, ErrInvalidRipple
+	}
+
+	epsilon := math.Sqrt(math.Pow(10, rippleDB/10) - 1)
+	mu := math.Asinh(1/epsilon) / float64(order)
+	k := math.Tan(math.Pi * cutoffHz / sampleRate)
+
+	var sections []*Biquad
+
+	for i := 1; i <= order/2; i++ {
+		theta := float64(2*i-1) * math.Pi / float64(2*order)
+		re := -math.Sinh(mu) * math.Sin(theta)
+		im := math.Cosh(mu) * math.Cos(theta)
+
+		// Prototype section w0² / (s² + b*s + w0²).
+		w2 := re*re + im*im
+		b := -2 * re
+
+		if highPass {
+			// s -> k/s, then the bilinear transform.
+			a0 := k*k + b*k + w2
+			sections = append(sections, NewBiquad(
+				w2/a0, -2*w2/a0, w2/a0,
+				(2*k*k-2*w2)/a0, (k*k-b*k+w2)/a0,
+			))
+		} else {
+			// s -> s/k, then the bilinear transform.
+			a0 := 1 + b*k + w2*k*k
+			g := w2 * k * k / a0
+			sections = append(sections, NewBiquad(
+				g, 2*g, g,
+				(2*w2*k*k-2)/a0, (1-b*k+w2*k*k)/a0,
+			))
+		}
+	}
+
+	if order%2 == 1 {
+		sigma := math.Sinh(mu)
+
+		if highPass {
+			a0 := k + sigma
+			sections = append(sections, NewBiquad(sigma/a0, -sigma/a0, 0, (k-sigma)/a0, 0))
+		} else {
+			a0 := 1 + sigma*k
+			sections = append(sections, NewBiquad(sigma*k/a0, sigma*k/a0, 0, (sigma*k-1)/a0, 0))
+		}
+	}
+
+	// Every section has unity gain at DC (or Nyquist for the high-pass).
+	// Even orders start the passband at the bottom of the ripple.
+	if order%2 == 0 {
+		g := 1 / math.Sqrt(1+epsilon*epsilon)
+		sections[0].B0 *= g
+		sections[0].B1 *= g
+		sections[0].B2 *= g
+	}
+
+	return sections, nil
+}
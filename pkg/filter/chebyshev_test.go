@@ -0,0 +1,112 @@
+package filter
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChebyshev1LowPass_Response(t *testing.T) {
+	sampleRate := 48000.0
+	cutoff := 1000.0
+
+	sections, err := NewChebyshev1LowPass(cutoff, 0.1, sampleRate, 4)
+	require.NoError(t, err)
+	require.Len(t, sections, 2)
+
+	minDB, maxDB := 0.0, math.Inf(-1)
+	for freq := 0.0; freq <= cutoff; freq += 5 {
+		gainDB := 20 * math.Log10(cascadeMagnitude(sections, freq, sampleRate))
+		minDB = math.Min(minDB, gainDB)
+		maxDB = math.Max(maxDB, gainDB)
+	}
+	require.LessOrEqual(t, maxDB, 1e-9)
+	require.Less(t, maxDB-minDB, 0.1+1e-9)
+	require.InDelta(t, -0.1, minDB, 1e-6, "ripple must reach its design depth")
+
+	// The magnitude follows 1/sqrt(1 + ε²*T4(Ω)²) on the prewarped axis,
+	// T4(x) = 8x⁴ - 8x² + 1. This is about -23.6 dB at twice the cutoff.
+	epsilon2 := math.Pow(10, 0.01) - 1
+	for freq := cutoff; freq < sampleRate/2; freq += 50 {
+		omega := math.Tan(math.Pi*freq/sampleRate) / math.Tan(math.Pi*cutoff/sampleRate)
+		t4 := 8*math.Pow(omega, 4) - 8*omega*omega + 1
+		want := 1 / math.Sqrt(1+epsilon2*t4*t4)
+		require.InDelta(t, want, cascadeMagnitude(sections, freq, sampleRate), 1e-9, "at %.0f Hz", freq)
+	}
+}
+
+func TestChebyshev1LowPass_Stopband(t *testing.T) {
+	sampleRate := 48000.0
+	cutoff := 1000.0
+
+	// 40 dB at twice the cutoff takes order 6 with 0.1 dB of ripple.
+	sections, err := NewChebyshev1LowPass(cutoff, 0.1, sampleRate, 6)
+	require.NoError(t, err)
+
+	for freq := 2 * cutoff; freq < sampleRate/2; freq += 50 {
+		gainDB := 20 * math.Log10(cascadeMagnitude(sections, freq, sampleRate))
+		require.Less(t, gainDB, -40.0, "stopband at %.0f Hz", freq)
+	}
+}
+
+func TestChebyshev1LowPass_SteeperThanButterworth(t *testing.T) {
+	sampleRate := 44100.0
+	chebyshev, err := NewChebyshev1LowPass(2000, 0.5, sampleRate, 4)
+	require.NoError(t, err)
+	butterworth := butterworthSections(2000, sampleRate, 4, false)
+
+	require.Less(t, cascadeMagnitude(chebyshev, 3000, sampleRate), cascadeMagnitude(butterworth, 3000, sampleRate))
+}
+
+func TestChebyshev1_OddOrder(t *testing.T) {
+	sampleRate := 44100.0
+
+	lowpass, err := NewChebyshev1LowPass(3000, 1, sampleRate, 5)
+	require.NoError(t, err)
+	require.Len(t, lowpass, 3)
+	require.InDelta(t, 1.0, cascadeMagnitude(lowpass, 0, sampleRate), 1e-9)
+	require.InDelta(t, -1.0, 20*math.Log10(cascadeMagnitude(lowpass, 3000, sampleRate)), 1e-6)
+
+	highpass, err := NewChebyshev1HighPass(3000, 1, sampleRate, 5)
+	require.NoError(t, err)
+	require.InDelta(t, 1.0, cascadeMagnitude(highpass, sampleRate/2, sampleRate), 1e-9)
+	require.InDelta(t, -1.0, 20*math.Log10(cascadeMagnitude(highpass, 3000, sampleRate)), 1e-6)
+}
+
+func TestChebyshev1HighPass_MirrorsLowPass(t *testing.T) {
+	sampleRate := 48000.0
+	cutoff := 1000.0
+
+	for _, order := range []int{2, 3, 4} {
+		lowpass, err := NewChebyshev1LowPass(cutoff, 0.1, sampleRate, order)
+		require.NoError(t, err)
+		highpass, err := NewChebyshev1HighPass(cutoff, 0.1, sampleRate, order)
+		require.NoError(t, err)
+
+		// On the prewarped axis tan(πf/fs), the high-pass at f behaves like
+		// the low-pass at the frequency whose tangent is k²/tan(πf/fs).
+		k := math.Tan(math.Pi * cutoff / sampleRate)
+		for freq := 100.0; freq < sampleRate/2-100; freq += 100 {
+			mirrored := math.Atan(k*k/math.Tan(math.Pi*freq/sampleRate)) * sampleRate / math.Pi
+			require.InDelta(t,
+				cascadeMagnitude(lowpass, mirrored, sampleRate),
+				cascadeMagnitude(highpass, freq, sampleRate),
+				1e-9, "order %d at %.0f Hz", order, freq)
+		}
+	}
+}
+
+func TestChebyshev1_Errors(t *testing.T) {
+	_, err := NewChebyshev1LowPass(1000, 0.1, 44100, 0)
+	require.ErrorIs(t, err, ErrInvalidOrder)
+
+	_, err = NewChebyshev1LowPass(30000, 0.1, 44100, 4)
+	require.ErrorIs(t, err, ErrInvalidCutoff)
+
+	_, err = NewChebyshev1HighPass(0, 0.1, 44100, 4)
+	require.ErrorIs(t, err, ErrInvalidCutoff)
+
+	_, err = NewChebyshev1HighPass(1000, 0, 44100, 4)
+	require.ErrorIs(t, err, ErrInvalidRipple)
+}
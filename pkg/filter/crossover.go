@@ -0,0 +1,101 @@
+package filter
+
+import (
+	"errors"
+	"math"
+)
+
+// ErrInvalidOrder is returned when a filter order is not supported.
+var ErrInvalidOrder = errors.New("invalid filter order")
+
+// NewLinkwitzRiley returns the low-pass and high-pass branches of a
+// Linkwitz-Riley crossover of the given even order (2, 4, 8, ...). Each
+// branch is a Butterworth filter of half the order applied twice, so both
+// sit at -6 dB at crossoverHz and their sum has a flat magnitude. For
+// orders where the branches end up in phase opposition (2, 6, ...) the
+// high-pass polarity is inverted so that the sum stays flat.
+func NewLinkwitzRiley(crossoverHz, sampleRate float64, order int) (lowpass, highpass []*Biquad, err error) {
+	if order < 2 || order%2 != 0 {
+		return nil, nil, ErrInvalidOrder
+	}
+	if crossoverHz <= 0 || crossoverHz >= sampleRate/2 {
+		return nil, nil, ErrInvalidCutoff
+	}
+
+	half := order / 2
+	for range 2 {
+		lowpass = append(lowpass, butterworthSections(crossoverHz, sampleRate, half, false)...)
+		highpass = append(highpass, butterworthSections(crossoverHz, sampleRate, half, true)...)
+	}
+
+	if half%2 == 1 {
+		highpass[0].B0 = -highpass[0].B0
+		highpass[0].B1 = -highpass[0].B1
+		highpass[0].B2 = -highpass[0].B2
+	}
+
+	return lowpass, highpass, nil
+}
+
+// NewButterworthHighPass designs a Butterworth high-pass filter of the
+// given order as a cascade of order/2 biquads, plus a first-order section
+// for odd orders. The analog prototype poles lie on the unit circle at
+//
+//	p[k] = e^(jπ(2k+n-1)/(2n)),  k = 1 … n
+//
+// and are mapped with the bilinear transform prewarped at cutoffHz, where
+// the gain is -3 dB. Below the cutoff the response falls by 20*order dB per
+// decade.
+func NewButterworthHighPass(cutoffHz, sampleRate float64, order int) ([]*Biquad, error) {
+	if order < 1 {
+		return nil, ErrInvalidOrder
+	}
+	if cutoffHz <= 0 || cutoffHz >= sampleRate/2 {
+		return nil, ErrInvalidCutoff
+	}
+
+	return butterworthSections(cutoffHz, sampleRate, order, true), nil
+}
+
+// butterworthSections returns the biquads of a Butterworth filter of the
+// given order: one second-order section per conjugate pole pair, with
+// Q = 1/(2*sin((2k-1)π/(2*order))), plus a first-order section for odd
+// orders. Each section is obtained with the bilinear transform prewarped at
+// cutoffHz.
+func butterworthSections(cutoffHz, sampleRate float64, order int, highPass bool) []*Biquad {
+	var sections []*Biquad
+
+	w0 := 2 * math.Pi * cutoffHz / sampleRate
+	cosW0 := math.Cos(w0)
+
+	for k := 1; k <= order/2; k++ {
+		q := 1 / (2 * math.Sin(float64(2*k-1)*math.Pi/float64(2*order)))
+		alpha := math.Sin(w0) / (2 * q)
+		a0 := 1 + alpha
+
+		if highPass {
+			sections = append(sections, NewBiquad(
+				(1+cosW0)/2/a0, -(1+cosW0)/a0, (1+cosW0)/2/a0,
+				-2*cosW0/a0, (1-alpha)/a0,
+			))
+		} else {
+			sections = append(sections, NewBiquad(
+				(1-cosW0)/2/a0, (1-cosW0)/a0, (1-cosW0)/2/a0,
+				-2*cosW0/a0, (1-alpha)/a0,
+			))
+		}
+	}
+
+	if order%2 == 1 {
+		k := math.Tan(math.Pi * cutoffHz / sampleRate)
+		a1 := (k - 1) / (k + 1)
+
+		if highPass {
+			sections = append(sections, NewBiquad(1/(1+k), -1/(1+k), 0, a1, 0))
+		} else {
+			sections = append(sections, NewBiquad(k/(1+k), k/(1+k), 0, a1, 0))
+		}
+	}
+
+	return sections
+}
@@ -0,0 +1,141 @@
+package filter
+
+import (
+	"math"
+	"math/cmplx"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// cascadeMagnitude returns the gain of a chain of biquads at freq.
+func cascadeMagnitude(sections []*Biquad, freq, sampleRate float64) float64 {
+	gain := 1.0
+	for _, section := range sections {
+		gain *= section.MagnitudeResponse(freq, sampleRate)
+	}
+	return gain
+}
+
+func TestLinkwitzRiley_SumsFlat(t *testing.T) {
+	sampleRate := 48000.0
+	crossover := 2000.0
+
+	for _, order := range []int{2, 4, 8} {
+		lowpass, highpass, err := NewLinkwitzRiley(crossover, sampleRate, order)
+		require.NoError(t, err)
+
+		rng := rand.New(rand.NewPCG(7, 8))
+		input := make([]float64, 1<<15)
+		for i := range 4096 {
+			input[i] = rng.Float64()*2 - 1
+		}
+
+		low := ProcessCascade(lowpass, input)
+		high := ProcessCascade(highpass, input)
+		sum := make([]float64, len(input))
+		for i := range sum {
+			sum[i] = low[i] + high[i]
+		}
+
+		in := fftOf(t, input)
+		out := fftOf(t, sum)
+		binWidth := sampleRate / float64(len(input))
+
+		for k := 1; k < len(in)/2; k++ {
+			freq := float64(k) * binWidth
+			if freq > crossover/2 && freq < crossover*2 {
+				continue
+			}
+			gainDB := 20 * math.Log10(cmplx.Abs(out[k])/cmplx.Abs(in[k]))
+			require.InDelta(t, 0.0, gainDB, 0.01, "order %d: sum deviates at %.1f Hz", order, freq)
+		}
+	}
+}
+
+func TestLinkwitzRiley_CrossoverPoint(t *testing.T) {
+	sampleRate := 44100.0
+	crossover := 800.0
+
+	for _, order := range []int{2, 4, 8} {
+		lowpass, highpass, err := NewLinkwitzRiley(crossover, sampleRate, order)
+		require.NoError(t, err)
+		require.Len(t, lowpass, max(order/2, 2))
+
+		lowDB := 20 * math.Log10(cascadeMagnitude(lowpass, crossover, sampleRate))
+		highDB := 20 * math.Log10(cascadeMagnitude(highpass, crossover, sampleRate))
+		require.InDelta(t, -6.02, lowDB, 0.01, "order %d low-pass", order)
+		require.InDelta(t, -6.02, highDB, 0.01, "order %d high-pass", order)
+
+		// Each branch rejects the other band.
+		require.Less(t, cascadeMagnitude(lowpass, 8*crossover, sampleRate), 0.02)
+		require.Less(t, cascadeMagnitude(highpass, crossover/8, sampleRate), 0.02)
+	}
+}
+
+func TestLinkwitzRiley_InvalidOrder(t *testing.T) {
+	for _, order := range []int{0, 1, 3, -2} {
+		_, _, err := NewLinkwitzRiley(1000.0, 48000.0, order)
+		require.ErrorIs(t, err, ErrInvalidOrder, "order %d", order)
+	}
+}
+
+func TestLinkwitzRiley_InvalidCutoff(t *testing.T) {
+	for _, crossover := range []float64{0, -1000, 24000, 30000} {
+		_, _, err := NewLinkwitzRiley(crossover, 48000.0, 4)
+		require.ErrorIs(t, err, ErrInvalidCutoff, "crossover %v", crossover)
+	}
+}
+
+func TestProcessCascade(t *testing.T) {
+	a := NewBiquad(0.5, 0, 0, 0, 0)
+	b := NewBiquad(1, 1, 0, 0, 0)
+	input := []float64{1, 0, 0, 2}
+
+	require.Equal(t, b.Process(a.Process(input)), ProcessCascade([]*Biquad{a, b}, input))
+	require.Equal(t, input, ProcessCascade(nil, input))
+}
+
+func TestNewButterworthHighPass(t *testing.T) {
+	sampleRate := 48000.0
+	cutoff := 1000.0
+
+	for _, order := range []int{1, 2, 3, 4, 5, 8} {
+		sections, err := NewButterworthHighPass(cutoff, sampleRate, order)
+		require.NoError(t, err)
+		require.Len(t, sections, (order+1)/2)
+
+		// -3 dB point within 1% of the cutoff.
+		gainDB := func(freq float64) float64 {
+			return 20 * math.Log10(cascadeMagnitude(sections, freq, sampleRate))
+		}
+		require.InDelta(t, -3.01, gainDB(cutoff), 0.01, "order %d", order)
+		require.Less(t, gainDB(cutoff*1.01), -3.01+0.5)
+		require.Greater(t, gainDB(cutoff*0.99), -3.01-0.5)
+
+		require.InDelta(t, 0.0, gainDB(20000), 0.1, "order %d passband", order)
+
+		// Well below the cutoff the response falls by 20*order dB/decade.
+		slope := gainDB(100) - gainDB(10)
+		require.InDelta(t, 20*float64(order), slope, 0.5, "order %d slope", order)
+
+		// Each pole contributes +45° at the cutoff.
+		var phase float64
+		for _, section := range sections {
+			phase += section.PhaseResponse(cutoff, sampleRate)
+		}
+		require.InDelta(t, 45*float64(order), phase*180/math.Pi, 1e-6, "order %d phase", order)
+	}
+}
+
+func TestNewButterworthHighPass_Errors(t *testing.T) {
+	_, err := NewButterworthHighPass(1000, 48000, 0)
+	require.ErrorIs(t, err, ErrInvalidOrder)
+
+	_, err = NewButterworthHighPass(0, 48000, 2)
+	require.ErrorIs(t, err, ErrInvalidCutoff)
+
+	_, err = NewButterworthHighPass(24000, 48000, 2)
+	require.ErrorIs(t, err, ErrInvalidCutoff)
+}
@@ -0,0 +1,32 @@
+package filter
+
+import (
+	"math"
+)
+
+// PreEmphasis returns a first-order high-shelf boost with time constant tau
+// in seconds (e.g. 50e-6 for FM broadcast in Europe, 75e-6 in the US). The
+// analog zero at 1/tau is mapped to z = exp(-1/(tau*sampleRate)) and the
+// filter is normalized to unity gain at DC:
+//
+//	H(z) = (1 - p*z^-1) / (1 - p)
+//
+// The boost levels off at (1+p)/(1-p) at Nyquist.
+func PreEmphasis(tau float64, sampleRate float64) *Biquad {
+	p := emphasisPole(tau, sampleRate)
+	return NewBiquad(1/(1-p), -p/(1-p), 0, 0, 0)
+}
+
+// DeEmphasis returns the exact inverse of PreEmphasis for the same tau and
+// sample rate, a one-pole low-shelf cut:
+//
+//	H(z) = (1 - p) / (1 - p*z^-1)
+func DeEmphasis(tau float64, sampleRate float64) *Biquad {
+	p := emphasisPole(tau, sampleRate)
+	return NewBiquad(1-p, 0, 0, -p, 0)
+}
+
+// emphasisPole maps the emphasis time constant to the z-plane.
+func emphasisPole(tau, sampleRate float64) float64 {
+	return math.Exp(-1 / (tau * sampleRate))
+}
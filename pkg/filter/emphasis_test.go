@@ -0,0 +1,72 @@
+package filter
+
+import (
+	"math"
+	"math/cmplx"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmphasis_RoundTrip(t *testing.T) {
+	taus := []struct {
+		name string
+		tau  float64
+	}{
+		{name: "fm_50us", tau: 50e-6},
+		{name: "fm_75us", tau: 75e-6},
+		{name: "cd_15us", tau: 15e-6},
+		{name: "j17_750us", tau: 750e-6},
+	}
+	sampleRate := 48000.0
+
+	for _, tt := range taus {
+		t.Run(tt.name, func(t *testing.T) {
+			rng := rand.New(rand.NewPCG(3, 4))
+			input := make([]float64, 1<<14)
+			for i := range input {
+				input[i] = rng.Float64()*2 - 1
+			}
+
+			pre := PreEmphasis(tt.tau, sampleRate)
+			de := DeEmphasis(tt.tau, sampleRate)
+			output := de.Process(pre.Process(input))
+
+			in := fftOf(t, input)
+			out := fftOf(t, output)
+
+			binWidth := sampleRate / float64(len(input))
+			for k := range len(in) / 2 {
+				freq := float64(k) * binWidth
+				if freq < 20 || freq > 20000 {
+					continue
+				}
+				gainDB := 20 * math.Log10(cmplx.Abs(out[k])/cmplx.Abs(in[k]))
+				require.InDelta(t, 0.0, gainDB, 0.1, "round trip deviates at %.1f Hz", freq)
+			}
+		})
+	}
+}
+
+func TestPreEmphasis_Shape(t *testing.T) {
+	sampleRate := 48000.0
+	pre := PreEmphasis(50e-6, sampleRate)
+	de := DeEmphasis(50e-6, sampleRate)
+
+	require.InDelta(t, 1.0, pre.MagnitudeResponse(0, sampleRate), 1e-12, "unity gain at DC")
+	require.InDelta(t, 1.0, de.MagnitudeResponse(0, sampleRate), 1e-12, "unity gain at DC")
+
+	previous := 1.0
+	for _, freq := range []float64{100, 1000, 3183, 8000, 15000, 20000} {
+		gain := pre.MagnitudeResponse(freq, sampleRate)
+		require.Greater(t, gain, previous, "pre-emphasis should rise with frequency at %f Hz", freq)
+		require.InDelta(t, 1.0, gain*de.MagnitudeResponse(freq, sampleRate), 1e-12)
+		previous = gain
+	}
+
+	// Around the corner frequency 1/(2π·50µs) ≈ 3183 Hz the boost is close
+	// to the analog +3 dB.
+	cornerDB := 20 * math.Log10(pre.MagnitudeResponse(3183, sampleRate))
+	require.InDelta(t, 3.0, cornerDB, 1.0)
+}
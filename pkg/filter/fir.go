@@ -0,0 +1,67 @@
+package filter
+
+import (
+	"math"
+)
+
+// NewFIRLowPass designs a linear-phase low-pass FIR of numTaps
+// coefficients with the windowed-sinc method: the ideal impulse response
+//
+//	h[n] = 2*fc * sinc(2*fc*(n - M/2))
+//
+// with fc = cutoffHz/sampleRate and M = numTaps-1 is shaped by a Hann
+// window, then normalized to unity gain at DC. The taps are symmetric, so
+// the filter delays the signal by M/2 samples.
+func NewFIRLowPass(cutoffHz, sampleRate float64, numTaps int) []float64 {
+	if numTaps <= 0 {
+		return nil
+	}
+
+	fc := cutoffHz / sampleRate
+	middle := float64(numTaps-1) / 2
+	taps := make([]float64, numTaps)
+
+	var sum float64
+	for n := range taps {
+		x := float64(n) - middle
+
+		h := 2 * fc
+		if x != 0 {
+			h = math.Sin(2*math.Pi*fc*x) / (math.Pi * x)
+		}
+
+		window := 1.0
+		if numTaps > 1 {
+			window = 0.5 - 0.5*math.Cos(2*math.Pi*float64(n)/float64(numTaps-1))
+		}
+
+		taps[n] = h * window
+		sum += taps[n]
+	}
+
+	for n := range taps {
+		taps[n] /= sum
+	}
+
+	return taps
+}
+
+// ApplyFIR convolves signal with taps in direct form and returns a slice of
+// the same length as signal. Samples before the start of the signal are
+// taken as zero.
+func ApplyFIR(taps, signal []float64) []float64 {
+	result := make([]float64, len(signal))
+
+	for n := range signal {
+		var y float64
+		for k, h := range taps {
+			if k > n {
+				break
+			}
+			y += h * signal[n-k]
+		}
+		result[n] = y
+	}
+
+	return result
+}
@@ -0,0 +1,83 @@
+package filter
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// firResponseDB evaluates the frequency response of taps at freq in dB.
+func firResponseDB(taps []float64, freq, sampleRate float64) float64 {
+	var h complex128
+	for n, tap := range taps {
+		h += complex(tap, 0) * cmplx.Exp(complex(0, -2*math.Pi*freq/sampleRate*float64(n)))
+	}
+	return 20 * math.Log10(cmplx.Abs(h))
+}
+
+func TestNewFIRLowPass_Shape(t *testing.T) {
+	taps := NewFIRLowPass(4000.0, 44100.0, 127)
+	require.Len(t, taps, 127)
+
+	for n := range taps {
+		require.InDelta(t, taps[n], taps[len(taps)-1-n], 1e-15, "taps must be symmetric")
+	}
+
+	sum := 0.0
+	for _, tap := range taps {
+		sum += tap
+	}
+	require.InDelta(t, 1.0, sum, 1e-12)
+}
+
+func TestNewFIRLowPass_Response(t *testing.T) {
+	sampleRate := 44100.0
+	cutoff := 4000.0
+	taps := NewFIRLowPass(cutoff, sampleRate, 127)
+
+	for freq := 0.0; freq <= cutoff-1000; freq += 50 {
+		require.Greater(t, firResponseDB(taps, freq, sampleRate), -1.0, "passband at %.0f Hz", freq)
+	}
+
+	for freq := cutoff + 1500; freq <= sampleRate/2; freq += 50 {
+		require.Less(t, firResponseDB(taps, freq, sampleRate), -40.0, "stopband at %.0f Hz", freq)
+	}
+}
+
+func TestApplyFIR(t *testing.T) {
+	taps := []float64{0.25, 0.5, 0.25}
+
+	// The impulse response is the taps themselves.
+	require.Equal(t, []float64{0.25, 0.5, 0.25, 0, 0}, ApplyFIR(taps, []float64{1, 0, 0, 0, 0}))
+	require.Equal(t, []float64{0.25, 0.75, 1, 1}, ApplyFIR(taps, []float64{1, 1, 1, 1}))
+	require.Empty(t, ApplyFIR(taps, nil))
+}
+
+func TestApplyFIR_FiltersSines(t *testing.T) {
+	sampleRate := 44100.0
+	taps := NewFIRLowPass(4000.0, sampleRate, 127)
+
+	amplitude := func(freq float64) float64 {
+		signal := make([]float64, 8192)
+		for n := range signal {
+			signal[n] = math.Sin(2 * math.Pi * freq * float64(n) / sampleRate)
+		}
+		output := ApplyFIR(taps, signal)
+
+		peak := 0.0
+		for _, v := range output[len(taps):] {
+			peak = math.Max(peak, math.Abs(v))
+		}
+		return peak
+	}
+
+	require.InDelta(t, 1.0, amplitude(1000.0), 0.11)
+	require.Less(t, amplitude(8000.0), 0.01)
+}
+
+func TestNewFIRLowPass_InvalidTaps(t *testing.T) {
+	require.Nil(t, NewFIRLowPass(1000.0, 44100.0, 0))
+	require.Equal(t, []float64{1}, NewFIRLowPass(1000.0, 44100.0, 1))
+}
@@ -0,0 +1,87 @@
+package filter
+
+import (
+	"math"
+)
+
+// EQType selects the response of a ParametricEQ band.
+type EQType int
+
+const (
+	Bell      EQType = iota // Boost or cut around Frequency
+	LowShelf                // Boost or cut below Frequency
+	HighShelf               // Boost or cut above Frequency
+)
+
+// ParametricEQ is a single equalizer band. Its biquad coefficients follow
+// the formulas of Robert Bristow-Johnson's Audio EQ Cookbook and are kept in
+// sync with the parameters by NewParametricEQ and SetGain.
+type ParametricEQ struct {
+	Biquad
+
+	FilterType EQType  // Shape of the band
+	Frequency  float64 // Center (Bell) or corner (shelves) frequency in Hz
+	Q          float64 // Bandwidth for Bell, shelf slope for the shelves
+	GaindB     float64 // Boost (positive) or cut (negative) in dB
+	SampleRate float64 // Sampling frequency in Hz
+}
+
+// NewParametricEQ returns an equalizer band with its coefficients computed.
+func NewParametricEQ(filterType EQType, frequency, q, gaindB, sampleRate float64) *ParametricEQ {
+	eq := &ParametricEQ{
+		FilterType: filterType,
+		Frequency:  frequency,
+		Q:          q,
+		GaindB:     gaindB,
+		SampleRate: sampleRate,
+	}
+	eq.update()
+
+	return eq
+}
+
+// SetGain changes the gain of the band and recomputes the coefficients in
+// place, which makes it safe to call from an audio callback.
+func (e *ParametricEQ) SetGain(gaindB float64) {
+	e.GaindB = gaindB
+	e.update()
+}
+
+// update recomputes the normalized biquad coefficients from the band
+// parameters.
+func (e *ParametricEQ) update() {
+	a := math.Pow(10, e.GaindB/40)
+	w0 := 2 * math.Pi * e.Frequency / e.SampleRate
+	cosW0 := math.Cos(w0)
+	alpha := math.Sin(w0) / (2 * e.Q)
+	sqrtA := 2 * math.Sqrt(a) * alpha
+
+	var b0, b1, b2, a0, a1, a2 float64
+
+	switch e.FilterType {
+	case LowShelf:
+		b0 = a * ((a + 1) - (a-1)*cosW0 + sqrtA)
+		b1 = 2 * a * ((a - 1) - (a+1)*cosW0)
+		b2 = a * ((a + 1) - (a-1)*cosW0 - sqrtA)
+		a0 = (a + 1) + (a-1)*cosW0 + sqrtA
+		a1 = -2 * ((a - 1) + (a+1)*cosW0)
+		a2 = (a + 1) + (a-1)*cosW0 - sqrtA
+	case HighShelf:
+		b0 = a * ((a + 1) + (a-1)*cosW0 + sqrtA)
+		b1 = -2 * a * ((a - 1) + (a+1)*cosW0)
+		b2 = a * ((a + 1) + (a-1)*cosW0 - sqrtA)
+		a0 = (a + 1) - (a-1)*cosW0 + sqrtA
+		a1 = 2 * ((a - 1) - (a+1)*cosW0)
+		a2 = (a + 1) - (a-1)*cosW0 - sqrtA
+	default:
+		b0 = 1 + alpha*a
+		b1 = -2 * cosW0
+		b2 = 1 - alpha*a
+		a0 = 1 + alpha/a
+		a1 = -2 * cosW0
+		a2 = 1 - alpha/a
+	}
+
+	e.B0, e.B1, e.B2 = b0/a0, b1/a0, b2/a0
+	e.A1, e.A2 = a1/a0, a2/a0
+}
@@ -0,0 +1,85 @@
+package filter
+
+import (
+	"math"
+	"math/cmplx"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// measuredResponse filters white noise followed by enough silence for the
+// tail to decay and returns the per-bin gain in dB along with the bin
+// width in Hz.
+func measuredResponse(t *testing.T, eq *ParametricEQ) ([]float64, float64) {
+	t.Helper()
+
+	rng := rand.New(rand.NewPCG(5, 6))
+	input := make([]float64, 1<<15)
+	for i := range 4096 {
+		input[i] = rng.Float64()*2 - 1
+	}
+
+	in := fftOf(t, input)
+	out := fftOf(t, eq.Process(input))
+
+	gains := make([]float64, len(in)/2)
+	for k := range gains {
+		gains[k] = 20 * math.Log10(cmplx.Abs(out[k])/cmplx.Abs(in[k]))
+	}
+
+	return gains, eq.SampleRate / float64(len(input))
+}
+
+func TestParametricEQ_BellBoost(t *testing.T) {
+	eq := NewParametricEQ(Bell, 1000.0, 1.0, 12.0, 48000.0)
+	gains, binWidth := measuredResponse(t, eq)
+
+	center := int(math.Round(1000.0 / binWidth))
+	require.InDelta(t, 12.0, gains[center], 0.1, "expected +12 dB at 1 kHz")
+
+	for _, freq := range []float64{30, 100, 10000, 20000} {
+		k := int(math.Round(freq / binWidth))
+		require.InDelta(t, 0.0, gains[k], 0.5, "expected no boost at %.0f Hz", freq)
+	}
+
+	for k := 1; k < len(gains); k++ {
+		expected := 20 * math.Log10(eq.MagnitudeResponse(float64(k)*binWidth, eq.SampleRate))
+		require.InDelta(t, expected, gains[k], 1e-6, "bin %d", k)
+	}
+}
+
+func TestParametricEQ_Shelves(t *testing.T) {
+	low := NewParametricEQ(LowShelf, 200.0, 0.707, 6.0, 48000.0)
+	require.InDelta(t, 6.0, 20*math.Log10(low.MagnitudeResponse(10, 48000.0)), 0.1)
+	require.InDelta(t, 0.0, 20*math.Log10(low.MagnitudeResponse(10000, 48000.0)), 0.1)
+
+	high := NewParametricEQ(HighShelf, 5000.0, 0.707, -9.0, 48000.0)
+	require.InDelta(t, 0.0, 20*math.Log10(high.MagnitudeResponse(50, 48000.0)), 0.1)
+	require.InDelta(t, -9.0, 20*math.Log10(high.MagnitudeResponse(23000, 48000.0)), 0.2)
+}
+
+func TestParametricEQ_ZeroGainIsFlat(t *testing.T) {
+	for _, filterType := range []EQType{Bell, LowShelf, HighShelf} {
+		eq := NewParametricEQ(filterType, 1000.0, 2.0, 0.0, 48000.0)
+		gains, _ := measuredResponse(t, eq)
+
+		for k := 1; k < len(gains); k++ {
+			require.InDelta(t, 0.0, gains[k], 1e-9, "type %d bin %d", filterType, k)
+		}
+	}
+}
+
+func TestParametricEQ_SetGain(t *testing.T) {
+	eq := NewParametricEQ(Bell, 1000.0, 1.0, 0.0, 48000.0)
+
+	eq.SetGain(-6.0)
+	require.Equal(t, *NewParametricEQ(Bell, 1000.0, 1.0, -6.0, 48000.0), *eq)
+	require.InDelta(t, -6.0, 20*math.Log10(eq.MagnitudeResponse(1000, 48000.0)), 1e-9)
+
+	allocs := testing.AllocsPerRun(100, func() {
+		eq.SetGain(3.0)
+	})
+	require.Zero(t, allocs)
+}
@@ -0,0 +1,81 @@
+package filter
+
+// Shelf slopes of the Pultec low section. The cut shelf spreads further
+// around the corner than the boost shelf, so when both are engaged at the
+// same frequency their transitions do not cancel.
+const (
+	pultecBoostSlope = 1.0
+	pultecCutSlope   = 0.5
+)
+
+// Pultec models the passive EQP-1A program equalizer. The low section is a
+// boost shelf and a cut shelf sharing LowBoostHz, the high section a bell
+// whose width is given in Hz. Engaging LowBoostDB and LowCutDB together
+// leaves the gain at DC at LowBoostDB-LowCutDB while the mismatched shelf
+// slopes carve a bump below the corner and a dip above it, the low-end
+// enhancement the hardware is known for.
+type Pultec struct {
+	LowBoostHz  float64 // Corner of the low boost and cut shelves
+	LowBoostDB  float64 // Low shelf boost in dB
+	LowCutDB    float64 // Low shelf attenuation in dB
+	HighFreqHz  float64 // Center of the high boost
+	HighBoostDB float64 // High bell boost in dB
+	HighBWHz    float64 // Bandwidth of the high bell in Hz
+	SampleRate  float64 // Sampling frequency in Hz
+
+	stages []*ParametricEQ
+}
+
+// NewPultec returns a Pultec equalizer with its filter stages computed.
+func NewPultec(lowBoostHz, lowBoostDB, lowCutDB, highFreqHz, highBoostDB, highBWHz, sampleRate float64) *Pultec {
+	p := &Pultec{
+		LowBoostHz:  lowBoostHz,
+		LowBoostDB:  lowBoostDB,
+		LowCutDB:    lowCutDB,
+		HighFreqHz:  highFreqHz,
+		HighBoostDB: highBoostDB,
+		HighBWHz:    highBWHz,
+		SampleRate:  sampleRate,
+	}
+	p.Update()
+
+	return p
+}
+
+// Update recomputes the filter stages after the parameters were changed.
+func (p *Pultec) Update() {
+	highQ := 1.0
+	if p.HighBWHz > 0 {
+		highQ = p.HighFreqHz / p.HighBWHz
+	}
+
+	p.stages = []*ParametricEQ{
+		NewParametricEQ(LowShelf, p.LowBoostHz, pultecBoostSlope, p.LowBoostDB, p.SampleRate),
+		NewParametricEQ(LowShelf, p.LowBoostHz, pultecCutSlope, -p.LowCutDB, p.SampleRate),
+		NewParametricEQ(Bell, p.HighFreqHz, highQ, p.HighBoostDB, p.SampleRate),
+	}
+}
+
+// Process runs samples through the low boost, low cut and high boost
+// stages in turn.
+func (p Pultec) Process(samples []float64) []float64 {
+	return ProcessCascade(p.sections(), samples)
+}
+
+// MagnitudeResponse returns the linear gain of the equalizer at freq.
+func (p Pultec) MagnitudeResponse(freq float64) float64 {
+	gain := 1.0
+	for _, section := range p.sections() {
+		gain *= section.MagnitudeResponse(freq, p.SampleRate)
+	}
+	return gain
+}
+
+// sections returns the biquads of the filter stages.
+func (p Pultec) sections() []*Biquad {
+	sections := make([]*Biquad, len(p.stages))
+	for i, stage := range p.stages {
+		sections[i] = &stage.Biquad
+	}
+	return sections
+}
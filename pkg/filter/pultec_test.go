@@ -0,0 +1,70 @@
+package filter
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func toDB(gain float64) float64 {
+	return 20 * math.Log10(gain)
+}
+
+func TestPultec_BoostAndCutInteraction(t *testing.T) {
+	const sampleRate = 48000.0
+	eq := NewPultec(60, 10, 10, 8000, 0, 2000, sampleRate)
+
+	// Equal boost and cut leave the DC gain untouched...
+	require.InDelta(t, 0.0, toDB(eq.MagnitudeResponse(1)), 0.05)
+	// ...and the top end flat.
+	require.InDelta(t, 0.0, toDB(eq.MagnitudeResponse(15000)), 0.05)
+
+	// The slope mismatch keeps a bump below the corner and a dip above it.
+	require.Greater(t, toDB(eq.MagnitudeResponse(30)), 1.0)
+	require.Less(t, toDB(eq.MagnitudeResponse(200)), -1.0)
+}
+
+func TestPultec_Sections(t *testing.T) {
+	const sampleRate = 48000.0
+
+	boost := NewPultec(100, 6, 0, 8000, 0, 2000, sampleRate)
+	require.InDelta(t, 6.0, toDB(boost.MagnitudeResponse(1)), 0.05)
+	require.InDelta(t, 0.0, toDB(boost.MagnitudeResponse(10000)), 0.1)
+
+	cut := NewPultec(100, 0, 6, 8000, 0, 2000, sampleRate)
+	require.InDelta(t, -6.0, toDB(cut.MagnitudeResponse(1)), 0.05)
+
+	high := NewPultec(100, 0, 0, 8000, 4, 2000, sampleRate)
+	require.InDelta(t, 4.0, toDB(high.MagnitudeResponse(8000)), 0.05)
+	require.InDelta(t, 0.0, toDB(high.MagnitudeResponse(100)), 0.05)
+}
+
+func TestPultec_ProcessMatchesResponse(t *testing.T) {
+	const sampleRate = 48000.0
+	eq := NewPultec(60, 8, 4, 10000, 3, 3000, sampleRate)
+
+	for _, freq := range []float64{40, 300, 10000} {
+		input := make([]float64, int(sampleRate))
+		for n := range input {
+			input[n] = math.Sin(2 * math.Pi * freq * float64(n) / sampleRate)
+		}
+		output := eq.Process(input)
+
+		// Measure the steady-state peak over the second half.
+		peak := 0.0
+		for _, v := range output[len(output)/2:] {
+			peak = math.Max(peak, math.Abs(v))
+		}
+		require.InDelta(t, eq.MagnitudeResponse(freq), peak, 0.01, "freq %v", freq)
+	}
+}
+
+func TestPultec_Update(t *testing.T) {
+	eq := NewPultec(60, 0, 0, 8000, 0, 2000, 48000)
+	require.InDelta(t, 0.0, toDB(eq.MagnitudeResponse(1)), 1e-9)
+
+	eq.LowBoostDB = 5
+	eq.Update()
+	require.InDelta(t, 5.0, toDB(eq.MagnitudeResponse(1)), 0.05)
+}
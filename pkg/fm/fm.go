@@ -0,0 +1,122 @@
+package fm
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+// ErrNoOperators is returned when an Algorithm has no operators.
+var ErrNoOperators = errors.New("algorithm has no operators")
+
+// ErrInvalidRouting is returned when the routing matrix is not NxN for N
+// operators.
+var ErrInvalidRouting = errors.New("routing matrix must be NxN for N operators")
+
+// ErrInvalidSampleRate is returned when the sample rate is not positive.
+var ErrInvalidSampleRate = errors.New("sample rate must be positive")
+
+// Generate renders the algorithm for duration. Every operator is evaluated
+// at each sample as
+//
+//	y_i[n] = A_i * env_i(t) * sin(2π*f_i*t + Σ_j Routing[i][j] * y_j[n-1])
+//
+// Modulation uses the operator outputs of the previous sample, which lets
+// any routing, including feedback loops, be evaluated in a single pass.
+// Each operator envelope is released so that its release stage ends with
+// the note; envelopes whose release is longer than the note are held
+// throughout instead.
+func (a *Algorithm) Generate(duration time.Duration, sampleRate float64) ([]float64, error) {
+	if err := a.validate(); err != nil {
+		return nil, err
+	}
+	if sampleRate <= 0 {
+		return nil, ErrInvalidSampleRate
+	}
+
+	carriers := a.carriers()
+	numSamples := int(duration.Seconds() * sampleRate)
+	result := make([]float64, numSamples)
+
+	previous := make([]float64, len(a.Operators))
+	current := make([]float64, len(a.Operators))
+
+	for n := range result {
+		t := float64(n) / sampleRate
+		elapsed := time.Duration(t * float64(time.Second))
+
+		for i, op := range a.Operators {
+			var modulation float64
+			for j, gain := range a.Routing[i] {
+				modulation += gain * previous[j]
+			}
+
+			current[i] = op.Amplitude * op.level(elapsed, duration) *
+				math.Sin(2*math.Pi*op.Frequency*t+modulation)
+		}
+
+		var sum float64
+		for _, i := range carriers {
+			sum += current[i]
+		}
+		result[n] = sum / float64(len(carriers))
+
+		previous, current = current, previous
+	}
+
+	return result, nil
+}
+
+func (a *Algorithm) validate() error {
+	if len(a.Operators) == 0 {
+		return ErrNoOperators
+	}
+	if len(a.Routing) != len(a.Operators) {
+		return ErrInvalidRouting
+	}
+	for _, row := range a.Routing {
+		if len(row) != len(a.Operators) {
+			return ErrInvalidRouting
+		}
+	}
+	return nil
+}
+
+// carriers returns the indices of the operators that modulate no other
+// operator. Feedback onto itself does not make an operator a modulator.
+func (a *Algorithm) carriers() []int {
+	var carriers []int
+	for j := range a.Operators {
+		modulator := false
+		for i := range a.Operators {
+			if i != j && a.Routing[i][j] != 0 {
+				modulator = true
+				break
+			}
+		}
+		if !modulator {
+			carriers = append(carriers, j)
+		}
+	}
+
+	// A fully connected ring has no carrier; listen to every operator.
+	if len(carriers) == 0 {
+		for j := range a.Operators {
+			carriers = append(carriers, j)
+		}
+	}
+	return carriers
+}
+
+// level returns the envelope level of the operator elapsed into a note of
+// the given duration.
+func (o Operator) level(elapsed, duration time.Duration) float64 {
+	if o.envelope == nil {
+		return 1
+	}
+	noteLength := duration - o.envelope.Release
+	if noteLength <= 0 {
+		noteLength = duration
+	}
+	return o.envelope.Level(elapsed, noteLength)
+}
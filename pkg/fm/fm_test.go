@@ -0,0 +1,144 @@
+package fm
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ECecillo/lib.go.sound/pkg/analysis"
+	"github.com/ECecillo/lib.go.sound/pkg/envelope"
+)
+
+// countPartials counts the spectral peaks of the first 16384 samples that
+// are within 60 dB of the strongest one.
+func countPartials(t *testing.T, samples []float64) int {
+	t.Helper()
+
+	const size = 16384
+	require.GreaterOrEqual(t, len(samples), size)
+
+	window := analysis.HannWindow(size)
+	frame := make([]float64, size)
+	for n := range frame {
+		frame[n] = samples[n] * window[n]
+	}
+
+	spectrum, err := analysis.FFT(frame)
+	require.NoError(t, err)
+
+	magnitudes := make([]float64, size/2)
+	peak := 0.0
+	for k := range magnitudes {
+		magnitudes[k] = cmplx.Abs(spectrum[k])
+		peak = math.Max(peak, magnitudes[k])
+	}
+
+	threshold := peak * math.Pow(10, -60.0/20)
+	count := 0
+	for k := 1; k < len(magnitudes)-1; k++ {
+		m := magnitudes[k]
+		if m > threshold && m > magnitudes[k-1] && m >= magnitudes[k+1] {
+			count++
+		}
+	}
+	return count
+}
+
+func TestGenerate_SimpleFM(t *testing.T) {
+	sampleRate := 44100.0
+	carrier := 1000.0
+	modulator := 250.0
+	index := 2.0
+
+	alg := NewAlgorithm(
+		[]Operator{NewOperator(carrier, 1, nil), NewOperator(modulator, index, nil)},
+		[][]float64{{0, 1}, {0, 0}},
+	)
+
+	result, err := alg.Generate(100*time.Millisecond, sampleRate)
+	require.NoError(t, err)
+	require.Len(t, result, 4410)
+
+	// Compare against the closed form, allowing for the one-sample delay
+	// of the modulator.
+	for n := 1; n < len(result); n++ {
+		tt := float64(n) / sampleRate
+		prev := float64(n-1) / sampleRate
+		want := math.Sin(2*math.Pi*carrier*tt + index*math.Sin(2*math.Pi*modulator*prev))
+		require.InDelta(t, want, result[n], 1e-9)
+	}
+}
+
+func TestGenerate_BellHasMorePartialsThanTwoOperators(t *testing.T) {
+	sampleRate := 44100.0
+
+	bell, err := NewBell().Generate(time.Second, sampleRate)
+	require.NoError(t, err)
+
+	twoOp := NewAlgorithm(
+		[]Operator{NewOperator(440, 1, nil), NewOperator(440*3.5, 2.5, nil)},
+		[][]float64{{0, 1}, {0, 0}},
+	)
+	simple, err := twoOp.Generate(time.Second, sampleRate)
+	require.NoError(t, err)
+
+	require.Greater(t, countPartials(t, bell), countPartials(t, simple))
+}
+
+func TestPresets(t *testing.T) {
+	for name, alg := range map[string]*Algorithm{"bell": NewBell(), "brass": NewBrass()} {
+		t.Run(name, func(t *testing.T) {
+			require.Len(t, alg.Operators, 6)
+
+			result, err := alg.Generate(500*time.Millisecond, 44100)
+			require.NoError(t, err)
+
+			peak := 0.0
+			for _, v := range result {
+				require.False(t, math.IsNaN(v))
+				peak = math.Max(peak, math.Abs(v))
+			}
+			require.Greater(t, peak, 0.1)
+			require.LessOrEqual(t, peak, 1.0)
+
+			// Every envelope has been released by the end of the note.
+			require.InDelta(t, 0.0, result[len(result)-1], 0.01)
+		})
+	}
+}
+
+func TestGenerate_Envelope(t *testing.T) {
+	adsr := envelope.NewADSR(0, 0, 1, 50*time.Millisecond)
+	alg := NewAlgorithm([]Operator{NewOperator(100, 1, adsr)}, [][]float64{{0}})
+
+	result, err := alg.Generate(100*time.Millisecond, 1000)
+	require.NoError(t, err)
+
+	// Held for the first 50 ms, then released linearly.
+	require.InDelta(t, math.Sin(2*math.Pi*100*0.012), result[12], 1e-9)
+	require.InDelta(t, 0.46*math.Sin(2*math.Pi*100*0.077), result[77], 1e-9)
+
+	// A release longer than the note keeps the envelope held.
+	result, err = alg.Generate(40*time.Millisecond, 1000)
+	require.NoError(t, err)
+	require.InDelta(t, math.Sin(2*math.Pi*100*0.037), result[37], 1e-9)
+}
+
+func TestGenerate_Errors(t *testing.T) {
+	_, err := NewAlgorithm(nil, nil).Generate(time.Second, 44100)
+	require.ErrorIs(t, err, ErrNoOperators)
+
+	ops := []Operator{NewOperator(440, 1, nil), NewOperator(880, 1, nil)}
+
+	_, err = NewAlgorithm(ops, [][]float64{{0, 0}}).Generate(time.Second, 44100)
+	require.ErrorIs(t, err, ErrInvalidRouting)
+
+	_, err = NewAlgorithm(ops, [][]float64{{0, 0}, {0}}).Generate(time.Second, 44100)
+	require.ErrorIs(t, err, ErrInvalidRouting)
+
+	_, err = NewAlgorithm(ops, [][]float64{{0, 0}, {0, 0}}).Generate(time.Second, 0)
+	require.ErrorIs(t, err, ErrInvalidSampleRate)
+}
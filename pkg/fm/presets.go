@@ -0,0 +1,69 @@
+package fm
+
+import (
+	"time"
+
+	"github.com/ECecillo/lib.go.sound/pkg/envelope"
+)
+
+// presetFrequency is the pitch of the preset algorithms, A4.
+const presetFrequency = 440.0
+
+// NewBell returns a 6-operator tubular bell at A4: three modulator/carrier
+// pairs with inharmonic frequency ratios (1:3.5, 2:7.1 and 0.5:1.41) and
+// long exponential-like decays, the last modulator with some feedback.
+func NewBell() *Algorithm {
+	f := presetFrequency
+	strike := envelope.NewADSR(2*time.Millisecond, 1500*time.Millisecond, 0.1, 300*time.Millisecond)
+	ring := envelope.NewADSR(time.Millisecond, 3*time.Second, 0.3, 400*time.Millisecond)
+
+	operators := []Operator{
+		NewOperator(f, 1.0, ring),
+		NewOperator(3.5*f, 2.5, strike),
+		NewOperator(2*f, 0.6, ring),
+		NewOperator(7.1*f, 1.8, strike),
+		NewOperator(0.5*f, 0.4, ring),
+		NewOperator(1.41*f, 1.2, strike),
+	}
+
+	routing := [][]float64{
+		{0, 1, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0, 0},
+		{0, 0, 0, 1, 0, 0},
+		{0, 0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0, 1},
+		{0, 0, 0, 0, 0, 0.3},
+	}
+
+	return NewAlgorithm(operators, routing)
+}
+
+// NewBrass returns a 6-operator brass patch at A4: a stack of three
+// harmonic modulators driving the main carrier, whose modulation index
+// swells with the envelope to brighten the attack, plus a slightly detuned
+// carrier pair for width.
+func NewBrass() *Algorithm {
+	f := presetFrequency
+	swell := envelope.NewADSR(60*time.Millisecond, 200*time.Millisecond, 0.7, 150*time.Millisecond)
+	body := envelope.NewADSR(30*time.Millisecond, 100*time.Millisecond, 0.9, 150*time.Millisecond)
+
+	operators := []Operator{
+		NewOperator(f, 1.0, body),
+		NewOperator(f, 1.8, swell),
+		NewOperator(f, 0.8, swell),
+		NewOperator(2*f, 0.5, swell),
+		NewOperator(f*1.003, 0.7, body),
+		NewOperator(f*1.003, 1.2, swell),
+	}
+
+	routing := [][]float64{
+		{0, 1, 0, 0, 0, 0},
+		{0, 0, 1, 0, 0, 0},
+		{0, 0, 0, 1, 0, 0},
+		{0, 0, 0, 0.4, 0, 0},
+		{0, 0, 0, 0, 0, 1},
+		{0, 0, 0, 0, 0, 0},
+	}
+
+	return NewAlgorithm(operators, routing)
+}
@@ -0,0 +1,43 @@
+package fm
+
+import (
+	"github.com/ECecillo/lib.go.sound/pkg/envelope"
+)
+
+// Operator is a sine oscillator with its own envelope. Depending on the
+// routing of the Algorithm it belongs to, its output either modulates the
+// phase of other operators or is heard directly.
+type Operator struct {
+	Frequency float64 // Frequency in Hz
+	Amplitude float64 // Peak output; the modulation index when it is a modulator
+
+	envelope *envelope.ADSR
+}
+
+// NewOperator creates an Operator. adsr shapes its output over the note; a
+// nil adsr keeps the output at Amplitude for the whole note.
+func NewOperator(frequency, amplitude float64, adsr *envelope.ADSR) Operator {
+	return Operator{
+		Frequency: frequency,
+		Amplitude: amplitude,
+		envelope:  adsr,
+	}
+}
+
+// Algorithm wires operators together. Routing[i][j] is the gain with which
+// the output of operator j modulates the phase of operator i, so a non-zero
+// diagonal entry is operator feedback. Operators that modulate no other
+// operator are carriers, and the output of the Algorithm is their average.
+type Algorithm struct {
+	Operators []Operator
+	Routing   [][]float64
+}
+
+// NewAlgorithm creates an Algorithm from its operators and NxN routing
+// matrix.
+func NewAlgorithm(operators []Operator, routing [][]float64) *Algorithm {
+	return &Algorithm{
+		Operators: operators,
+		Routing:   routing,
+	}
+}
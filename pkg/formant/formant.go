@@ -0,0 +1,109 @@
+package formant
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+var (
+	// ErrInvalidSampleRate is returned when the sample rate is not positive.
+	ErrInvalidSampleRate = errors.New("sample rate must be positive")
+	// ErrInvalidFormant is returned when a formant cannot be realized at
+	// the given sample rate.
+	ErrInvalidFormant = errors.New("invalid formant")
+)
+
+// Formant is a resonance of the vocal tract.
+type Formant struct {
+	CenterFreq float64 // Resonance frequency in Hz
+	Bandwidth  float64 // -3 dB bandwidth in Hz
+}
+
+// FormantFilter models the vocal tract as a cascade of resonators, one per
+// formant.
+type FormantFilter struct {
+	Formants []Formant
+}
+
+// vowels holds the first three formants of an average adult male voice
+// (Peterson & Barney, 1952).
+var vowels = map[string][]Formant{
+	"a": {{730, 70}, {1090, 90}, {2440, 150}},
+	"e": {{530, 70}, {1840, 90}, {2480, 150}},
+	"i": {{270, 70}, {2290, 90}, {3010, 150}},
+	"o": {{570, 70}, {840, 90}, {2410, 150}},
+	"u": {{300, 70}, {870, 90}, {2240, 150}},
+}
+
+// NewVowel returns the formant filter of one of the vowels "a", "e", "i",
+// "o" or "u", or nil for any other vowel. Formants that cannot be
+// represented at sampleRate (at or above Nyquist) are left out.
+func NewVowel(vowel string, sampleRate float64) *FormantFilter {
+	formants, ok := vowels[vowel]
+	if !ok {
+		return nil
+	}
+
+	filter := &FormantFilter{}
+	for _, f := range formants {
+		if f.CenterFreq < sampleRate/2 {
+			filter.Formants = append(filter.Formants, f)
+		}
+	}
+
+	return filter
+}
+
+// Synthesize runs the excitation signal, typically a glottal pulse train or
+// a sawtooth, through every formant resonator in turn.
+func (f FormantFilter) Synthesize(excitation []float64, sampleRate float64) ([]float64, error) {
+	if sampleRate <= 0 {
+		return nil, ErrInvalidSampleRate
+	}
+
+	result := make([]float64, len(excitation))
+	copy(result, excitation)
+
+	for _, formant := range f.Formants {
+		r, err := newResonator(formant, sampleRate)
+		if err != nil {
+			return nil, err
+		}
+		r.process(result)
+	}
+
+	return result, nil
+}
+
+// resonator is a two-pole peak filter normalized to unity gain at DC
+// (Klatt, 1980):
+//
+//	y[n] = a*x[n] + b*y[n-1] + c*y[n-2]
+type resonator struct {
+	a, b, c float64
+}
+
+func newResonator(f Formant, sampleRate float64) (resonator, error) {
+	if f.CenterFreq <= 0 || f.CenterFreq >= sampleRate/2 || f.Bandwidth <= 0 {
+		return resonator{}, fmt.Errorf("%w: %+v at %f Hz", ErrInvalidFormant, f, sampleRate)
+	}
+
+	radius := math.Exp(-math.Pi * f.Bandwidth / sampleRate)
+	theta := 2 * math.Pi * f.CenterFreq / sampleRate
+
+	b := 2 * radius * math.Cos(theta)
+	c := -radius * radius
+
+	return resonator{a: 1 - b - c, b: b, c: c}, nil
+}
+
+// process filters samples in place.
+func (r resonator) process(samples []float64) {
+	var y1, y2 float64
+	for i, x := range samples {
+		y := r.a*x + r.b*y1 + r.c*y2
+		y2, y1 = y1, y
+		samples[i] = y
+	}
+}
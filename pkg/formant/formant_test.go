@@ -0,0 +1,90 @@
+package formant
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// magnitudeAt evaluates the DTFT magnitude of an impulse response h at freq.
+func magnitudeAt(h []float64, freq, sampleRate float64) float64 {
+	var sum complex128
+	for n, v := range h {
+		sum += complex(v, 0) * cmplx.Exp(complex(0, -2*math.Pi*freq*float64(n)/sampleRate))
+	}
+	return cmplx.Abs(sum)
+}
+
+func TestNewVowel_PeaksAtFormants(t *testing.T) {
+	sampleRate := 16000.0
+
+	for _, vowel := range []string{"a", "e", "i", "o", "u"} {
+		t.Run(vowel, func(t *testing.T) {
+			filter := NewVowel(vowel, sampleRate)
+			require.NotNil(t, filter)
+			require.Len(t, filter.Formants, 3)
+
+			impulse := make([]float64, 4096)
+			impulse[0] = 1.0
+			response, err := filter.Synthesize(impulse, sampleRate)
+			require.NoError(t, err)
+
+			for _, f := range filter.Formants {
+				// Locate the strongest frequency around the formant.
+				peakFreq, peakMag := 0.0, 0.0
+				for freq := f.CenterFreq - f.Bandwidth; freq <= f.CenterFreq+f.Bandwidth; freq++ {
+					if mag := magnitudeAt(response, freq, sampleRate); mag > peakMag {
+						peakFreq, peakMag = freq, mag
+					}
+				}
+
+				require.InDelta(t, f.CenterFreq, peakFreq, 0.05*f.CenterFreq,
+					"vowel %q: peak for formant %f Hz found at %f Hz", vowel, f.CenterFreq, peakFreq)
+
+				// The peak must stand out from its surroundings.
+				below := magnitudeAt(response, peakFreq-2*f.Bandwidth, sampleRate)
+				above := magnitudeAt(response, peakFreq+2*f.Bandwidth, sampleRate)
+				require.Greater(t, peakMag, below)
+				require.Greater(t, peakMag, above)
+			}
+		})
+	}
+}
+
+func TestNewVowel_Unknown(t *testing.T) {
+	require.Nil(t, NewVowel("y", 44100))
+}
+
+func TestNewVowel_DropsFormantsAboveNyquist(t *testing.T) {
+	filter := NewVowel("i", 5000)
+	require.NotNil(t, filter)
+	require.Len(t, filter.Formants, 2, "3010 Hz cannot be represented at 5 kHz")
+}
+
+func TestSynthesize_UnityDCGain(t *testing.T) {
+	filter := NewVowel("a", 44100)
+
+	dc := make([]float64, 44100)
+	for i := range dc {
+		dc[i] = 1.0
+	}
+
+	output, err := filter.Synthesize(dc, 44100)
+	require.NoError(t, err)
+	require.InDelta(t, 1.0, output[len(output)-1], 1e-9)
+}
+
+func TestSynthesize_Errors(t *testing.T) {
+	_, err := NewVowel("a", 44100).Synthesize([]float64{1}, 0)
+	require.ErrorIs(t, err, ErrInvalidSampleRate)
+
+	filter := FormantFilter{Formants: []Formant{{CenterFreq: 30000, Bandwidth: 100}}}
+	_, err = filter.Synthesize([]float64{1}, 44100)
+	require.ErrorIs(t, err, ErrInvalidFormant)
+
+	filter = FormantFilter{Formants: []Formant{{CenterFreq: 500, Bandwidth: 0}}}
+	_, err = filter.Synthesize([]float64{1}, 44100)
+	require.ErrorIs(t, err, ErrInvalidFormant)
+}
@@ -1,9 +1,20 @@
 package format
 
 import (
+	"errors"
+	"fmt"
+	"io"
 	"math"
 )
 
+// ErrWrongLength is returned when decoding a byte slice whose length does
+// not match the sample size of the format.
+var ErrWrongLength = errors.New("wrong sample length for format")
+
+// ErrNotDecoder is returned when decoding with a format that does not
+// implement Decoder.
+var ErrNotDecoder = errors.New("format does not implement Decoder")
+
 type AudioFormat interface {
 	// BitDepth return an integer representing the byte deph of the format.
 	BitDepth() int
@@ -12,6 +23,129 @@ type AudioFormat interface {
 	ConvertSample(float64) []byte
 }
 
+// Decoder is implemented by formats able to read their samples back. It is
+// separate from AudioFormat so that implementations written before it
+// existed still satisfy AudioFormat; callers type-assert when they need
+// to decode. Every format of this package implements it.
+type Decoder interface {
+	// Decode reverse ConvertSample by reconstructing the float64 sample
+	// from its byte representation.
+	Decode([]byte) (float64, error)
+}
+
+var (
+	_ Decoder = PCM8{}
+	_ Decoder = PCM16{}
+	_ Decoder = PCM32{}
+	_ Decoder = Float32{}
+	_ Decoder = Float64{}
+)
+
+// BatchWriter is implemented by formats able to encode a whole slice of
+// samples faster than one ConvertSample call per sample. Like Decoder it
+// is separate from AudioFormat; WriteAll uses it when available. Every
+// format of this package implements it.
+type BatchWriter interface {
+	// WriteAll encodes every sample into a single buffer and writes it
+	// with one call to w.Write, returning the number of bytes written.
+	WriteAll(samples []float64, w io.Writer) (int64, error)
+}
+
+var (
+	_ BatchWriter = PCM8{}
+	_ BatchWriter = PCM16{}
+	_ BatchWriter = PCM32{}
+	_ BatchWriter = Float32{}
+	_ BatchWriter = Float64{}
+)
+
+// WriteAll encodes samples with f and writes them with one call to
+// w.Write, returning the number of bytes written. It delegates to f when f
+// implements BatchWriter and otherwise fills the buffer with ConvertSample.
+func WriteAll(f AudioFormat, samples []float64, w io.Writer) (int64, error) {
+	if batch, ok := f.(BatchWriter); ok {
+		return batch.WriteAll(samples, w)
+	}
+
+	buf := make([]byte, 0, len(samples)*f.BitDepth()/8)
+	for _, sample := range samples {
+		buf = append(buf, f.ConvertSample(sample)...)
+	}
+	return writeBuffer(w, buf)
+}
+
+// writeBuffer writes the encoded samples of a WriteAll call.
+func writeBuffer(w io.Writer, buf []byte) (int64, error) {
+	n, err := w.Write(buf)
+	if err != nil {
+		return int64(n), fmt.Errorf("unable to write data, err: %w", err)
+	}
+	return int64(n), nil
+}
+
+// growBuffer returns buf resized to n bytes, allocating a new slice only
+// when its capacity is too small.
+func growBuffer(buf []byte, n int) []byte {
+	if cap(buf) < n {
+		return make([]byte, n)
+	}
+	return buf[:n]
+}
+
+// checkLength makes sure b holds exactly one sample of format f.
+func checkLength(f AudioFormat, b []byte) error {
+	if len(b) != f.BitDepth()/8 {
+		return ErrWrongLength
+	}
+	return nil
+}
+
+// PCM8 is unsigned 8-bit linear PCM, as found in WAV files, where silence
+// is stored as 128.
+type PCM8 struct{}
+
+func (f PCM8) BitDepth() int {
+	return 8
+}
+
+func (f PCM8) ConvertSample(sample float64) []byte {
+	value := f.Quantize(sample)
+	return f.Encode(value)
+}
+
+// Quantize scale the float64 sample to the int8 range (-127 to 127) and
+// offset it to the unsigned range (1 to 255)
+func (f PCM8) Quantize(sample float64) uint8 {
+	sample = Clamp(sample, -1.0, 1.0)
+	return uint8(int16(sample*127.0) + 128)
+}
+
+func (f PCM8) Encode(value uint8) []byte {
+	return []byte{value}
+}
+
+func (f PCM8) Decode(b []byte) (float64, error) {
+	if err := checkLength(f, b); err != nil {
+		return 0, err
+	}
+	return float64(int16(b[0])-128) / 127.0, nil
+}
+
+// ConvertBatch encodes every sample into buf, reusing its capacity when it
+// is large enough, and returns the filled buffer. It produces the same
+// bytes as calling ConvertSample on each sample in turn.
+func (f PCM8) ConvertBatch(samples []float64, buf []byte) []byte {
+	buf = growBuffer(buf, len(samples))
+	for i, sample := range samples {
+		buf[i] = f.Quantize(sample)
+	}
+	return buf
+}
+
+func (f PCM8) WriteAll(samples []float64, w io.Writer) (int64, error) {
+	return writeBuffer(w, f.ConvertBatch(samples, nil))
+}
+
 type PCM16 struct{}
 
 func (f PCM16) BitDepth() int {
@@ -46,6 +180,27 @@ func (f PCM16) Encode(value int16) []byte {
 	return []byte{byte(value & 0xFF), byte((value >> 8) & 0xFF)}
 }
 
+func (f PCM16) Decode(b []byte) (float64, error) {
+	if err := checkLength(f, b); err != nil {
+		return 0, err
+	}
+	return float64(int16(b[0])|int16(b[1])<<8) / 32767.0, nil
+}
+
+func (f PCM16) ConvertBatch(samples []float64, buf []byte) []byte {
+	buf = growBuffer(buf, 2*len(samples))
+	for i, sample := range samples {
+		value := f.Quantize(sample)
+		buf[2*i] = byte(value)
+		buf[2*i+1] = byte(value >> 8)
+	}
+	return buf
+}
+
+func (f PCM16) WriteAll(samples []float64, w io.Writer) (int64, error) {
+	return writeBuffer(w, f.ConvertBatch(samples, nil))
+}
+
 type PCM32 struct{}
 
 func (f PCM32) BitDepth() int {
@@ -70,6 +225,78 @@ func (f PCM32) Encode(value int32) []byte {
 	}
 }
 
+func (f PCM32) Decode(b []byte) (float64, error) {
+	if err := checkLength(f, b); err != nil {
+		return 0, err
+	}
+	value := int32(b[0]) | int32(b[1])<<8 | int32(b[2])<<16 | int32(b[3])<<24
+	return float64(value) / 2147483647.0, nil
+}
+
+func (f PCM32) ConvertBatch(samples []float64, buf []byte) []byte {
+	buf = growBuffer(buf, 4*len(samples))
+	for i, sample := range samples {
+		value := f.Quantize(sample)
+		buf[4*i] = byte(value)
+		buf[4*i+1] = byte(value >> 8)
+		buf[4*i+2] = byte(value >> 16)
+		buf[4*i+3] = byte(value >> 24)
+	}
+	return buf
+}
+
+func (f PCM32) WriteAll(samples []float64, w io.Writer) (int64, error) {
+	return writeBuffer(w, f.ConvertBatch(samples, nil))
+}
+
+type Float32 struct{}
+
+func (f Float32) BitDepth() int {
+	return 32
+}
+
+func (f Float32) ConvertSample(sample float64) []byte {
+	value := f.Quantize(sample)
+	return f.Encode(value)
+}
+
+// Quantize converts the float64 sample to IEEE 754 single precision binary
+// representation
+func (f Float32) Quantize(sample float64) uint32 {
+	return math.Float32bits(float32(sample))
+}
+
+func (f Float32) Encode(value uint32) []byte {
+	return []byte{
+		byte(value & 0xFF), byte((value >> 8) & 0xFF),
+		byte((value >> 16) & 0xFF), byte((value >> 24) & 0xFF),
+	}
+}
+
+func (f Float32) Decode(b []byte) (float64, error) {
+	if err := checkLength(f, b); err != nil {
+		return 0, err
+	}
+	bits := uint32(b[0]) | uint32(b[1])<<8 | uint32(b[2])<<16 | uint32(b[3])<<24
+	return float64(math.Float32frombits(bits)), nil
+}
+
+func (f Float32) ConvertBatch(samples []float64, buf []byte) []byte {
+	buf = growBuffer(buf, 4*len(samples))
+	for i, sample := range samples {
+		value := f.Quantize(sample)
+		buf[4*i] = byte(value)
+		buf[4*i+1] = byte(value >> 8)
+		buf[4*i+2] = byte(value >> 16)
+		buf[4*i+3] = byte(value >> 24)
+	}
+	return buf
+}
+
+func (f Float32) WriteAll(samples []float64, w io.Writer) (int64, error) {
+	return writeBuffer(w, f.ConvertBatch(samples, nil))
+}
+
 type Float64 struct{}
 
 func (f Float64) BitDepth() int {
@@ -95,8 +322,34 @@ func (f Float64) Encode(value uint64) []byte {
 	}
 }
 
+func (f Float64) Decode(b []byte) (float64, error) {
+	if err := checkLength(f, b); err != nil {
+		return 0, err
+	}
+	bits := uint64(b[0]) | uint64(b[1])<<8 | uint64(b[2])<<16 | uint64(b[3])<<24 |
+		uint64(b[4])<<32 | uint64(b[5])<<40 | uint64(b[6])<<48 | uint64(b[7])<<56
+	return math.Float64frombits(bits), nil
+}
+
+func (f Float64) ConvertBatch(samples []float64, buf []byte) []byte {
+	buf = growBuffer(buf, 8*len(samples))
+	for i, sample := range samples {
+		value := f.Quantize(sample)
+		for b := range 8 {
+			buf[8*i+b] = byte(value >> (8 * b))
+		}
+	}
+	return buf
+}
+
+func (f Float64) WriteAll(samples []float64, w io.Writer) (int64, error) {
+	return writeBuffer(w, f.ConvertBatch(samples, nil))
+}
+
 var (
+	_ AudioFormat = new(PCM8)
 	_ AudioFormat = new(PCM16)
 	_ AudioFormat = new(PCM32)
+	_ AudioFormat = new(Float32)
 	_ AudioFormat = new(Float64)
 )
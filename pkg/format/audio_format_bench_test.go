@@ -1,6 +1,7 @@
 package format
 
 import (
+	"bytes"
 	"math"
 	"testing"
 )
@@ -136,3 +137,95 @@ func BenchmarkBatchConversion(b *testing.B) {
 		b.ReportMetric(float64(numSamples*b.N)/b.Elapsed().Seconds(), "samples/sec")
 	})
 }
+
+// benchmarkConvertBatch compares ConvertBatch on 1000 samples with a loop
+// of ConvertSample appending to a buffer, reporting the throughput of each.
+func benchmarkConvertBatch(b *testing.B, f batchConverter) {
+	samples := make([]float64, 1000)
+	for i := range samples {
+		samples[i] = math.Sin(2 * math.Pi * float64(i) / 100)
+	}
+	sampleSize := f.BitDepth() / 8
+
+	report := func(b *testing.B) {
+		seconds := b.Elapsed().Seconds()
+		b.ReportMetric(float64(b.N*len(samples))/seconds, "samples/s")
+		b.ReportMetric(float64(b.N*len(samples)*sampleSize)/seconds, "bytes/s")
+	}
+
+	b.Run("ConvertBatch", func(b *testing.B) {
+		buf := make([]byte, len(samples)*sampleSize)
+		for b.Loop() {
+			buf = f.ConvertBatch(samples, buf)
+		}
+		report(b)
+	})
+
+	b.Run("ConvertSample", func(b *testing.B) {
+		buf := make([]byte, 0, len(samples)*sampleSize)
+		for b.Loop() {
+			buf = buf[:0]
+			for _, sample := range samples {
+				buf = append(buf, f.ConvertSample(sample)...)
+			}
+		}
+		report(b)
+	})
+}
+
+func BenchmarkPCM16_ConvertBatch_1000samples(b *testing.B) {
+	benchmarkConvertBatch(b, PCM16{})
+}
+
+func BenchmarkPCM32_ConvertBatch_1000samples(b *testing.B) {
+	benchmarkConvertBatch(b, PCM32{})
+}
+
+func BenchmarkFloat64_ConvertBatch_1000samples(b *testing.B) {
+	benchmarkConvertBatch(b, Float64{})
+}
+
+// BenchmarkBatchConversion_Preallocated checks that converting into a
+// buffer of sufficient capacity does not allocate.
+func BenchmarkBatchConversion_Preallocated(b *testing.B) {
+	samples := make([]float64, 1000)
+	buf := make([]byte, 0, 2*len(samples))
+	f := PCM16{}
+
+	b.ReportAllocs()
+	for b.Loop() {
+		buf = f.ConvertBatch(samples, buf)
+	}
+}
+
+// BenchmarkPCM16_WriteAll compares WriteAll with the per-sample loop of
+// ConvertSample and Write it replaced, on one second of samples.
+func BenchmarkPCM16_WriteAll(b *testing.B) {
+	samples := make([]float64, 44100)
+	for i := range samples {
+		samples[i] = math.Sin(2 * math.Pi * 440 * float64(i) / 44100)
+	}
+	f := PCM16{}
+
+	b.Run("WriteAll", func(b *testing.B) {
+		var buf bytes.Buffer
+		for b.Loop() {
+			buf.Reset()
+			if _, err := WriteAll(f, samples, &buf); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("PerSample", func(b *testing.B) {
+		var buf bytes.Buffer
+		for b.Loop() {
+			buf.Reset()
+			for _, sample := range samples {
+				if _, err := buf.Write(f.ConvertSample(sample)); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	})
+}
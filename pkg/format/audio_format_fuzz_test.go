@@ -182,8 +182,10 @@ func FuzzAllFormats_ConsistentBehavior(f *testing.F) {
 		name         string
 		expectedSize int
 	}{
+		{PCM8{}, "PCM8", 1},
 		{PCM16{}, "PCM16", 2},
 		{PCM32{}, "PCM32", 4},
+		{Float32{}, "Float32", 4},
 		{Float64{}, "Float64", 8},
 	}
 
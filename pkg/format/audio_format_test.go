@@ -1,6 +1,9 @@
 package format
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
 	"math"
 	"testing"
 
@@ -339,8 +342,10 @@ func TestAllFormats_ConsistentBehavior(t *testing.T) {
 		format AudioFormat
 		name   string
 	}{
+		{PCM8{}, "PCM8"},
 		{PCM16{}, "PCM16"},
 		{PCM32{}, "PCM32"},
+		{Float32{}, "Float32"},
 		{Float64{}, "Float64"},
 	}
 
@@ -358,3 +363,160 @@ func TestAllFormats_ConsistentBehavior(t *testing.T) {
 		})
 	}
 }
+
+// TestPCM8_ConvertSample tests unsigned PCM8 sample conversion
+func TestPCM8_ConvertSample(t *testing.T) {
+	format := PCM8{}
+	require.Equal(t, 8, format.BitDepth())
+
+	tests := []struct {
+		name     string
+		expected []byte
+		input    float64
+	}{
+		{name: "zero value", input: 0.0, expected: []byte{0x80}},
+		{name: "maximum positive value", input: 1.0, expected: []byte{0xFF}},
+		{name: "maximum negative value", input: -1.0, expected: []byte{0x01}},
+		{name: "clamped above", input: 2.0, expected: []byte{0xFF}},
+		{name: "clamped below", input: -2.0, expected: []byte{0x01}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, format.ConvertSample(tt.input))
+		})
+	}
+}
+
+// TestDecode_RoundTrip verifies Decode(Encode(Quantize(x))) stays within one
+// LSB of the original sample for every format
+func TestDecode_RoundTrip(t *testing.T) {
+	// Same inputs as TestPCM16_ConvertSample
+	inputs := []float64{0.0, 1.0, -1.0, 0.5, -0.5}
+
+	formats := []struct {
+		format AudioFormat
+		name   string
+		lsb    float64
+	}{
+		{PCM8{}, "PCM8", 1.0 / 127.0},
+		{PCM16{}, "PCM16", 1.0 / 32767.0},
+		{PCM32{}, "PCM32", 1.0 / 2147483647.0},
+		{Float32{}, "Float32", 1e-7},
+		{Float64{}, "Float64", 0},
+	}
+
+	for _, f := range formats {
+		for _, input := range inputs {
+			t.Run(fmt.Sprintf("%s/%g", f.name, input), func(t *testing.T) {
+				decoded, err := f.format.(Decoder).Decode(f.format.ConvertSample(input))
+				require.NoError(t, err)
+				require.InDelta(t, input, decoded, f.lsb, "round-trip of %f drifted by more than one LSB", input)
+			})
+		}
+	}
+}
+
+// TestPCM16_Decode verifies PCM16 decoding of known byte patterns
+func TestPCM16_Decode(t *testing.T) {
+	format := PCM16{}
+
+	tests := []struct {
+		name     string
+		input    []byte
+		expected float64
+	}{
+		{name: "zero value", input: []byte{0x00, 0x00}, expected: 0.0},
+		{name: "maximum positive value", input: []byte{0xFF, 0x7F}, expected: 1.0},
+		{name: "maximum negative value", input: []byte{0x01, 0x80}, expected: -1.0},
+		{name: "most negative code", input: []byte{0x00, 0x80}, expected: -32768.0 / 32767.0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := format.Decode(tt.input)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+// TestDecode_WrongLength verifies every format rejects badly sized input
+func TestDecode_WrongLength(t *testing.T) {
+	formats := []interface {
+		AudioFormat
+		Decoder
+	}{PCM8{}, PCM16{}, PCM32{}, Float32{}, Float64{}}
+
+	for _, f := range formats {
+		_, err := f.Decode(nil)
+		require.ErrorIs(t, err, ErrWrongLength)
+
+		_, err = f.Decode(make([]byte, f.BitDepth()/8+1))
+		require.ErrorIs(t, err, ErrWrongLength)
+	}
+}
+
+// encodeOnly implements AudioFormat without Decoder or BatchWriter, like
+// formats written before they existed.
+type encodeOnly struct{}
+
+func (encodeOnly) BitDepth() int {
+	return 16
+}
+
+func (encodeOnly) ConvertSample(sample float64) []byte {
+	return PCM16{}.ConvertSample(sample)
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("write failed")
+}
+
+func TestWriteAll_MatchesConvertSample(t *testing.T) {
+	samples := []float64{0, 0.5, -0.5, 1, -1, 1.5, -2, 0.123456789, math.SmallestNonzeroFloat64}
+	formats := []AudioFormat{PCM8{}, PCM16{}, PCM32{}, Float32{}, Float64{}, encodeOnly{}}
+
+	for _, f := range formats {
+		var expected []byte
+		for _, sample := range samples {
+			expected = append(expected, f.ConvertSample(sample)...)
+		}
+
+		var buf bytes.Buffer
+		n, err := WriteAll(f, samples, &buf)
+		require.NoError(t, err)
+		require.Equal(t, int64(len(expected)), n, "%T", f)
+		require.Equal(t, expected, buf.Bytes(), "%T", f)
+
+		_, err = WriteAll(f, samples, failingWriter{})
+		require.Error(t, err)
+	}
+}
+
+// batchConverter is implemented by the formats offering ConvertBatch.
+type batchConverter interface {
+	AudioFormat
+	ConvertBatch(samples []float64, buf []byte) []byte
+}
+
+func TestConvertBatch_MatchesConvertSample(t *testing.T) {
+	samples := []float64{0, 0.5, -0.5, 1, -1, 1.5, -2, 0.123456789}
+	formats := []batchConverter{PCM8{}, PCM16{}, PCM32{}, Float32{}, Float64{}}
+
+	for _, f := range formats {
+		var expected []byte
+		for _, sample := range samples {
+			expected = append(expected, f.ConvertSample(sample)...)
+		}
+		require.Equal(t, expected, f.ConvertBatch(samples, nil), "%T", f)
+
+		// A large enough buffer is reused rather than reallocated.
+		buf := make([]byte, 0, 1024)
+		out := f.ConvertBatch(samples, buf)
+		require.Equal(t, expected, out, "%T", f)
+		require.Same(t, &buf[:1][0], &out[0], "%T", f)
+	}
+}
@@ -0,0 +1,64 @@
+package format
+
+import "fmt"
+
+// CrossfadeFormat returns a format that moves gradually from a (blend=0) to
+// b (blend=1). blend is clamped to [0, 1] and the end points return a and b
+// themselves.
+//
+// In between, samples are encoded with the higher bit-depth format after
+// adding the quantization error of the lower one, scaled by the weight the
+// lower format still has in the blend:
+//
+//	y = x + w·(low(x) - x)
+//
+// where low(x) is x quantized and decoded by the lower bit-depth format.
+// When the lower format does not implement Decoder its quantization error
+// is unknown and the higher format is used alone. The result implements
+// Decoder when the higher format does.
+func CrossfadeFormat(a, b AudioFormat, blend float64) AudioFormat {
+	blend = Clamp(blend, 0, 1)
+	switch blend {
+	case 0:
+		return a
+	case 1:
+		return b
+	}
+
+	if a.BitDepth() >= b.BitDepth() {
+		return crossfade{high: a, low: b, lowWeight: blend}
+	}
+	return crossfade{high: b, low: a, lowWeight: 1 - blend}
+}
+
+// crossfade is the AudioFormat built by CrossfadeFormat for blends strictly
+// between 0 and 1.
+type crossfade struct {
+	high      AudioFormat
+	low       AudioFormat
+	lowWeight float64
+}
+
+func (f crossfade) BitDepth() int {
+	return f.high.BitDepth()
+}
+
+func (f crossfade) ConvertSample(sample float64) []byte {
+	sample = Clamp(sample, -1.0, 1.0)
+	decoder, ok := f.low.(Decoder)
+	if !ok {
+		return f.high.ConvertSample(sample)
+	}
+	// ConvertSample always produces one full sample, so decoding it back
+	// cannot fail on length.
+	quantized, _ := decoder.Decode(f.low.ConvertSample(sample))
+	return f.high.ConvertSample(sample + f.lowWeight*(quantized-sample))
+}
+
+func (f crossfade) Decode(b []byte) (float64, error) {
+	decoder, ok := f.high.(Decoder)
+	if !ok {
+		return 0, fmt.Errorf("%w: %T", ErrNotDecoder, f.high)
+	}
+	return decoder.Decode(b)
+}
@@ -0,0 +1,95 @@
+package format
+
+import (
+	"bytes"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCrossfadeFormat_EndPoints(t *testing.T) {
+	a, b := PCM16{}, PCM32{}
+	inputs := []float64{-1.0, -0.3337, 0, 0.00001, 0.5, 0.98765, 1.0}
+
+	for _, x := range inputs {
+		require.Equal(t, a.Encode(a.Quantize(x)), CrossfadeFormat(a, b, 0).ConvertSample(x))
+		require.Equal(t, b.Encode(b.Quantize(x)), CrossfadeFormat(a, b, 1).ConvertSample(x))
+	}
+
+	require.Equal(t, a, CrossfadeFormat(a, b, -0.5))
+	require.Equal(t, b, CrossfadeFormat(a, b, 1.5))
+}
+
+func TestCrossfadeFormat_Blend(t *testing.T) {
+	x := 0.123456789
+	low, err := PCM8{}.Decode(PCM8{}.ConvertSample(x))
+	require.NoError(t, err)
+	lowError := low - x
+
+	tests := []struct {
+		name     string
+		a, b     AudioFormat
+		blend    float64
+		expected float64
+	}{
+		{name: "low to high quarter", a: PCM8{}, b: Float64{}, blend: 0.25, expected: x + 0.75*lowError},
+		{name: "low to high half", a: PCM8{}, b: Float64{}, blend: 0.5, expected: x + 0.5*lowError},
+		{name: "high to low quarter", a: Float64{}, b: PCM8{}, blend: 0.25, expected: x + 0.25*lowError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := CrossfadeFormat(tt.a, tt.b, tt.blend)
+			require.Equal(t, 64, f.BitDepth(), "should encode with the higher bit depth")
+
+			decoded, err := f.(Decoder).Decode(f.ConvertSample(x))
+			require.NoError(t, err)
+			require.InDelta(t, tt.expected, decoded, 1e-12)
+		})
+	}
+}
+
+func TestCrossfadeFormat_ErrorShrinks(t *testing.T) {
+	a, b := PCM8{}, PCM32{}
+	x := math.Pi / 10
+
+	previous := math.Inf(1)
+	for _, blend := range []float64{0.1, 0.4, 0.7, 0.9} {
+		f := CrossfadeFormat(a, b, blend)
+		decoded, err := f.(Decoder).Decode(f.ConvertSample(x))
+		require.NoError(t, err)
+
+		diff := math.Abs(decoded - x)
+		require.Less(t, diff, previous, "blend %v", blend)
+		previous = diff
+	}
+}
+
+func TestCrossfadeFormat_WriteAll(t *testing.T) {
+	f := CrossfadeFormat(PCM16{}, PCM32{}, 0.5)
+	samples := []float64{-0.9, -0.1, 0, 0.2, 0.7}
+
+	var expected []byte
+	for _, s := range samples {
+		expected = append(expected, f.ConvertSample(s)...)
+	}
+
+	var buf bytes.Buffer
+	n, err := WriteAll(f, samples, &buf)
+	require.NoError(t, err)
+	require.Equal(t, int64(len(expected)), n)
+	require.Equal(t, expected, buf.Bytes())
+}
+
+func TestCrossfadeFormat_NotDecoder(t *testing.T) {
+	x := 0.123456789
+
+	// Without a decodable lower format the higher one is used alone.
+	f := CrossfadeFormat(encodeOnly{}, Float64{}, 0.5)
+	require.Equal(t, Float64{}.ConvertSample(x), f.ConvertSample(x))
+
+	f = CrossfadeFormat(PCM8{}, encodeOnly{}, 0.5)
+	_, err := f.(Decoder).Decode(f.ConvertSample(x))
+	require.ErrorIs(t, err, ErrNotDecoder)
+}
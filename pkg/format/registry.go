@@ -0,0 +1,84 @@
+package format
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// registry maps format names to AudioFormat implementations so that a
+// format can be referenced by name, e.g. in serialized configurations.
+var registry = struct {
+	byName map[string]AudioFormat
+	byType map[reflect.Type]string
+	sync.RWMutex
+}{
+	byName: map[string]AudioFormat{},
+	byType: map[reflect.Type]string{},
+}
+
+func init() {
+	RegisterFormat("PCM8", PCM8{})
+	RegisterFormat("PCM16", PCM16{})
+	RegisterFormat("PCM32", PCM32{})
+	RegisterFormat("Float32", Float32{})
+	RegisterFormat("Float64", Float64{})
+}
+
+// RegisterFormat makes f available under name. Registering a name twice
+// replaces the previous format.
+func RegisterFormat(name string, f AudioFormat) {
+	registry.Lock()
+	defer registry.Unlock()
+
+	if previous, ok := registry.byName[name]; ok {
+		delete(registry.byType, reflect.TypeOf(previous))
+	}
+
+	registry.byName[name] = f
+	registry.byType[reflect.TypeOf(f)] = name
+}
+
+// Lookup returns the format registered under name.
+func Lookup(name string) (AudioFormat, error) {
+	registry.RLock()
+	defer registry.RUnlock()
+
+	f, ok := registry.byName[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown audio format %q", name)
+	}
+	return f, nil
+}
+
+// NameOf returns the name f was registered under.
+func NameOf(f AudioFormat) (string, error) {
+	registry.RLock()
+	defer registry.RUnlock()
+
+	name, ok := registry.byType[reflect.TypeOf(f)]
+	if !ok {
+		return "", fmt.Errorf("audio format %T is not registered", f)
+	}
+	return name, nil
+}
+
+// FormatName returns the name of the built-in format f, as registered by
+// default, or "custom" for any other implementation. Unlike NameOf it does
+// not consult the registry, so it never fails.
+func FormatName(f AudioFormat) string {
+	switch f.(type) {
+	case PCM8:
+		return "PCM8"
+	case PCM16:
+		return "PCM16"
+	case PCM32:
+		return "PCM32"
+	case Float32:
+		return "Float32"
+	case Float64:
+		return "Float64"
+	default:
+		return "custom"
+	}
+}
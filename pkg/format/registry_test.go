@@ -0,0 +1,68 @@
+package format
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type customFormat struct{ PCM16 }
+
+func TestRegistry_BuiltinFormats(t *testing.T) {
+	formats := map[string]AudioFormat{
+		"PCM8":    PCM8{},
+		"PCM16":   PCM16{},
+		"PCM32":   PCM32{},
+		"Float32": Float32{},
+		"Float64": Float64{},
+	}
+
+	for name, expected := range formats {
+		t.Run(name, func(t *testing.T) {
+			f, err := Lookup(name)
+			require.NoError(t, err)
+			require.Equal(t, expected, f)
+
+			got, err := NameOf(expected)
+			require.NoError(t, err)
+			require.Equal(t, name, got)
+		})
+	}
+}
+
+func TestRegistry_CustomFormat(t *testing.T) {
+	_, err := NameOf(customFormat{})
+	require.Error(t, err)
+
+	RegisterFormat("Custom", customFormat{})
+
+	f, err := Lookup("Custom")
+	require.NoError(t, err)
+	require.Equal(t, customFormat{}, f)
+
+	name, err := NameOf(customFormat{})
+	require.NoError(t, err)
+	require.Equal(t, "Custom", name)
+}
+
+func TestRegistry_UnknownName(t *testing.T) {
+	_, err := Lookup("PCM24")
+	require.Error(t, err)
+}
+
+func TestFormatName(t *testing.T) {
+	for name, f := range map[string]AudioFormat{
+		"PCM8":    PCM8{},
+		"PCM16":   PCM16{},
+		"PCM32":   PCM32{},
+		"Float32": Float32{},
+		"Float64": Float64{},
+	} {
+		require.Equal(t, name, FormatName(f))
+	}
+
+	// Embedding a built-in format does not make it one, even once
+	// registered.
+	require.Equal(t, "custom", FormatName(customFormat{}))
+	require.Equal(t, "custom", FormatName(nil))
+}
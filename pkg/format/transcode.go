@@ -0,0 +1,34 @@
+package format
+
+import (
+	"fmt"
+)
+
+// Transcode converts raw samples encoded with from into the to format.
+// Every sample is decoded to float64 then quantized and encoded again, so
+// converting to a smaller bit depth loses precision. from must implement
+// Decoder.
+func Transcode(input []byte, from, to AudioFormat) ([]byte, error) {
+	decoder, ok := from.(Decoder)
+	if !ok {
+		return nil, fmt.Errorf("%w: %T", ErrNotDecoder, from)
+	}
+
+	inSize := from.BitDepth() / 8
+	if inSize == 0 || len(input)%inSize != 0 {
+		return nil, ErrWrongLength
+	}
+
+	totalSamples := len(input) / inSize
+	result := make([]byte, 0, totalSamples*to.BitDepth()/8)
+
+	for i := range totalSamples {
+		sample, err := decoder.Decode(input[i*inSize : (i+1)*inSize])
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode sample %d, err: %w", i, err)
+		}
+		result = append(result, to.ConvertSample(sample)...)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,36 @@
+package format
+
+import (
+	"testing"
+)
+
+// BenchmarkTranscode_PCM16ToPCM32 compares Transcode with a naive loop
+// growing the output one sample at a time
+func BenchmarkTranscode_PCM16ToPCM32(b *testing.B) {
+	input := encodeSine(PCM16{}, 44100, 0.8)
+
+	b.Run("Transcode", func(b *testing.B) {
+		b.SetBytes(int64(len(input)))
+		for b.Loop() {
+			if _, err := Transcode(input, PCM16{}, PCM32{}); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("NaiveLoop", func(b *testing.B) {
+		from, to := PCM16{}, PCM32{}
+		b.SetBytes(int64(len(input)))
+		for b.Loop() {
+			var result []byte
+			for i := 0; i < len(input); i += 2 {
+				sample, err := from.Decode(input[i : i+2])
+				if err != nil {
+					b.Fatal(err)
+				}
+				result = append(result, to.Encode(to.Quantize(sample))...)
+			}
+			_ = result
+		}
+	})
+}
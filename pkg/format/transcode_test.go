@@ -0,0 +1,90 @@
+package format
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// encodeSine returns n samples of a sine encoded with f.
+func encodeSine(f AudioFormat, n int, amplitude float64) []byte {
+	result := make([]byte, 0, n*f.BitDepth()/8)
+	for i := range n {
+		result = append(result, f.ConvertSample(amplitude*math.Sin(2*math.Pi*440*float64(i)/44100))...)
+	}
+	return result
+}
+
+// decodedRMS decodes every sample of data and returns their RMS level.
+func decodedRMS(t *testing.T, f AudioFormat, data []byte) float64 {
+	t.Helper()
+
+	size := f.BitDepth() / 8
+	sum := 0.0
+	for i := 0; i < len(data); i += size {
+		v, err := f.(Decoder).Decode(data[i : i+size])
+		require.NoError(t, err)
+		sum += v * v
+	}
+	return math.Sqrt(sum / float64(len(data)/size))
+}
+
+func TestTranscode_EnergyConservation(t *testing.T) {
+	tests := []struct {
+		from      AudioFormat
+		to        AudioFormat
+		name      string
+		tolerance float64
+	}{
+		{name: "PCM16_to_PCM32", from: PCM16{}, to: PCM32{}, tolerance: 1e-6},
+		{name: "PCM32_to_PCM16", from: PCM32{}, to: PCM16{}, tolerance: 1e-4},
+		{name: "PCM16_to_Float32", from: PCM16{}, to: Float32{}, tolerance: 1e-6},
+		{name: "Float64_to_PCM8", from: Float64{}, to: PCM8{}, tolerance: 1e-2},
+		{name: "PCM8_to_Float64", from: PCM8{}, to: Float64{}, tolerance: 1e-9},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			original := encodeSine(tt.from, 4410, 0.8)
+
+			transcoded, err := Transcode(original, tt.from, tt.to)
+			require.NoError(t, err)
+			require.Len(t, transcoded, 4410*tt.to.BitDepth()/8)
+
+			require.InDelta(t, decodedRMS(t, tt.from, original), decodedRMS(t, tt.to, transcoded), tt.tolerance)
+		})
+	}
+}
+
+func TestTranscode_RoundTrip(t *testing.T) {
+	original := encodeSine(PCM16{}, 1000, 1.0)
+
+	wide, err := Transcode(original, PCM16{}, PCM32{})
+	require.NoError(t, err)
+
+	back, err := Transcode(wide, PCM32{}, PCM16{})
+	require.NoError(t, err)
+	require.Len(t, back, len(original))
+
+	// Quantize truncates, so a round trip may drop by at most one LSB.
+	for i := 0; i < len(original); i += 2 {
+		want := int16(original[i]) | int16(original[i+1])<<8
+		got := int16(back[i]) | int16(back[i+1])<<8
+		require.InDelta(t, want, got, 1, "sample %d drifted by more than one LSB", i/2)
+	}
+}
+
+func TestTranscode_WrongLength(t *testing.T) {
+	_, err := Transcode([]byte{0x00, 0x01, 0x02}, PCM16{}, PCM32{})
+	require.ErrorIs(t, err, ErrWrongLength)
+
+	result, err := Transcode(nil, PCM16{}, PCM32{})
+	require.NoError(t, err)
+	require.Empty(t, result)
+}
+
+func TestTranscode_NotDecoder(t *testing.T) {
+	_, err := Transcode(make([]byte, 4), encodeOnly{}, PCM16{})
+	require.ErrorIs(t, err, ErrNotDecoder)
+}
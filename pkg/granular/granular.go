@@ -0,0 +1,83 @@
+package granular
+
+import (
+	"errors"
+	"math"
+	"math/rand/v2"
+	"time"
+)
+
+var (
+	// ErrEmptySource is returned when there is no audio to read grains from.
+	ErrEmptySource = errors.New("granular source is empty")
+	// ErrInvalidParameter is returned when the sample rate, grain rate or
+	// grain duration is not positive.
+	ErrInvalidParameter = errors.New("granular parameters must be positive")
+)
+
+// scatterSeed keeps the grain positions reproducible between runs.
+const scatterSeed = 0x6a41
+
+// Generate synthesizes duration of audio. Grains are started every
+// 1/GrainRate seconds; each one reads GrainDuration of Source at its
+// original pitch from a position that advances Speed times faster than the
+// output, optionally jittered by ±Scatter seconds. Grains are shaped by a
+// Hann window and overlap-added. Reads past the end of Source wrap around.
+func (g Granular) Generate(duration time.Duration) ([]float64, error) {
+	if len(g.Source) == 0 {
+		return nil, ErrEmptySource
+	}
+	if g.SampleRate <= 0 || g.GrainRate <= 0 || g.GrainDuration <= 0 {
+		return nil, ErrInvalidParameter
+	}
+
+	totalSamples := int(g.SampleRate * duration.Seconds())
+	result := make([]float64, totalSamples)
+
+	grainLength := max(int(g.SampleRate*g.GrainDuration.Seconds()), 1)
+	window := hann(grainLength)
+	hop := g.SampleRate / g.GrainRate
+
+	// Overlapping Hann windows add up to GrainRate*GrainDuration/2 on
+	// average, compensate so dense clouds keep the source level.
+	gain := 1 / max(g.GrainRate*g.GrainDuration.Seconds()/2, 1)
+
+	rng := rand.New(rand.NewPCG(scatterSeed, scatterSeed))
+
+	for grain := 0; ; grain++ {
+		start := int(math.Round(float64(grain) * hop))
+		if start >= totalSamples {
+			break
+		}
+
+		position := float64(start) * g.Speed
+		if g.Scatter != 0 {
+			position += (rng.Float64()*2 - 1) * g.Scatter * g.SampleRate
+		}
+		readIndex := int(math.Round(position))
+
+		for i := range grainLength {
+			if start+i >= totalSamples {
+				break
+			}
+			result[start+i] += gain * window[i] * g.sourceAt(readIndex+i)
+		}
+	}
+
+	return result, nil
+}
+
+// sourceAt returns the source sample at index, wrapping around both ends.
+func (g Granular) sourceAt(index int) float64 {
+	n := len(g.Source)
+	return g.Source[((index%n)+n)%n]
+}
+
+// hann returns a periodic Hann window of the given length.
+func hann(length int) []float64 {
+	window := make([]float64, length)
+	for i := range window {
+		window[i] = 0.5 * (1 - math.Cos(2*math.Pi*float64(i)/float64(length)))
+	}
+	return window
+}
@@ -0,0 +1,107 @@
+package granular
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// twoToneSource returns one second of 440 Hz followed by one second of
+// 880 Hz.
+func twoToneSource(sampleRate float64) []float64 {
+	samples := make([]float64, int(2*sampleRate))
+	for i := range samples {
+		freq := 440.0
+		if i >= int(sampleRate) {
+			freq = 880.0
+		}
+		samples[i] = math.Sin(2 * math.Pi * freq * float64(i) / sampleRate)
+	}
+	return samples
+}
+
+// power returns the energy of samples at freq using a single DFT bin.
+func power(samples []float64, freq, sampleRate float64) float64 {
+	var re, im float64
+	for n, v := range samples {
+		angle := 2 * math.Pi * freq * float64(n) / sampleRate
+		re += v * math.Cos(angle)
+		im -= v * math.Sin(angle)
+	}
+	return re*re + im*im
+}
+
+func TestGenerate_Length(t *testing.T) {
+	g := NewGranular(twoToneSource(8000), 8000)
+
+	samples, err := g.Generate(1500 * time.Millisecond)
+	require.NoError(t, err)
+	require.Len(t, samples, 12000)
+}
+
+func TestGenerate_DeterministicWithoutScatter(t *testing.T) {
+	g := NewGranular(twoToneSource(8000), 8000, WithScatter(0))
+
+	first, err := g.Generate(time.Second)
+	require.NoError(t, err)
+	second, err := g.Generate(time.Second)
+	require.NoError(t, err)
+	require.Equal(t, first, second)
+
+	// With Speed=1, no scatter and 50% overlapping Hann windows the grains
+	// reconstruct the source exactly once the cloud is fully overlapped.
+	g = NewGranular(twoToneSource(8000), 8000, WithGrainDuration(50*time.Millisecond), WithGrainRate(40))
+	samples, err := g.Generate(time.Second)
+	require.NoError(t, err)
+	for i := 400; i < len(samples); i++ {
+		require.InDelta(t, g.Source[i], samples[i], 1e-9, "sample %d", i)
+	}
+}
+
+func TestGenerate_ScatterIsReproducible(t *testing.T) {
+	g := NewGranular(twoToneSource(8000), 8000, WithScatter(0.02))
+
+	first, err := g.Generate(time.Second)
+	require.NoError(t, err)
+	second, err := g.Generate(time.Second)
+	require.NoError(t, err)
+	require.Equal(t, first, second)
+
+	plain, err := NewGranular(twoToneSource(8000), 8000).Generate(time.Second)
+	require.NoError(t, err)
+	require.NotEqual(t, plain, first, "scatter should move the grains")
+}
+
+func TestGenerate_SpeedKeepsPitch(t *testing.T) {
+	sampleRate := 8000.0
+	g := NewGranular(twoToneSource(sampleRate), sampleRate, WithSpeed(2.0))
+
+	// Two seconds of source played twice as fast take one second.
+	samples, err := g.Generate(time.Second)
+	require.NoError(t, err)
+
+	firstHalf := samples[400:3600]
+	secondHalf := samples[4400:7600]
+
+	// The 440 Hz tone now lasts half a second but keeps its pitch.
+	require.Greater(t, power(firstHalf, 440, sampleRate), 100*power(firstHalf, 880, sampleRate))
+	require.Greater(t, power(firstHalf, 440, sampleRate), 100*power(firstHalf, 220, sampleRate))
+	require.Greater(t, power(secondHalf, 880, sampleRate), 100*power(secondHalf, 440, sampleRate))
+	require.Greater(t, power(secondHalf, 880, sampleRate), 100*power(secondHalf, 1760, sampleRate))
+}
+
+func TestGenerate_Errors(t *testing.T) {
+	_, err := NewGranular(nil, 44100).Generate(time.Second)
+	require.ErrorIs(t, err, ErrEmptySource)
+
+	_, err = NewGranular([]float64{1}, 0).Generate(time.Second)
+	require.ErrorIs(t, err, ErrInvalidParameter)
+
+	_, err = NewGranular([]float64{1}, 44100, WithGrainRate(0)).Generate(time.Second)
+	require.ErrorIs(t, err, ErrInvalidParameter)
+
+	_, err = NewGranular([]float64{1}, 44100, WithGrainDuration(0)).Generate(time.Second)
+	require.ErrorIs(t, err, ErrInvalidParameter)
+}
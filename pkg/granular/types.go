@@ -0,0 +1,57 @@
+package granular
+
+import (
+	"time"
+)
+
+type Granular struct {
+	Source        []float64     // Audio the grains are read from
+	GrainDuration time.Duration // Length of a single grain
+	GrainRate     float64       // Number of grains started per second
+	Scatter       float64       // Random read position offset in seconds (±Scatter)
+	Speed         float64       // Rate at which the read position moves through Source
+	SampleRate    float64       // Sampling frequency in Hz of Source and of the output
+}
+
+type Option func(*Granular)
+
+func NewGranular(source []float64, sampleRate float64, options ...Option) *Granular {
+	granular := &Granular{
+		Source:        source,
+		GrainDuration: 50 * time.Millisecond,
+		GrainRate:     40.0,
+		Scatter:       0.0,
+		Speed:         1.0,
+		SampleRate:    sampleRate,
+	}
+
+	for _, opt := range options {
+		opt(granular)
+	}
+
+	return granular
+}
+
+func WithGrainDuration(duration time.Duration) Option {
+	return func(g *Granular) {
+		g.GrainDuration = duration
+	}
+}
+
+func WithGrainRate(rate float64) Option {
+	return func(g *Granular) {
+		g.GrainRate = rate
+	}
+}
+
+func WithScatter(scatter float64) Option {
+	return func(g *Granular) {
+		g.Scatter = scatter
+	}
+}
+
+func WithSpeed(speed float64) Option {
+	return func(g *Granular) {
+		g.Speed = speed
+	}
+}
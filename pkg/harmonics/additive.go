@@ -0,0 +1,102 @@
+package harmonics
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"time"
+
+	"github.com/ECecillo/lib.go.sound/pkg/sine"
+)
+
+// NewSquareFromSeries approximates a square wave with its Fourier series:
+// partial k has a frequency of (2k-1)*f and an amplitude of 4/(π*(2k-1)).
+// The series stops after numHarmonics partials or at the Nyquist limit.
+func NewSquareFromSeries(frequency float64, numHarmonics int, duration time.Duration, options ...Option) *Additive {
+	additive := NewAdditive(nil, duration, options...)
+
+	for k := 1; k <= numHarmonics; k++ {
+		n := float64(2*k - 1)
+		if !additive.add(n*frequency, 4/(math.Pi*n)) {
+			break
+		}
+	}
+
+	return additive
+}
+
+// NewSawFromSeries approximates a sawtooth wave with its Fourier series:
+// partial k has a frequency of k*f and an amplitude of 2/π * (-1)^(k+1) / k.
+// The series stops after numHarmonics partials or at the Nyquist limit.
+func NewSawFromSeries(frequency float64, numHarmonics int, duration time.Duration, options ...Option) *Additive {
+	additive := NewAdditive(nil, duration, options...)
+
+	sign := 1.0
+	for k := 1; k <= numHarmonics; k++ {
+		n := float64(k)
+		if !additive.add(n*frequency, sign*2/(math.Pi*n)) {
+			break
+		}
+		sign = -sign
+	}
+
+	return additive
+}
+
+// add appends a partial unless its frequency reaches the Nyquist limit, in
+// which case it reports false.
+func (a *Additive) add(frequency, amplitude float64) bool {
+	if frequency >= a.SamplingRate/2 {
+		return false
+	}
+	a.Partials = append(a.Partials, Partial{Frequency: frequency, Amplitude: amplitude})
+	return true
+}
+
+// Generate sums one sine per partial.
+func (a Additive) Generate() ([]float64, error) {
+	totalSamples := int(a.SamplingRate * a.Duration.Seconds())
+	result := make([]float64, totalSamples)
+
+	for _, p := range a.Partials {
+		partial := sine.NewSine(
+			p.Frequency,
+			a.Duration,
+			sine.WithAmplitude(a.Amplitude*p.Amplitude),
+			sine.WithSampleRate(a.SamplingRate),
+		)
+
+		samples, err := partial.Generate()
+		if err != nil {
+			return nil, fmt.Errorf("unable to generate partial at %f Hz, err: %w", p.Frequency, err)
+		}
+
+		for i := range result {
+			result[i] += samples[i]
+		}
+	}
+
+	return result, nil
+}
+
+// WriteTo will generate samples and write them to the given Writer.
+func (a Additive) WriteTo(w io.Writer) (int64, error) {
+	samples, err := a.Generate()
+	if err != nil {
+		return 0, fmt.Errorf("unable to generate samples, err: %w", err)
+	}
+
+	var totalBytesWritten int64
+
+	for i := range samples {
+		data := a.Format.ConvertSample(samples[i])
+
+		n, err := w.Write(data)
+		if err != nil {
+			return totalBytesWritten, fmt.Errorf("unable to write data, err: %w", err)
+		}
+		totalBytesWritten += int64(n)
+	}
+
+	return totalBytesWritten, nil
+}
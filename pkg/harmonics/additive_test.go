@@ -0,0 +1,109 @@
+package harmonics
+
+import (
+	"bytes"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSquareFromSeries_Partials(t *testing.T) {
+	square := NewSquareFromSeries(100.0, 4, time.Second)
+
+	expected := []Partial{
+		{Frequency: 100, Amplitude: 4 / math.Pi},
+		{Frequency: 300, Amplitude: 4 / (3 * math.Pi)},
+		{Frequency: 500, Amplitude: 4 / (5 * math.Pi)},
+		{Frequency: 700, Amplitude: 4 / (7 * math.Pi)},
+	}
+	require.Equal(t, expected, square.Partials)
+}
+
+func TestNewSawFromSeries_Partials(t *testing.T) {
+	saw := NewSawFromSeries(100.0, 3, time.Second)
+
+	expected := []Partial{
+		{Frequency: 100, Amplitude: 2 / math.Pi},
+		{Frequency: 200, Amplitude: -2 / (2 * math.Pi)},
+		{Frequency: 300, Amplitude: 2 / (3 * math.Pi)},
+	}
+	require.Equal(t, expected, saw.Partials)
+}
+
+func TestSeries_StopAtNyquist(t *testing.T) {
+	square := NewSquareFromSeries(1000.0, 100, time.Second, WithSamplingRate(8000.0))
+	require.Len(t, square.Partials, 2, "only 1 kHz and 3 kHz lie below 4 kHz")
+
+	saw := NewSawFromSeries(1000.0, 100, time.Second, WithSamplingRate(8000.0))
+	require.Len(t, saw.Partials, 3)
+}
+
+func TestGenerate_MatchesFourierApproximation(t *testing.T) {
+	frequency := 220.0
+	samplingRate := 44100.0
+	saw := NewSawFromSeries(frequency, 10, 10*time.Millisecond, WithAmplitude(0.5))
+
+	samples, err := saw.Generate()
+	require.NoError(t, err)
+	require.Len(t, samples, 441)
+
+	for i, v := range samples {
+		at := float64(i) / samplingRate
+		expected := 0.0
+		for k := 1; k <= 10; k++ {
+			expected += 2 / math.Pi * math.Pow(-1, float64(k+1)) / float64(k) * math.Sin(2*math.Pi*float64(k)*frequency*at)
+		}
+		require.InDelta(t, 0.5*expected, v, 1e-12, "sample %d", i)
+	}
+}
+
+// squareError returns the RMS distance between the series and an ideal
+// square wave, ignoring samples close to the discontinuities where the
+// Gibbs phenomenon never vanishes.
+func squareError(t *testing.T, numHarmonics int) float64 {
+	t.Helper()
+
+	frequency := 100.0
+	samplingRate := 48000.0
+	square := NewSquareFromSeries(frequency, numHarmonics, 100*time.Millisecond, WithSamplingRate(samplingRate))
+
+	samples, err := square.Generate()
+	require.NoError(t, err)
+
+	sum, count := 0.0, 0
+	for i, v := range samples {
+		phase := math.Mod(float64(i)*frequency/samplingRate, 1.0)
+		if math.Abs(phase-0.5) < 0.05 || phase < 0.05 || phase > 0.95 {
+			continue
+		}
+		ideal := 1.0
+		if phase > 0.5 {
+			ideal = -1.0
+		}
+		sum += (v - ideal) * (v - ideal)
+		count++
+	}
+	return math.Sqrt(sum / float64(count))
+}
+
+func TestSquare_Convergence(t *testing.T) {
+	previous := math.Inf(1)
+	for _, n := range []int{1, 4, 16, 64} {
+		err := squareError(t, n)
+		require.Less(t, err, previous, "error should shrink with %d harmonics", n)
+		previous = err
+	}
+	require.Less(t, previous, 0.02)
+}
+
+func TestWriteTo(t *testing.T) {
+	square := NewSquareFromSeries(440.0, 5, 100*time.Millisecond)
+
+	var buf bytes.Buffer
+	n, err := square.WriteTo(&buf)
+	require.NoError(t, err)
+	require.Equal(t, int64(4410*2), n)
+	require.Equal(t, int(n), buf.Len())
+}
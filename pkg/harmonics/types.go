@@ -0,0 +1,58 @@
+package harmonics
+
+import (
+	"time"
+
+	"github.com/ECecillo/lib.go.sound/pkg/format"
+)
+
+// Partial is a single sinusoidal component of an additive signal.
+type Partial struct {
+	Frequency float64 // Frequency in Hz
+	Amplitude float64 // Amplitude relative to the Additive amplitude
+}
+
+// Additive builds a signal by summing sinusoidal partials.
+type Additive struct {
+	Format       format.AudioFormat
+	Partials     []Partial
+	Duration     time.Duration // Duration of the signal
+	Amplitude    float64       // Overall amplitude (optional, default 1.0)
+	SamplingRate float64       // Sampling frequency in Hz
+}
+
+type Option func(*Additive)
+
+func NewAdditive(partials []Partial, duration time.Duration, options ...Option) *Additive {
+	additive := &Additive{
+		Partials:     partials,
+		Duration:     duration,
+		Amplitude:    1.0,
+		SamplingRate: 44100.0,
+		Format:       format.PCM16{},
+	}
+
+	for _, opt := range options {
+		opt(additive)
+	}
+
+	return additive
+}
+
+func WithAmplitude(amplitude float64) Option {
+	return func(a *Additive) {
+		a.Amplitude = amplitude
+	}
+}
+
+func WithSamplingRate(rate float64) Option {
+	return func(a *Additive) {
+		a.SamplingRate = rate
+	}
+}
+
+func WithFormat(fmt format.AudioFormat) Option {
+	return func(a *Additive) {
+		a.Format = fmt
+	}
+}
@@ -0,0 +1,58 @@
+package impulse
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+)
+
+// ErrOffsetOutOfRange is returned by Generate when Offset does not fall
+// within the generated samples.
+var ErrOffsetOutOfRange = errors.New("impulse offset is out of range")
+
+// WriteTo will generate samples and write them to the given Writer.
+func (i Impulse) WriteTo(w io.Writer) (int64, error) {
+	samples, err := i.Generate()
+	if err != nil {
+		return 0, fmt.Errorf("unable to generate samples, err: %w", err)
+	}
+
+	var totalBytesWritten int64
+
+	for _, sample := range samples {
+		n, err := w.Write(i.Format.ConvertSample(sample))
+		if err != nil {
+			return totalBytesWritten, fmt.Errorf("unable to write data, err: %w", err)
+		}
+		totalBytesWritten += int64(n)
+	}
+
+	return totalBytesWritten, nil
+}
+
+// Generate returns a zero-filled slice with samples[Offset] = Amplitude, the
+// discrete approximation of a Dirac delta. When Sigma is positive the
+// impulse is spread into a Gaussian centered at Offset:
+//
+//	x[n] = A * exp(-(n-Offset)² / (2σ²))
+func (i Impulse) Generate() ([]float64, error) {
+	totalSamples := max(int(i.SamplingRate*i.Duration.Seconds()), 0)
+	if i.Offset < 0 || i.Offset >= totalSamples {
+		return nil, ErrOffsetOutOfRange
+	}
+
+	result := make([]float64, totalSamples)
+
+	if i.Sigma <= 0 {
+		result[i.Offset] = i.Amplitude
+		return result, nil
+	}
+
+	for n := range result {
+		d := float64(n - i.Offset)
+		result[n] = i.Amplitude * math.Exp(-d*d/(2*i.Sigma*i.Sigma))
+	}
+
+	return result, nil
+}
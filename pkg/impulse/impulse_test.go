@@ -0,0 +1,106 @@
+package impulse
+
+import (
+	"bytes"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/ECecillo/lib.go.sound/pkg/filter"
+	"github.com/ECecillo/lib.go.sound/pkg/format"
+	"github.com/stretchr/testify/require"
+)
+
+// convolve returns the full linear convolution of x and h.
+func convolve(x, h []float64) []float64 {
+	result := make([]float64, len(x)+len(h)-1)
+	for n, xv := range x {
+		for k, hv := range h {
+			result[n+k] += xv * hv
+		}
+	}
+	return result
+}
+
+func TestGenerate_SingleNonZeroSample(t *testing.T) {
+	impulse := NewImpulse(10*time.Millisecond, WithAmplitude(0.8), WithOffset(25))
+
+	samples, err := impulse.Generate()
+	require.NoError(t, err)
+	require.Len(t, samples, 441)
+
+	sum := 0.0
+	nonZero := 0
+	for _, v := range samples {
+		sum += v
+		if v != 0 {
+			nonZero++
+		}
+	}
+	require.Equal(t, 1, nonZero)
+	require.Equal(t, 0.8, sum)
+	require.Equal(t, 0.8, samples[25])
+}
+
+func TestGenerate_ConvolutionReproducesFilter(t *testing.T) {
+	kernel := []float64{0.5, -0.25, 0.125, 0.0625, -0.03125}
+	impulse := NewImpulse(time.Millisecond, WithSamplingRate(48000.0), WithOffset(3))
+
+	samples, err := impulse.Generate()
+	require.NoError(t, err)
+
+	output := convolve(samples, kernel)
+	require.Equal(t, kernel, output[3:3+len(kernel)])
+	require.Equal(t, make([]float64, 3), output[:3])
+
+	// Recursive filters return their impulse response as well.
+	ap := filter.AllPass{Delay: 2, Gain: 0.5}
+	response := ap.Process(samples)[3:]
+	require.InDeltaSlice(t, []float64{-0.5, 0, 0.75, 0, 0.375}, response[:5], 1e-12)
+}
+
+func TestGenerate_OffsetOutOfRange(t *testing.T) {
+	for _, offset := range []int{-1, 441} {
+		_, err := NewImpulse(10*time.Millisecond, WithOffset(offset)).Generate()
+		require.ErrorIs(t, err, ErrOffsetOutOfRange)
+	}
+
+	_, err := NewImpulse(10*time.Millisecond, WithOffset(441)).WriteTo(&bytes.Buffer{})
+	require.ErrorIs(t, err, ErrOffsetOutOfRange)
+}
+
+func TestGaussianPulse(t *testing.T) {
+	pulse := GaussianPulse(4.0, 10*time.Millisecond, WithAmplitude(0.5), WithOffset(100))
+
+	samples, err := pulse.Generate()
+	require.NoError(t, err)
+
+	require.Equal(t, 0.5, samples[100])
+	for d := 1; d < 20; d++ {
+		require.Equal(t, samples[100-d], samples[100+d], "pulse should be symmetric")
+		require.Less(t, samples[100+d], samples[100+d-1], "pulse should decay away from the center")
+	}
+
+	// One standard deviation away the pulse drops to A*e^(-1/2).
+	require.InDelta(t, 0.5*math.Exp(-0.5), samples[104], 1e-12)
+
+	// The area of a sampled Gaussian approaches A*σ*√(2π).
+	sum := 0.0
+	for _, v := range samples {
+		sum += v
+	}
+	require.InDelta(t, 0.5*4.0*math.Sqrt(2*math.Pi), sum, 1e-9)
+}
+
+func TestWriteTo(t *testing.T) {
+	impulse := NewImpulse(time.Millisecond, WithFormat(format.Float64{}), WithOffset(1))
+
+	var buf bytes.Buffer
+	n, err := impulse.WriteTo(&buf)
+	require.NoError(t, err)
+	require.Equal(t, int64(44*8), n)
+
+	expected := format.Float64{}.ConvertSample(1.0)
+	require.Equal(t, expected, buf.Bytes()[8:16])
+	require.Equal(t, make([]byte, 8), buf.Bytes()[:8])
+}
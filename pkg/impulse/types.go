@@ -0,0 +1,66 @@
+package impulse
+
+import (
+	"time"
+
+	"github.com/ECecillo/lib.go.sound/pkg/format"
+)
+
+type Impulse struct {
+	Format       format.AudioFormat
+	Duration     time.Duration // Duration of the signal
+	Amplitude    float64       // Value of the impulse (optional, default 1.0)
+	SamplingRate float64       // Sampling frequency in Hz
+	Offset       int           // Sample index of the impulse (optional, default 0)
+	Sigma        float64       // Standard deviation in samples; 0 produces a single-sample impulse
+}
+
+type Option func(*Impulse)
+
+func NewImpulse(duration time.Duration, options ...Option) *Impulse {
+	impulse := &Impulse{
+		Duration:     duration,
+		Amplitude:    1.0,
+		SamplingRate: 44100.0,
+		Format:       format.PCM16{},
+	}
+
+	for _, opt := range options {
+		opt(impulse)
+	}
+
+	return impulse
+}
+
+// GaussianPulse returns a generator producing a Gaussian bump of standard
+// deviation sigma samples, centered at Offset and peaking at Amplitude.
+func GaussianPulse(sigma float64, duration time.Duration, options ...Option) *Impulse {
+	impulse := NewImpulse(duration, options...)
+	impulse.Sigma = sigma
+
+	return impulse
+}
+
+func WithAmplitude(amplitude float64) Option {
+	return func(i *Impulse) {
+		i.Amplitude = amplitude
+	}
+}
+
+func WithSamplingRate(rate float64) Option {
+	return func(i *Impulse) {
+		i.SamplingRate = rate
+	}
+}
+
+func WithFormat(fmt format.AudioFormat) Option {
+	return func(i *Impulse) {
+		i.Format = fmt
+	}
+}
+
+func WithOffset(offset int) Option {
+	return func(i *Impulse) {
+		i.Offset = offset
+	}
+}
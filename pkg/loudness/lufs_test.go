@@ -0,0 +1,71 @@
+package loudness
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ECecillo/lib.go.sound/pkg/sine"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMeasureIntegratedLUFS_ReferenceTone(t *testing.T) {
+	// BS.1770 calibrates a full-scale 997 Hz sine on one channel to read
+	// -3.01 LUFS.
+	for _, sampleRate := range []float64{44100.0, 48000.0, 96000.0} {
+		samples, err := sine.NewSine(997.0, 2*time.Second, sine.WithSamplingRate(sampleRate)).Generate()
+		require.NoError(t, err)
+
+		lufs, err := MeasureIntegratedLUFS(samples, sampleRate)
+		require.NoError(t, err)
+		require.InDelta(t, -3.01, lufs, 0.1, "sample rate %.0f", sampleRate)
+	}
+}
+
+func TestMeasureIntegratedLUFS_Gating(t *testing.T) {
+	// Silent blocks fall below the absolute gate, so the length of the
+	// silence following the tone must not change the integrated loudness.
+	tone, err := sine.NewSine(997.0, 2*time.Second, sine.WithSamplingRate(48000.0), sine.WithAmplitude(0.5)).Generate()
+	require.NoError(t, err)
+
+	short := append(append([]float64(nil), tone...), make([]float64, 48000)...)
+	long := append(append([]float64(nil), tone...), make([]float64, 48000*10)...)
+
+	shortLUFS, err := MeasureIntegratedLUFS(short, 48000.0)
+	require.NoError(t, err)
+	longLUFS, err := MeasureIntegratedLUFS(long, 48000.0)
+	require.NoError(t, err)
+
+	require.InDelta(t, shortLUFS, longLUFS, 1e-9)
+	require.InDelta(t, -9.03, shortLUFS, 0.5)
+}
+
+func TestMeasureIntegratedLUFS_Errors(t *testing.T) {
+	_, err := MeasureIntegratedLUFS(make([]float64, 48000), 48000.0)
+	require.ErrorIs(t, err, ErrSilentSignal)
+
+	_, err = MeasureIntegratedLUFS(make([]float64, 1000), 48000.0)
+	require.ErrorIs(t, err, ErrSignalTooShort)
+
+	_, err = MeasureIntegratedLUFS(make([]float64, 1000), 0)
+	require.ErrorIs(t, err, ErrInvalidSampleRate)
+}
+
+func TestNormalizeToLUFS(t *testing.T) {
+	for _, amplitude := range []float64{0.05, 0.3, 0.9} {
+		samples, err := sine.NewSine(440.0, 3*time.Second, sine.WithSamplingRate(48000.0), sine.WithAmplitude(amplitude)).Generate()
+		require.NoError(t, err)
+
+		normalized, err := NormalizeToLUFS(samples, 48000.0, -23.0)
+		require.NoError(t, err)
+		require.Len(t, normalized, len(samples))
+
+		lufs, err := MeasureIntegratedLUFS(normalized, 48000.0)
+		require.NoError(t, err)
+		require.InDelta(t, -23.0, lufs, 0.1, "amplitude %.2f", amplitude)
+	}
+}
+
+func TestNormalizeToLUFS_Silence(t *testing.T) {
+	_, err := NormalizeToLUFS(make([]float64, 48000), 48000.0, -23.0)
+	require.ErrorIs(t, err, ErrSilentSignal)
+}
@@ -0,0 +1,91 @@
+package loudness
+
+import (
+	"errors"
+	"math"
+
+	"github.com/ECecillo/lib.go.sound/pkg/units"
+)
+
+// ErrEmptySignal is returned when there are no samples to measure.
+var ErrEmptySignal = errors.New("signal has no samples")
+
+// ErrInvalidSampleRate is returned when the sample rate is not positive.
+var ErrInvalidSampleRate = errors.New("sample rate must be positive")
+
+// oversamplingFactor is the upsampling ratio used by TruePeak.
+const oversamplingFactor = 4
+
+// truePeakPhases holds the 48-tap interpolation filter from ITU-R BS.1770-4
+// Annex 2, split into its four 12-tap polyphase components.
+var truePeakPhases = [oversamplingFactor][12]float64{
+	{
+		0.0017089843750, 0.0109863281250, -0.0196533203125, 0.0332031250000,
+		-0.0594482421875, 0.1373291015625, 0.9721679687500, -0.1022949218750,
+		0.0476074218750, -0.0266113281250, 0.0148925781250, -0.0083007812500,
+	},
+	{
+		-0.0291748046875, 0.0292968750000, -0.0517578125000, 0.0891113281250,
+		-0.1665039062500, 0.4650878906250, 0.7797851562500, -0.2003173828125,
+		0.1015625000000, -0.0582275390625, 0.0330810546875, -0.0189208984375,
+	},
+	{
+		-0.0189208984375, 0.0330810546875, -0.0582275390625, 0.1015625000000,
+		-0.2003173828125, 0.7797851562500, 0.4650878906250, -0.1665039062500,
+		0.0891113281250, -0.0517578125000, 0.0292968750000, -0.0291748046875,
+	},
+	{
+		-0.0083007812500, 0.0148925781250, -0.0266113281250, 0.0476074218750,
+		-0.1022949218750, 0.9721679687500, 0.1373291015625, -0.0594482421875,
+		0.0332031250000, -0.0196533203125, 0.0109863281250, 0.0017089843750,
+	},
+}
+
+// Peak returns the largest absolute sample value in dBFS.
+func Peak(samples []float64) (float64, error) {
+	if len(samples) == 0 {
+		return 0, ErrEmptySignal
+	}
+
+	peak := 0.0
+	for _, x := range samples {
+		peak = math.Max(peak, math.Abs(x))
+	}
+
+	return units.LinearToDBFS(peak), nil
+}
+
+// TruePeak estimates the peak of the reconstructed analog signal in dBFS
+// following ITU-R BS.1770-4. The samples are upsampled 4x with the
+// recommended polyphase FIR so that inter-sample peaks, which a DAC would
+// reproduce but Peak cannot see, are taken into account. The filter is
+// specified for 48 kHz material; at higher sample rates it remains a
+// conservative estimate.
+func TruePeak(samples []float64, sampleRate float64) (float64, error) {
+	if sampleRate <= 0 {
+		return 0, ErrInvalidSampleRate
+	}
+	if len(samples) == 0 {
+		return 0, ErrEmptySignal
+	}
+
+	taps := len(truePeakPhases[0])
+	peak := 0.0
+
+	// Run past the last sample so the filter tail is flushed as well.
+	for n := range len(samples) + taps {
+		for _, phase := range truePeakPhases {
+			var y float64
+			for k, h := range phase {
+				i := n - k
+				if i < 0 || i >= len(samples) {
+					continue
+				}
+				y += h * samples[i]
+			}
+			peak = math.Max(peak, math.Abs(y))
+		}
+	}
+
+	return units.LinearToDBFS(peak), nil
+}
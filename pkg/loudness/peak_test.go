@@ -0,0 +1,67 @@
+package loudness
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/ECecillo/lib.go.sound/pkg/sine"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTruePeak_PhasesHaveNearUnityGain(t *testing.T) {
+	for p, phase := range truePeakPhases {
+		sum := 0.0
+		for _, h := range phase {
+			sum += h
+		}
+		require.InDelta(t, 1.0, sum, 0.05, "phase %d does not pass DC close to unity", p)
+	}
+}
+
+func TestTruePeak_InterSampleOvershoot(t *testing.T) {
+	// A 997 Hz tone sampled at four times its frequency and offset by 45° is
+	// sampled at ±A/√2 only, while the reconstructed waveform reaches ±A.
+	// With A=1.2 every sample stays below full scale but the true peak
+	// exceeds it.
+	sampleRate := 4 * 997.0
+	amplitude := 1.2
+	samples, err := sine.NewSine(997.0, time.Second, sine.WithSamplingRate(sampleRate),
+		sine.WithAmplitude(amplitude), sine.WithPhase(math.Pi/4)).Generate()
+	require.NoError(t, err)
+
+	peak, err := Peak(samples)
+	require.NoError(t, err)
+	require.Less(t, peak, 0.0)
+
+	truePeak, err := TruePeak(samples, sampleRate)
+	require.NoError(t, err)
+	require.Greater(t, truePeak, 0.0)
+	require.Greater(t, truePeak, peak)
+	require.InDelta(t, 20*math.Log10(amplitude), truePeak, 0.5)
+}
+
+func TestTruePeak_AgreesWithPeakForLowLevelSine(t *testing.T) {
+	samples, err := sine.NewSine(997.0, time.Second,
+		sine.WithSamplingRate(48000.0), sine.WithAmplitude(0.5)).Generate()
+	require.NoError(t, err)
+
+	peak, err := Peak(samples)
+	require.NoError(t, err)
+	require.InDelta(t, -6.02, peak, 0.01)
+
+	truePeak, err := TruePeak(samples, 48000.0)
+	require.NoError(t, err)
+	require.InDelta(t, peak, truePeak, 0.1)
+}
+
+func TestPeak_Errors(t *testing.T) {
+	_, err := Peak(nil)
+	require.ErrorIs(t, err, ErrEmptySignal)
+
+	_, err = TruePeak(nil, 48000.0)
+	require.ErrorIs(t, err, ErrEmptySignal)
+
+	_, err = TruePeak([]float64{0.5}, 0)
+	require.ErrorIs(t, err, ErrInvalidSampleRate)
+}
@@ -0,0 +1,71 @@
+package mixer
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+)
+
+// ErrNegativeOffset is returned by Generate when a track starts before the
+// first sample.
+var ErrNegativeOffset = errors.New("track offset must not be negative")
+
+// WriteTo will generate samples and write them to the given Writer.
+func (m Mixer) WriteTo(w io.Writer) (int64, error) {
+	samples, err := m.Generate()
+	if err != nil {
+		return 0, fmt.Errorf("unable to generate samples, err: %w", err)
+	}
+
+	var totalBytesWritten int64
+
+	for _, sample := range samples {
+		n, err := w.Write(m.Format.ConvertSample(sample))
+		if err != nil {
+			return totalBytesWritten, fmt.Errorf("unable to write data, err: %w", err)
+		}
+		totalBytesWritten += int64(n)
+	}
+
+	return totalBytesWritten, nil
+}
+
+// Generate sums every track at its offset and gain. The result lasts until
+// the end of the longest track. When the sum peaks above 1.0 the whole mix
+// is scaled down to a peak of 1.0 so that it does not clip once encoded.
+func (m Mixer) Generate() ([]float64, error) {
+	var result []float64
+
+	for i, track := range m.Tracks {
+		if track.Offset < 0 {
+			return nil, ErrNegativeOffset
+		}
+
+		samples, err := track.Source.Generate()
+		if err != nil {
+			return nil, fmt.Errorf("unable to generate track %d, err: %w", i, err)
+		}
+
+		if end := track.Offset + len(samples); end > len(result) {
+			result = append(result, make([]float64, end-len(result))...)
+		}
+
+		for n, v := range samples {
+			result[track.Offset+n] += track.Gain * v
+		}
+	}
+
+	peak := 0.0
+	for _, v := range result {
+		peak = math.Max(peak, math.Abs(v))
+	}
+
+	if peak > 1.0 {
+		for n := range result {
+			result[n] /= peak
+		}
+	}
+
+	return result, nil
+}
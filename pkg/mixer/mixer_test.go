@@ -0,0 +1,114 @@
+package mixer
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/ECecillo/lib.go.sound/pkg/format"
+	"github.com/ECecillo/lib.go.sound/pkg/sine"
+	"github.com/stretchr/testify/require"
+)
+
+// constant is a Source producing the same value for a number of samples.
+type constant struct {
+	value  float64
+	length int
+}
+
+func (c constant) Generate() ([]float64, error) {
+	samples := make([]float64, c.length)
+	for i := range samples {
+		samples[i] = c.value
+	}
+	return samples, nil
+}
+
+type failing struct{}
+
+var errSource = errors.New("source failed")
+
+func (failing) Generate() ([]float64, error) {
+	return nil, errSource
+}
+
+func TestGenerate_SumsTracks(t *testing.T) {
+	m := NewMixer()
+	m.Add(constant{value: 0.25, length: 4}, 1.0)
+	m.AddAt(constant{value: 0.5, length: 3}, 2, 0.5)
+
+	samples, err := m.Generate()
+	require.NoError(t, err)
+	require.Equal(t, []float64{0.25, 0.25, 0.5, 0.5, 0.25}, samples)
+}
+
+func TestGenerate_MatchesSources(t *testing.T) {
+	a := sine.NewSine(440.0, 100*time.Millisecond, sine.WithAmplitude(0.3))
+	b := sine.NewSine(660.0, 100*time.Millisecond, sine.WithAmplitude(0.3))
+
+	m := NewMixer()
+	m.Add(a, 1.0)
+	m.Add(b, 1.0)
+
+	samples, err := m.Generate()
+	require.NoError(t, err)
+
+	expectedA, err := a.Generate()
+	require.NoError(t, err)
+	expectedB, err := b.Generate()
+	require.NoError(t, err)
+
+	for i := range samples {
+		require.InDelta(t, expectedA[i]+expectedB[i], samples[i], 1e-12)
+	}
+}
+
+func TestGenerate_PreventsClipping(t *testing.T) {
+	m := NewMixer()
+	for _, freq := range []float64{220, 330, 440} {
+		m.Add(sine.NewSine(freq, 100*time.Millisecond), 1.0)
+	}
+
+	samples, err := m.Generate()
+	require.NoError(t, err)
+
+	peak := 0.0
+	for _, v := range samples {
+		peak = math.Max(peak, math.Abs(v))
+	}
+	require.InDelta(t, 1.0, peak, 1e-12)
+}
+
+func TestGenerate_Errors(t *testing.T) {
+	m := NewMixer()
+	m.Add(failing{}, 1.0)
+	_, err := m.Generate()
+	require.ErrorIs(t, err, errSource)
+
+	_, err = m.WriteTo(&bytes.Buffer{})
+	require.ErrorIs(t, err, errSource)
+
+	m = NewMixer()
+	m.AddAt(constant{value: 1, length: 1}, -1, 1.0)
+	_, err = m.Generate()
+	require.ErrorIs(t, err, ErrNegativeOffset)
+}
+
+func TestWriteTo(t *testing.T) {
+	m := NewMixer(WithFormat(format.Float64{}))
+	m.Add(constant{value: 0.5, length: 3}, 1.0)
+
+	var buf bytes.Buffer
+	n, err := m.WriteTo(&buf)
+	require.NoError(t, err)
+	require.Equal(t, int64(24), n)
+	require.Equal(t, bytes.Repeat(format.Float64{}.ConvertSample(0.5), 3), buf.Bytes())
+}
+
+func TestGenerate_Empty(t *testing.T) {
+	samples, err := NewMixer().Generate()
+	require.NoError(t, err)
+	require.Empty(t, samples)
+}
@@ -0,0 +1,54 @@
+package mixer
+
+import (
+	"github.com/ECecillo/lib.go.sound/pkg/format"
+)
+
+// Source is anything able to produce samples, such as sine.Sine or
+// harmonics.Additive.
+type Source interface {
+	Generate() ([]float64, error)
+}
+
+// Track places a source in the mix.
+type Track struct {
+	Source Source
+	Gain   float64 // Linear gain applied to the source
+	Offset int     // Sample index at which the source starts
+}
+
+// Mixer sums several sources into a single signal.
+type Mixer struct {
+	Format format.AudioFormat
+	Tracks []Track
+}
+
+type Option func(*Mixer)
+
+func NewMixer(options ...Option) *Mixer {
+	mixer := &Mixer{
+		Format: format.PCM16{},
+	}
+
+	for _, opt := range options {
+		opt(mixer)
+	}
+
+	return mixer
+}
+
+func WithFormat(fmt format.AudioFormat) Option {
+	return func(m *Mixer) {
+		m.Format = fmt
+	}
+}
+
+// Add mixes source from the first sample with the given gain.
+func (m *Mixer) Add(source Source, gain float64) {
+	m.AddAt(source, 0, gain)
+}
+
+// AddAt mixes source starting at sample offset with the given gain.
+func (m *Mixer) AddAt(source Source, offset int, gain float64) {
+	m.Tracks = append(m.Tracks, Track{Source: source, Gain: gain, Offset: offset})
+}
@@ -0,0 +1,121 @@
+package modem
+
+import (
+	"math"
+
+	"github.com/ECecillo/lib.go.sound/pkg/analysis"
+)
+
+// ASKModulator encodes data with amplitude-shift keying: every symbol of
+// BitsPerSymbol bits is sent as a burst of the carrier at one of
+// 2^BitsPerSymbol amplitude levels:
+//
+//	A(v) = (v + 1) / 2^BitsPerSymbol
+//
+// The lowest level is kept above zero so the carrier never disappears.
+type ASKModulator struct {
+	CarrierFreq   float64 // Carrier frequency in Hz
+	SampleRate    float64 // Sampling frequency in Hz
+	BaudRate      float64 // Symbols per second
+	BitsPerSymbol int     // Bits carried by each symbol: 1, 2, 4 or 8
+}
+
+// NewASKModulator returns an ASK modulator for the given parameters.
+func NewASKModulator(carrierFreq, sampleRate, baudRate float64, bitsPerSymbol int) *ASKModulator {
+	return &ASKModulator{
+		CarrierFreq:   carrierFreq,
+		SampleRate:    sampleRate,
+		BaudRate:      baudRate,
+		BitsPerSymbol: bitsPerSymbol,
+	}
+}
+
+// Modulate returns the ASK signal carrying data, most significant bit
+// first. The carrier phase runs continuously across symbols.
+func (m ASKModulator) Modulate(data []byte) ([]float64, error) {
+	length, err := m.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	levels := float64(int(1) << m.BitsPerSymbol)
+	values := symbols(data, m.BitsPerSymbol)
+	result := make([]float64, len(values)*length)
+
+	for i, v := range values {
+		amplitude := float64(v+1) / levels
+		for j := range length {
+			n := i*length + j
+			result[n] = amplitude * math.Sin(2*math.Pi*m.CarrierFreq*float64(n)/m.SampleRate)
+		}
+	}
+
+	return result, nil
+}
+
+// Demodulate recovers the bytes carried by an ASK signal. The carrier
+// amplitude of each symbol is measured with carrierAmplitude and rounded to
+// the nearest level. Samples that do not fill a whole byte are ignored.
+func (m ASKModulator) Demodulate(samples []float64) ([]byte, error) {
+	length, err := m.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	levels := int(1) << m.BitsPerSymbol
+	values := make([]int, len(samples)/length)
+
+	for i := range values {
+		symbol := samples[i*length : (i+1)*length]
+		amplitude := carrierAmplitude(symbol, m.CarrierFreq, m.SampleRate)
+
+		v := int(math.Round(amplitude*float64(levels))) - 1
+		values[i] = min(max(v, 0), levels-1)
+	}
+
+	return pack(values, m.BitsPerSymbol), nil
+}
+
+// carrierAmplitude returns the amplitude of the tone at freq in samples by
+// fitting a·sin(ωn) + b·cos(ωn) in the least squares sense (I/Q):
+//
+//	[Σss Σsc] [a]   [Σxs]
+//	[Σsc Σcc] [b] = [Σxc]
+//
+// Unlike the Goertzel estimate 2*|X(freq)|/N the fit is unbiased for any
+// symbol length, including those holding a fractional number of carrier
+// periods. It falls back to Goertzel when the system is singular, as for
+// symbols of a single sample.
+func carrierAmplitude(samples []float64, freq, sampleRate float64) float64 {
+	var ss, sc, cc, xs, xc float64
+	for n, x := range samples {
+		sin, cos := math.Sincos(2 * math.Pi * freq * float64(n) / sampleRate)
+		ss += sin * sin
+		sc += sin * cos
+		cc += cos * cos
+		xs += x * sin
+		xc += x * cos
+	}
+
+	det := ss*cc - sc*sc
+	if det <= 1e-9*(ss+cc)*(ss+cc) {
+		return 2 * analysis.Goertzel(samples, freq, sampleRate) / float64(len(samples))
+	}
+
+	a := (xs*cc - xc*sc) / det
+	b := (xc*ss - xs*sc) / det
+	return math.Hypot(a, b)
+}
+
+// validate checks the configuration and returns the symbol length.
+func (m ASKModulator) validate() (int, error) {
+	switch m.BitsPerSymbol {
+	case 1, 2, 4, 8:
+	default:
+		return 0, ErrInvalidBitsPerSymbol
+	}
+	if !validFrequency(m.CarrierFreq, m.SampleRate) {
+		return 0, ErrInvalidFrequency
+	}
+	return samplesPerSymbol(m.SampleRate, m.BaudRate)
+}
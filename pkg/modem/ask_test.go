@@ -0,0 +1,92 @@
+package modem
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestASKModulator_RoundTrip(t *testing.T) {
+	data := randomBytes(64, 1)
+
+	for _, bits := range []int{1, 2, 4} {
+		m := NewASKModulator(2000, 48000, 500, bits)
+
+		signal, err := m.Modulate(data)
+		require.NoError(t, err)
+		require.Len(t, signal, len(data)*8/bits*96)
+
+		decoded, err := m.Demodulate(signal)
+		require.NoError(t, err)
+		require.Equal(t, data, decoded, "clean, %d bits per symbol", bits)
+
+		decoded, err = m.Demodulate(addNoise(signal, 20, uint64(bits)))
+		require.NoError(t, err)
+		require.Equal(t, data, decoded, "20 dB SNR, %d bits per symbol", bits)
+	}
+}
+
+func TestASKModulator_RoundTripFractionalCarrier(t *testing.T) {
+	data := randomBytes(64, 2)
+
+	// None of these carriers fits a whole number of periods in the 96
+	// samples of a symbol.
+	tests := []struct {
+		carrier float64
+		bits    int
+	}{
+		{carrier: 1900, bits: 4},
+		{carrier: 1234, bits: 8},
+		{carrier: 3100, bits: 8},
+		{carrier: 1900, bits: 8},
+	}
+
+	for _, tt := range tests {
+		m := NewASKModulator(tt.carrier, 48000, 500, tt.bits)
+
+		signal, err := m.Modulate(data)
+		require.NoError(t, err)
+
+		decoded, err := m.Demodulate(signal)
+		require.NoError(t, err)
+		require.Equal(t, data, decoded, "%g Hz, %d bits per symbol", tt.carrier, tt.bits)
+	}
+}
+
+func TestASKModulator_Levels(t *testing.T) {
+	m := NewASKModulator(1000, 8000, 250, 2)
+
+	// 0b00011011 holds the four levels in increasing order.
+	signal, err := m.Modulate([]byte{0b00011011})
+	require.NoError(t, err)
+
+	for i, expected := range []float64{0.25, 0.5, 0.75, 1} {
+		peak := 0.0
+		for _, v := range signal[i*32 : (i+1)*32] {
+			peak = max(peak, v)
+		}
+		require.InDelta(t, expected, peak, 1e-9, "symbol %d", i)
+	}
+}
+
+func TestASKModulator_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		m    *ASKModulator
+		err  error
+	}{
+		{name: "bits per symbol", m: NewASKModulator(2000, 48000, 500, 3), err: ErrInvalidBitsPerSymbol},
+		{name: "baud not dividing rate", m: NewASKModulator(2000, 48000, 700, 1), err: ErrInvalidBaudRate},
+		{name: "zero baud", m: NewASKModulator(2000, 48000, 0, 1), err: ErrInvalidBaudRate},
+		{name: "carrier above Nyquist", m: NewASKModulator(30000, 48000, 500, 1), err: ErrInvalidFrequency},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.m.Modulate([]byte{1})
+			require.ErrorIs(t, err, tt.err)
+			_, err = tt.m.Demodulate(make([]float64, 96))
+			require.ErrorIs(t, err, tt.err)
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package modem
+
+import (
+	"math"
+
+	"github.com/ECecillo/lib.go.sound/pkg/analysis"
+)
+
+// FSKModulator encodes data with binary frequency-shift keying: each bit
+// is sent as a tone at MarkFreq for 1 and SpaceFreq for 0, as in the Bell
+// 202 and Kansas City Standard audio modems.
+type FSKModulator struct {
+	MarkFreq   float64 // Tone for 1 bits in Hz
+	SpaceFreq  float64 // Tone for 0 bits in Hz
+	SampleRate float64 // Sampling frequency in Hz
+	BaudRate   float64 // Bits per second
+}
+
+// NewFSKModulator returns an FSK modulator for the given parameters.
+func NewFSKModulator(markFreq, spaceFreq, sampleRate, baudRate float64) *FSKModulator {
+	return &FSKModulator{
+		MarkFreq:   markFreq,
+		SpaceFreq:  spaceFreq,
+		SampleRate: sampleRate,
+		BaudRate:   baudRate,
+	}
+}
+
+// Modulate returns the FSK signal carrying data, most significant bit
+// first, at unit amplitude. The phase is carried over from one bit to the
+// next so the frequency changes without clicks.
+func (m FSKModulator) Modulate(data []byte) ([]float64, error) {
+	length, err := m.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	bits := symbols(data, 1)
+	result := make([]float64, len(bits)*length)
+
+	var phase float64
+	for i, bit := range bits {
+		freq := m.SpaceFreq
+		if bit == 1 {
+			freq = m.MarkFreq
+		}
+
+		step := 2 * math.Pi * freq / m.SampleRate
+		for j := range length {
+			result[i*length+j] = math.Sin(phase)
+			phase = math.Mod(phase+step, 2*math.Pi)
+		}
+	}
+
+	return result, nil
+}
+
+// Demodulate recovers the bytes carried by an FSK signal by comparing the
+// energy of each bit at the mark and space frequencies. Samples that do
+// not fill a whole byte are ignored.
+func (m FSKModulator) Demodulate(samples []float64) ([]byte, error) {
+	length, err := m.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	bits := make([]int, len(samples)/length)
+	for i := range bits {
+		symbol := samples[i*length : (i+1)*length]
+		if analysis.Goertzel(symbol, m.MarkFreq, m.SampleRate) > analysis.Goertzel(symbol, m.SpaceFreq, m.SampleRate) {
+			bits[i] = 1
+		}
+	}
+
+	return pack(bits, 1), nil
+}
+
+// validate checks the configuration and returns the bit length.
+func (m FSKModulator) validate() (int, error) {
+	if !validFrequency(m.MarkFreq, m.SampleRate) || !validFrequency(m.SpaceFreq, m.SampleRate) || m.MarkFreq == m.SpaceFreq {
+		return 0, ErrInvalidFrequency
+	}
+	return samplesPerSymbol(m.SampleRate, m.BaudRate)
+}
@@ -0,0 +1,47 @@
+package modem
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFSKModulator_RoundTrip(t *testing.T) {
+	// Bell 202 tones at 1200 baud.
+	m := NewFSKModulator(1200, 2200, 48000, 1200)
+	data := randomBytes(64, 2)
+
+	signal, err := m.Modulate(data)
+	require.NoError(t, err)
+	require.Len(t, signal, len(data)*8*40)
+
+	decoded, err := m.Demodulate(signal)
+	require.NoError(t, err)
+	require.Equal(t, data, decoded)
+
+	decoded, err = m.Demodulate(addNoise(signal, 20, 3))
+	require.NoError(t, err)
+	require.Equal(t, data, decoded)
+}
+
+func TestFSKModulator_ContinuousPhase(t *testing.T) {
+	m := NewFSKModulator(1200, 2200, 48000, 1200)
+	signal, err := m.Modulate([]byte{0b01010101})
+	require.NoError(t, err)
+
+	// No step between consecutive samples exceeds the steepest slope of
+	// the higher tone.
+	maxStep := 2 * math.Pi * 2200 / 48000
+	for n := 1; n < len(signal); n++ {
+		require.LessOrEqual(t, math.Abs(signal[n]-signal[n-1]), maxStep+1e-9, "sample %d", n)
+	}
+}
+
+func TestFSKModulator_Errors(t *testing.T) {
+	_, err := NewFSKModulator(1200, 1200, 48000, 1200).Modulate([]byte{1})
+	require.ErrorIs(t, err, ErrInvalidFrequency)
+
+	_, err = NewFSKModulator(1200, 2200, 48000, 1100).Demodulate(make([]float64, 100))
+	require.ErrorIs(t, err, ErrInvalidBaudRate)
+}
@@ -0,0 +1,66 @@
+package modem
+
+import (
+	"errors"
+	"math"
+)
+
+var (
+	// ErrInvalidBaudRate is returned when the sample rate is not a whole
+	// multiple of the baud rate.
+	ErrInvalidBaudRate = errors.New("sample rate must be a positive multiple of the baud rate")
+	// ErrInvalidBitsPerSymbol is returned when the number of bits per
+	// symbol does not divide a byte.
+	ErrInvalidBitsPerSymbol = errors.New("bits per symbol must be 1, 2, 4 or 8")
+	// ErrInvalidFrequency is returned when a tone frequency is not between
+	// 0 and Nyquist.
+	ErrInvalidFrequency = errors.New("frequency must be between 0 and Nyquist")
+)
+
+// samplesPerSymbol returns the length in samples of one symbol.
+func samplesPerSymbol(sampleRate, baudRate float64) (int, error) {
+	if sampleRate <= 0 || baudRate <= 0 {
+		return 0, ErrInvalidBaudRate
+	}
+
+	n := sampleRate / baudRate
+	if n < 1 || n != math.Trunc(n) {
+		return 0, ErrInvalidBaudRate
+	}
+	return int(n), nil
+}
+
+// validFrequency reports whether freq can be represented at sampleRate.
+func validFrequency(freq, sampleRate float64) bool {
+	return freq > 0 && freq < sampleRate/2
+}
+
+// symbols splits data into groups of bits most significant bit first.
+// bits must divide 8.
+func symbols(data []byte, bits int) []int {
+	mask := 1<<bits - 1
+	result := make([]int, 0, len(data)*8/bits)
+
+	for _, b := range data {
+		for shift := 8 - bits; shift >= 0; shift -= bits {
+			result = append(result, int(b)>>shift&mask)
+		}
+	}
+	return result
+}
+
+// pack is the inverse of symbols. Trailing symbols that do not fill a
+// whole byte are dropped.
+func pack(values []int, bits int) []byte {
+	perByte := 8 / bits
+	result := make([]byte, len(values)/perByte)
+
+	for i := range result {
+		var b int
+		for _, v := range values[i*perByte : (i+1)*perByte] {
+			b = b<<bits | v
+		}
+		result[i] = byte(b)
+	}
+	return result
+}
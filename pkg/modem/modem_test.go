@@ -0,0 +1,45 @@
+package modem
+
+import (
+	"math"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// randomBytes returns n reproducible random bytes.
+func randomBytes(n int, seed uint64) []byte {
+	rng := rand.New(rand.NewPCG(seed, seed))
+	data := make([]byte, n)
+	for i := range data {
+		data[i] = byte(rng.IntN(256))
+	}
+	return data
+}
+
+// addNoise returns samples with white Gaussian noise added at the given
+// signal-to-noise ratio in dB.
+func addNoise(samples []float64, snrDB float64, seed uint64) []float64 {
+	var power float64
+	for _, v := range samples {
+		power += v * v
+	}
+	power /= float64(len(samples))
+	sigma := math.Sqrt(power / math.Pow(10, snrDB/10))
+
+	rng := rand.New(rand.NewPCG(seed, seed))
+	noisy := make([]float64, len(samples))
+	for i, v := range samples {
+		noisy[i] = v + sigma*rng.NormFloat64()
+	}
+	return noisy
+}
+
+func TestSymbolsPack(t *testing.T) {
+	data := []byte{0b10110100, 0xFF, 0x00, 0x5A}
+	for _, bits := range []int{1, 2, 4, 8} {
+		require.Equal(t, data, pack(symbols(data, bits), bits), "bits %d", bits)
+	}
+	require.Equal(t, []int{2, 3, 1, 0}, symbols([]byte{0b10110100}, 2))
+}
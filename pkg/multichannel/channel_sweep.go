@@ -0,0 +1,67 @@
+package multichannel
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ECecillo/lib.go.sound/pkg/format"
+	"github.com/ECecillo/lib.go.sound/pkg/sine"
+)
+
+// ErrInvalidTestChannel is returned when the test channel of a ChannelSweep
+// is not one of its channels or when it has no signal.
+var ErrInvalidTestChannel = errors.New("test channel out of range")
+
+// ChannelSweep drives a single channel of a multichannel layout with a test
+// signal while every other channel stays silent, as needed to measure the
+// crosstalk between channels.
+type ChannelSweep struct {
+	Channels    int        // Number of output channels
+	TestChannel int        // Index of the channel carrying Signal
+	Signal      *sine.Sine // Test signal
+}
+
+// NewChannelSweep returns a ChannelSweep playing signal on testChannel.
+func NewChannelSweep(channels, testChannel int, signal *sine.Sine) *ChannelSweep {
+	return &ChannelSweep{
+		Channels:    channels,
+		TestChannel: testChannel,
+		Signal:      signal,
+	}
+}
+
+// Generate returns one slice per channel. TestChannel holds the generated
+// signal and the other channels hold as many zeros.
+func (c ChannelSweep) Generate() ([][]float64, error) {
+	if c.Signal == nil || c.TestChannel < 0 || c.TestChannel >= c.Channels {
+		return nil, ErrInvalidTestChannel
+	}
+
+	samples, err := c.Signal.Generate()
+	if err != nil {
+		return nil, fmt.Errorf("unable to generate test signal, err: %w", err)
+	}
+
+	channels := make([][]float64, c.Channels)
+	for i := range channels {
+		if i == c.TestChannel {
+			channels[i] = samples
+			continue
+		}
+		channels[i] = make([]float64, len(samples))
+	}
+
+	return channels, nil
+}
+
+// WriteInterleaved generates the channels and encodes them with f as
+// interleaved frames, returning the number of bytes written.
+func (c ChannelSweep) WriteInterleaved(w io.Writer, f format.AudioFormat) (int64, error) {
+	channels, err := c.Generate()
+	if err != nil {
+		return 0, err
+	}
+
+	return NewMultichannelWriter(c.Channels, c.Signal.SamplingRate, f).WriteInterleaved(channels, w)
+}
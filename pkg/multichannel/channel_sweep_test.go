@@ -0,0 +1,74 @@
+package multichannel
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/ECecillo/lib.go.sound/pkg/format"
+	"github.com/ECecillo/lib.go.sound/pkg/sine"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChannelSweep_Generate(t *testing.T) {
+	signal := sine.NewSine(1000.0, 10*time.Millisecond)
+	expected, err := signal.Generate()
+	require.NoError(t, err)
+
+	for testChannel := range 4 {
+		channels, err := NewChannelSweep(4, testChannel, signal).Generate()
+		require.NoError(t, err)
+		require.Len(t, channels, 4)
+
+		for i, ch := range channels {
+			require.Len(t, ch, len(expected))
+			if i == testChannel {
+				require.Equal(t, expected, ch)
+				continue
+			}
+			require.Equal(t, make([]float64, len(expected)), ch, "channel %d should be silent", i)
+		}
+	}
+}
+
+func TestChannelSweep_WriteInterleaved(t *testing.T) {
+	signal := sine.NewSine(1000.0, 10*time.Millisecond)
+	samples, err := signal.Generate()
+	require.NoError(t, err)
+
+	formats := []format.AudioFormat{format.PCM8{}, format.PCM16{}, format.Float32{}, format.Float64{}}
+	for _, f := range formats {
+		var buf bytes.Buffer
+		n, err := NewChannelSweep(2, 1, signal).WriteInterleaved(&buf, f)
+		require.NoError(t, err)
+
+		frameSize := 2 * f.BitDepth() / 8
+		require.Equal(t, int64(len(samples)*2*(f.BitDepth()/8)), n)
+		require.Equal(t, int(n), buf.Len())
+
+		// The left channel of the third frame is silent, the right one
+		// holds the signal.
+		frame := buf.Bytes()[3*frameSize : 4*frameSize]
+		require.Equal(t, f.ConvertSample(0), frame[:frameSize/2])
+		require.Equal(t, f.ConvertSample(samples[3]), frame[frameSize/2:])
+	}
+}
+
+func TestChannelSweep_Errors(t *testing.T) {
+	signal := sine.NewSine(1000.0, 10*time.Millisecond)
+
+	for _, sweep := range []*ChannelSweep{
+		NewChannelSweep(2, 2, signal),
+		NewChannelSweep(2, -1, signal),
+		NewChannelSweep(2, 0, nil),
+	} {
+		_, err := sweep.Generate()
+		require.ErrorIs(t, err, ErrInvalidTestChannel)
+
+		_, err = sweep.WriteInterleaved(&bytes.Buffer{}, format.PCM16{})
+		require.ErrorIs(t, err, ErrInvalidTestChannel)
+	}
+
+	_, err := NewChannelSweep(2, 0, sine.NewSine(30000, 10*time.Millisecond, sine.WithNyquistCheck())).Generate()
+	require.ErrorIs(t, err, sine.ErrNyquistViolation)
+}
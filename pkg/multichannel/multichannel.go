@@ -0,0 +1,69 @@
+package multichannel
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ECecillo/lib.go.sound/pkg/format"
+)
+
+// ErrChannelCountMismatch is returned when the channels given to
+// WriteInterleaved do not match the writer configuration or do not all hold
+// the same number of samples.
+var ErrChannelCountMismatch = errors.New("channel count or length mismatch")
+
+// MultichannelWriter encodes N channels into a single interleaved stream,
+// as used by surround and multi-microphone recordings.
+type MultichannelWriter struct {
+	Channels   int                // Number of channels per frame
+	SampleRate float64            // Sampling frequency in Hz shared by every channel
+	Format     format.AudioFormat // Encoding applied to every sample
+}
+
+// NewMultichannelWriter returns a writer for the given channel layout.
+func NewMultichannelWriter(channels int, sampleRate float64, f format.AudioFormat) *MultichannelWriter {
+	return &MultichannelWriter{
+		Channels:   channels,
+		SampleRate: sampleRate,
+		Format:     f,
+	}
+}
+
+// WriteInterleaved encodes one frame per sample index, each frame holding
+// one sample per channel in order:
+//
+//	ch0[0], ch1[0], …, chN[0], ch0[1], ch1[1], …
+func (m MultichannelWriter) WriteInterleaved(channels [][]float64, w io.Writer) (int64, error) {
+	if len(channels) != m.Channels {
+		return 0, ErrChannelCountMismatch
+	}
+
+	frames := 0
+	if len(channels) > 0 {
+		frames = len(channels[0])
+	}
+	for _, ch := range channels {
+		if len(ch) != frames {
+			return 0, ErrChannelCountMismatch
+		}
+	}
+
+	var totalBytesWritten int64
+	frame := make([]byte, 0, m.Channels*m.Format.BitDepth()/8)
+
+	for i := range frames {
+		frame = frame[:0]
+		for _, ch := range channels {
+			frame = append(frame, m.Format.ConvertSample(ch[i])...)
+		}
+
+		n, err := w.Write(frame)
+		totalBytesWritten += int64(n)
+		if err != nil {
+			return totalBytesWritten, fmt.Errorf("unable to write frame %d, err: %w", i, err)
+		}
+	}
+
+	return totalBytesWritten, nil
+}
@@ -0,0 +1,77 @@
+package multichannel
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"testing"
+
+	"github.com/ECecillo/lib.go.sound/pkg/format"
+	"github.com/stretchr/testify/require"
+)
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("write failed")
+}
+
+func TestWriteInterleaved_Order(t *testing.T) {
+	writer := NewMultichannelWriter(3, 48000.0, format.PCM16{})
+	channels := [][]float64{
+		{0.5, -0.5},
+		{0.25, -0.25},
+		{1.0, 0.0},
+	}
+
+	var buf bytes.Buffer
+	n, err := writer.WriteInterleaved(channels, &buf)
+	require.NoError(t, err)
+	require.Equal(t, int64(12), n)
+
+	data := buf.Bytes()
+	decoded := make([]int16, 6)
+	for i := range decoded {
+		decoded[i] = int16(binary.LittleEndian.Uint16(data[2*i:]))
+	}
+
+	require.Equal(t, []int16{16383, 8191, 32767, -16383, -8191, 0}, decoded)
+}
+
+func TestWriteInterleaved_MatchesFormat(t *testing.T) {
+	formats := []format.AudioFormat{format.PCM8{}, format.PCM16{}, format.PCM32{}, format.Float32{}, format.Float64{}}
+	channels := [][]float64{{0.1, 0.2}, {-0.3, 0.4}, {0.5, -0.6}, {0.7, 0.8}, {-0.9, 0}, {0, 0.05}}
+
+	for _, f := range formats {
+		writer := NewMultichannelWriter(len(channels), 44100.0, f)
+
+		var buf bytes.Buffer
+		_, err := writer.WriteInterleaved(channels, &buf)
+		require.NoError(t, err)
+
+		size := f.BitDepth() / 8
+		data := buf.Bytes()
+		require.Len(t, data, 2*len(channels)*size)
+
+		for frame := range 2 {
+			for ch := range channels {
+				offset := (frame*len(channels) + ch) * size
+				require.Equal(t, f.ConvertSample(channels[ch][frame]), data[offset:offset+size],
+					"%T frame %d channel %d", f, frame, ch)
+			}
+		}
+	}
+}
+
+func TestWriteInterleaved_Errors(t *testing.T) {
+	writer := NewMultichannelWriter(2, 44100.0, format.PCM16{})
+
+	_, err := writer.WriteInterleaved([][]float64{{0}}, &bytes.Buffer{})
+	require.ErrorIs(t, err, ErrChannelCountMismatch)
+
+	_, err = writer.WriteInterleaved([][]float64{{0, 1}, {0}}, &bytes.Buffer{})
+	require.ErrorIs(t, err, ErrChannelCountMismatch)
+
+	_, err = writer.WriteInterleaved([][]float64{{0}, {0}}, failingWriter{})
+	require.Error(t, err)
+}
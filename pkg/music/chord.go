@@ -0,0 +1,123 @@
+package music
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/ECecillo/lib.go.sound/pkg/mixer"
+	"github.com/ECecillo/lib.go.sound/pkg/sine"
+)
+
+// ErrInvalidTempo is returned when the tempo is not positive.
+var ErrInvalidTempo = errors.New("tempo must be positive")
+
+// ErrInvalidChord is returned by NewChord when the root frequency is not
+// positive or the chord type is unknown.
+var ErrInvalidChord = errors.New("invalid chord")
+
+// ChordType lists the intervals of a chord.
+type ChordType int
+
+const (
+	Major      ChordType = iota // Root, major third, perfect fifth
+	Minor                       // Root, minor third, perfect fifth
+	Diminished                  // Root, minor third, diminished fifth
+	Augmented                   // Root, major third, augmented fifth
+	Major7                      // Major triad with a major seventh
+	Minor7                      // Minor triad with a minor seventh
+	Dominant7                   // Major triad with a minor seventh
+	Sus2                        // Root, major second, perfect fifth
+	Sus4                        // Root, perfect fourth, perfect fifth
+)
+
+// chordIntervals holds the semitone offsets from the root of each chord.
+var chordIntervals = map[ChordType][]int{
+	Major:      {0, 4, 7},
+	Minor:      {0, 3, 7},
+	Diminished: {0, 3, 6},
+	Augmented:  {0, 4, 8},
+	Major7:     {0, 4, 7, 11},
+	Minor7:     {0, 3, 7, 10},
+	Dominant7:  {0, 4, 7, 10},
+	Sus2:       {0, 2, 7},
+	Sus4:       {0, 5, 7},
+}
+
+// Chord returns the frequencies of the notes of a chord built on root, in
+// ascending order. It returns nil when root cannot be parsed or chordType
+// is unknown.
+func Chord(root string, chordType ChordType) []float64 {
+	number, err := noteNumber(root)
+	if err != nil {
+		return nil
+	}
+
+	intervals, ok := chordIntervals[chordType]
+	if !ok {
+		return nil
+	}
+
+	frequencies := make([]float64, len(intervals))
+	for i, interval := range intervals {
+		frequencies[i] = frequencyOf(number + interval)
+	}
+
+	return frequencies
+}
+
+// NewChord returns a Mixer playing every note of a chord built on
+// rootFreq at once, each note being a sine.Sine of the given duration
+// configured with opts. The notes lie at rootFreq * 2^(interval/12) and are
+// mixed at equal gain 1/len(notes), so that the chord does not clip as long
+// as each note stays within full scale. The Mixer uses the format set by
+// opts.
+func NewChord(rootFreq float64, chordType ChordType, duration time.Duration, opts ...sine.Option) (*mixer.Mixer, error) {
+	intervals, ok := chordIntervals[chordType]
+	if !ok || rootFreq <= 0 {
+		return nil, fmt.Errorf("%w: type %d on %f Hz", ErrInvalidChord, chordType, rootFreq)
+	}
+
+	var m *mixer.Mixer
+	for _, interval := range intervals {
+		note := sine.NewSine(rootFreq*math.Pow(2, float64(interval)/12), duration, opts...)
+		if m == nil {
+			m = mixer.NewMixer(mixer.WithFormat(note.Format))
+		}
+		m.Add(note, 1/float64(len(intervals)))
+	}
+
+	return m, nil
+}
+
+// Arpeggio plays the notes of a chord one after the other, one beat apart
+// at tempo beats per minute, each note ringing until the end of duration.
+// The notes are mixed with a Mixer at equal gain so that the full chord
+// never clips.
+func Arpeggio(root string, chordType ChordType, tempo float64, duration time.Duration) ([]float64, error) {
+	if tempo <= 0 {
+		return nil, ErrInvalidTempo
+	}
+
+	frequencies := Chord(root, chordType)
+	if frequencies == nil {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidNote, root)
+	}
+
+	beat := time.Duration(float64(time.Minute) / tempo)
+	m := mixer.NewMixer()
+
+	for i, freq := range frequencies {
+		start := time.Duration(i) * beat
+		if start >= duration {
+			break
+		}
+
+		note := sine.NewSine(freq, duration-start)
+		offset := int(note.SamplingRate * start.Seconds())
+		m.AddAt(note, offset, 1/float64(len(frequencies)))
+	}
+
+	return m.Generate()
+}
@@ -0,0 +1,122 @@
+package music
+
+import (
+	"math"
+	"math/cmplx"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/ECecillo/lib.go.sound/pkg/analysis"
+	"github.com/ECecillo/lib.go.sound/pkg/format"
+	"github.com/ECecillo/lib.go.sound/pkg/sine"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChord(t *testing.T) {
+	c4, _ := NoteFrequency("C4")
+	e4, _ := NoteFrequency("E4")
+	g4, _ := NoteFrequency("G4")
+	bb4, _ := NoteFrequency("Bb4")
+
+	require.InDeltaSlice(t, []float64{c4, e4, g4}, Chord("C4", Major), 1e-9)
+	require.InDeltaSlice(t, []float64{c4, e4, g4, bb4}, Chord("C4", Dominant7), 1e-9)
+	require.Len(t, Chord("A3", Sus4), 3)
+	require.Nil(t, Chord("nope", Major))
+}
+
+func TestArpeggio(t *testing.T) {
+	// At 120 BPM the notes of the triad start 500ms apart.
+	samples, err := Arpeggio("C4", Major, 120.0, 2*time.Second)
+	require.NoError(t, err)
+	require.Len(t, samples, 88200)
+
+	rms := func(from, to int) float64 {
+		sum := 0.0
+		for _, v := range samples[from:to] {
+			sum += v * v
+		}
+		return math.Sqrt(sum / float64(to-from))
+	}
+
+	// Each new note adds energy to the mix.
+	first := rms(0, 22050)
+	second := rms(22050, 44100)
+	third := rms(44100, 66150)
+	require.Greater(t, second, first)
+	require.Greater(t, third, second)
+
+	for _, v := range samples {
+		require.LessOrEqual(t, math.Abs(v), 1.0)
+	}
+}
+
+func TestArpeggio_Errors(t *testing.T) {
+	_, err := Arpeggio("C4", Major, 0, time.Second)
+	require.ErrorIs(t, err, ErrInvalidTempo)
+
+	_, err = Arpeggio("Z9", Major, 120, time.Second)
+	require.ErrorIs(t, err, ErrInvalidNote)
+}
+
+func TestNewChord_CMajor(t *testing.T) {
+	c4, _ := NoteFrequency("C4")
+	e4, _ := NoteFrequency("E4")
+	g4, _ := NoteFrequency("G4")
+
+	m, err := NewChord(c4, Major, time.Second, sine.WithFormat(format.PCM32{}))
+	require.NoError(t, err)
+	require.Len(t, m.Tracks, 3)
+	require.IsType(t, format.PCM32{}, m.Format)
+
+	samples, err := m.Generate()
+	require.NoError(t, err)
+	require.Len(t, samples, 44100)
+
+	// Pick the three strongest peaks of the spectrum.
+	spectrum, err := analysis.FFT(samples[:32768])
+	require.NoError(t, err)
+	binWidth := 44100.0 / 32768
+
+	type peak struct {
+		freq, magnitude float64
+	}
+	var peaks []peak
+	for k := 1; k < len(spectrum)/2-1; k++ {
+		m := cmplx.Abs(spectrum[k])
+		if m > cmplx.Abs(spectrum[k-1]) && m > cmplx.Abs(spectrum[k+1]) {
+			peaks = append(peaks, peak{float64(k) * binWidth, m})
+		}
+	}
+	sort.Slice(peaks, func(i, j int) bool { return peaks[i].magnitude > peaks[j].magnitude })
+	require.GreaterOrEqual(t, len(peaks), 3)
+
+	found := []float64{peaks[0].freq, peaks[1].freq, peaks[2].freq}
+	sort.Float64s(found)
+	require.InDeltaSlice(t, []float64{c4, e4, g4}, found, 2*binWidth)
+}
+
+func TestNewChord_Normalized(t *testing.T) {
+	for _, chordType := range []ChordType{Major, Minor, Diminished, Augmented, Major7, Minor7, Dominant7, Sus2, Sus4} {
+		m, err := NewChord(220, chordType, 500*time.Millisecond)
+		require.NoError(t, err)
+
+		samples, err := m.Generate()
+		require.NoError(t, err)
+
+		peak := 0.0
+		for _, v := range samples {
+			peak = math.Max(peak, math.Abs(v))
+		}
+		require.LessOrEqual(t, peak, 1.0)
+		require.Greater(t, peak, 0.5)
+	}
+}
+
+func TestNewChord_Errors(t *testing.T) {
+	_, err := NewChord(0, Major, time.Second)
+	require.ErrorIs(t, err, ErrInvalidChord)
+
+	_, err = NewChord(440, ChordType(99), time.Second)
+	require.ErrorIs(t, err, ErrInvalidChord)
+}
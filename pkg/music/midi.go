@@ -0,0 +1,43 @@
+package music
+
+import (
+	"errors"
+	"math"
+)
+
+// ErrOutOfMIDIRange is returned when a note or frequency falls outside the
+// MIDI note numbers 0 to 127.
+var ErrOutOfMIDIRange = errors.New("outside of MIDI note range")
+
+// FrequencyToMIDI returns the MIDI note number closest to freq:
+//
+//	note = round(69 + 12*log2(freq/440))
+func FrequencyToMIDI(freq float64) (int, error) {
+	if freq <= 0 {
+		return 0, ErrOutOfMIDIRange
+	}
+
+	note := int(math.Round(69 + 12*math.Log2(freq/440)))
+	if note < 0 || note > 127 {
+		return 0, ErrOutOfMIDIRange
+	}
+
+	return note, nil
+}
+
+// MIDIToFrequency returns the equal-tempered frequency of a MIDI note
+// number with A4 (69) tuned to 440 Hz: 440 * 2^((note-69)/12).
+func MIDIToFrequency(note int) (float64, error) {
+	if note < 0 || note > 127 {
+		return 0, ErrOutOfMIDIRange
+	}
+
+	return frequencyOf(note), nil
+}
+
+// MIDIToFrequencyDetune works like MIDIToFrequency but shifts the note by
+// centOffset hundredths of a semitone, as done by a MIDI pitch bend or a
+// fine-tuning knob. The note is not range checked.
+func MIDIToFrequencyDetune(note int, centOffset float64) float64 {
+	return 440 * math.Pow(2, (float64(note-69)+centOffset/100)/12)
+}
@@ -0,0 +1,79 @@
+package music
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFrequencyToMIDI(t *testing.T) {
+	note, err := FrequencyToMIDI(440.0)
+	require.NoError(t, err)
+	require.Equal(t, 69, note)
+
+	// Slightly sharp or flat frequencies round to the nearest note.
+	note, err = FrequencyToMIDI(445.0)
+	require.NoError(t, err)
+	require.Equal(t, 69, note)
+
+	note, err = FrequencyToMIDI(261.0)
+	require.NoError(t, err)
+	require.Equal(t, 60, note)
+}
+
+func TestMIDIToFrequency(t *testing.T) {
+	freq, err := MIDIToFrequency(69)
+	require.NoError(t, err)
+	require.Equal(t, 440.0, freq)
+
+	freq, err = MIDIToFrequency(57)
+	require.NoError(t, err)
+	require.InDelta(t, 220.0, freq, 1e-9)
+
+	c4, err := NoteFrequency("C4")
+	require.NoError(t, err)
+	freq, err = MIDIToFrequency(60)
+	require.NoError(t, err)
+	require.Equal(t, c4, freq)
+}
+
+func TestMIDI_RoundTrip(t *testing.T) {
+	for note := range 128 {
+		freq, err := MIDIToFrequency(note)
+		require.NoError(t, err)
+
+		back, err := FrequencyToMIDI(freq)
+		require.NoError(t, err)
+		require.Equal(t, note, back)
+
+		roundTrip, err := MIDIToFrequency(back)
+		require.NoError(t, err)
+		require.InDelta(t, freq, roundTrip, 0.5)
+	}
+}
+
+func TestMIDI_OutOfRange(t *testing.T) {
+	for _, freq := range []float64{0, -440, 7.0, 13000} {
+		_, err := FrequencyToMIDI(freq)
+		require.ErrorIs(t, err, ErrOutOfMIDIRange, "frequency %f", freq)
+	}
+
+	for _, note := range []int{-1, 128} {
+		_, err := MIDIToFrequency(note)
+		require.ErrorIs(t, err, ErrOutOfMIDIRange, "note %d", note)
+	}
+}
+
+func TestMIDIToFrequencyDetune(t *testing.T) {
+	require.Equal(t, 440.0, MIDIToFrequencyDetune(69, 0))
+
+	// 100 cents is a semitone.
+	a4Sharp, err := MIDIToFrequency(70)
+	require.NoError(t, err)
+	require.InDelta(t, a4Sharp, MIDIToFrequencyDetune(69, 100), 1e-9)
+
+	// 1200 cents is an octave.
+	require.InDelta(t, 880.0, MIDIToFrequencyDetune(69, 1200), 1e-9)
+	require.InDelta(t, 440.0*math.Pow(2, -0.25/12), MIDIToFrequencyDetune(69, -25), 1e-9)
+}
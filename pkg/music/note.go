@@ -0,0 +1,62 @@
+package music
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// ErrInvalidNote is returned when a note name cannot be parsed.
+var ErrInvalidNote = errors.New("invalid note name")
+
+// semitones maps natural note letters to their offset from C.
+var semitones = map[byte]int{
+	'C': 0, 'D': 2, 'E': 4, 'F': 5, 'G': 7, 'A': 9, 'B': 11,
+}
+
+// NoteFrequency returns the equal-tempered frequency of a note written in
+// scientific pitch notation, such as "A4" (440 Hz), "C#3" or "Bb5".
+func NoteFrequency(name string) (float64, error) {
+	number, err := noteNumber(name)
+	if err != nil {
+		return 0, err
+	}
+	return frequencyOf(number), nil
+}
+
+// noteNumber parses a note name into its MIDI note number, where C4 is 60
+// and A4 is 69.
+func noteNumber(name string) (int, error) {
+	if len(name) < 2 {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidNote, name)
+	}
+
+	offset, ok := semitones[name[0]]
+	if !ok {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidNote, name)
+	}
+
+	rest := name[1:]
+	switch rest[0] {
+	case '#':
+		offset++
+		rest = rest[1:]
+	case 'b':
+		offset--
+		rest = rest[1:]
+	}
+
+	octave, err := strconv.Atoi(rest)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidNote, name)
+	}
+
+	return (octave+1)*12 + offset, nil
+}
+
+// frequencyOf returns the equal-tempered frequency of a MIDI note number
+// with A4 tuned to 440 Hz.
+func frequencyOf(number int) float64 {
+	return 440 * math.Pow(2, float64(number-69)/12)
+}
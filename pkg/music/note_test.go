@@ -0,0 +1,38 @@
+package music
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNoteFrequency(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected float64
+	}{
+		{name: "A4", expected: 440.0},
+		{name: "A3", expected: 220.0},
+		{name: "C4", expected: 261.6255653005986},
+		{name: "C#4", expected: 277.1826309768721},
+		{name: "Db4", expected: 277.1826309768721},
+		{name: "B3", expected: 246.94165062806206},
+		{name: "Cb4", expected: 246.94165062806206},
+		{name: "C-1", expected: 8.175798915643707},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			freq, err := NoteFrequency(tt.name)
+			require.NoError(t, err)
+			require.InDelta(t, tt.expected, freq, 1e-9)
+		})
+	}
+}
+
+func TestNoteFrequency_Invalid(t *testing.T) {
+	for _, name := range []string{"", "A", "H4", "C#", "Cx4", "c4"} {
+		_, err := NoteFrequency(name)
+		require.ErrorIs(t, err, ErrInvalidNote, "note %q", name)
+	}
+}
@@ -0,0 +1,68 @@
+package music
+
+// ScaleType lists the intervals of a scale.
+type ScaleType int
+
+const (
+	MajorScale           ScaleType = iota // Ionian: W W H W W W H
+	MinorScale                            // Natural minor (Aeolian): W H W W H W W
+	HarmonicMinorScale                    // Natural minor with a raised seventh
+	PentatonicScale                       // Major pentatonic
+	MinorPentatonicScale                  // Minor pentatonic
+	BluesScale                            // Minor pentatonic with a flat fifth
+	ChromaticScale                        // All twelve semitones
+)
+
+// scaleIntervals holds the semitone offsets from the root of each scale
+// within one octave.
+var scaleIntervals = map[ScaleType][]int{
+	MajorScale:           {0, 2, 4, 5, 7, 9, 11},
+	MinorScale:           {0, 2, 3, 5, 7, 8, 10},
+	HarmonicMinorScale:   {0, 2, 3, 5, 7, 8, 11},
+	PentatonicScale:      {0, 2, 4, 7, 9},
+	MinorPentatonicScale: {0, 3, 5, 7, 10},
+	BluesScale:           {0, 3, 5, 6, 7, 10},
+	ChromaticScale:       {0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11},
+}
+
+// Scale enumerates the notes of a scale over one or more octaves.
+type Scale struct {
+	Root      string    // Root note in scientific pitch notation, e.g. "C4"
+	ScaleType ScaleType // Intervals of the scale
+	Octaves   int       // Number of octaves covered (optional, default 1)
+}
+
+func NewScale(root string, scaleType ScaleType, octaves int) *Scale {
+	return &Scale{
+		Root:      root,
+		ScaleType: scaleType,
+		Octaves:   octaves,
+	}
+}
+
+// Frequencies returns the frequency of every note of the scale in
+// ascending order, starting at Root and stopping before the root of the
+// octave following the last one. It returns nil when Root cannot be parsed
+// or ScaleType is unknown.
+func (s Scale) Frequencies() []float64 {
+	root, err := noteNumber(s.Root)
+	if err != nil {
+		return nil
+	}
+
+	intervals, ok := scaleIntervals[s.ScaleType]
+	if !ok {
+		return nil
+	}
+
+	octaves := max(s.Octaves, 1)
+	frequencies := make([]float64, 0, octaves*len(intervals))
+
+	for octave := range octaves {
+		for _, interval := range intervals {
+			frequencies = append(frequencies, frequencyOf(root+12*octave+interval))
+		}
+	}
+
+	return frequencies
+}
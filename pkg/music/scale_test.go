@@ -0,0 +1,33 @@
+package music
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestScale_CMajor(t *testing.T) {
+	scale := NewScale("C4", MajorScale, 1)
+
+	var expected []float64
+	for _, name := range []string{"C4", "D4", "E4", "F4", "G4", "A4", "B4"} {
+		freq, err := NoteFrequency(name)
+		require.NoError(t, err)
+		expected = append(expected, freq)
+	}
+
+	require.InDeltaSlice(t, expected, scale.Frequencies(), 1e-9)
+}
+
+func TestScale_Octaves(t *testing.T) {
+	scale := NewScale("A3", MinorPentatonicScale, 3)
+	frequencies := scale.Frequencies()
+
+	require.Len(t, frequencies, 15)
+	for i := 5; i < len(frequencies); i++ {
+		require.InDelta(t, 2*frequencies[i-5], frequencies[i], 1e-9, "each octave doubles the frequency")
+	}
+
+	require.Len(t, NewScale("C4", ChromaticScale, 0).Frequencies(), 12, "zero octaves default to one")
+	require.Nil(t, NewScale("X4", MajorScale, 1).Frequencies())
+}
@@ -0,0 +1,127 @@
+package noise
+
+import (
+	"math"
+	"math/rand/v2"
+
+	"github.com/ECecillo/lib.go.sound/pkg/filter"
+)
+
+// breathSeed keeps the breath noise reproducible between runs.
+const breathSeed = 0xb4ea
+
+// defaultBreathCenter is the band center in Hz used when the fundamental
+// of the signal cannot be estimated.
+const defaultBreathCenter = 1500.0
+
+// breathBandwidth is the width of the noise band in octaves.
+const breathBandwidth = 2.0
+
+// BreathNoise adds the breathy component of a wind instrument to a tonal
+// signal.
+type BreathNoise struct {
+	Breathiness float64 // Amount of noise, from 0 (none) to 1
+	Fundamental float64 // Pitch of the signal in Hz, estimated when 0
+}
+
+// NewBreathNoise returns a BreathNoise that estimates the fundamental of
+// the signals it processes.
+func NewBreathNoise(breathiness float64) *BreathNoise {
+	return &BreathNoise{Breathiness: breathiness}
+}
+
+// ApplyBreathiness is a shortcut for NewBreathNoise(breathiness).Apply.
+func ApplyBreathiness(signal []float64, breathiness float64, sampleRate float64) []float64 {
+	return NewBreathNoise(breathiness).Apply(signal, sampleRate)
+}
+
+// Apply returns signal with band-pass filtered white noise added. The band
+// is two octaves wide, centered at three times the fundamental. The noise
+// is scaled so that, being uncorrelated with the signal, it raises the RMS
+// level by the Breathiness ratio:
+//
+//	rms(noise) = rms(signal) * sqrt((1 + b)² - 1)
+//
+// Breathiness is clamped to [0, 1]. Silent signals are returned unchanged.
+func (b BreathNoise) Apply(signal []float64, sampleRate float64) []float64 {
+	result := append([]float64(nil), signal...)
+
+	breathiness := math.Max(0, math.Min(1, b.Breathiness))
+	level := rms(signal)
+	if breathiness == 0 || level == 0 || sampleRate <= 0 {
+		return result
+	}
+
+	fundamental := b.Fundamental
+	if fundamental <= 0 {
+		fundamental = estimateFundamental(signal, sampleRate)
+	}
+	center := defaultBreathCenter
+	if fundamental > 0 {
+		center = 3 * fundamental
+	}
+	center = math.Min(center, 0.4*sampleRate)
+
+	rng := rand.New(rand.NewPCG(breathSeed, breathSeed))
+	white := make([]float64, len(signal))
+	for i := range white {
+		white[i] = rng.Float64()*2 - 1
+	}
+	band := newBandPass(center, breathBandwidth, sampleRate).Process(white)
+
+	bandLevel := rms(band)
+	if bandLevel == 0 {
+		return result
+	}
+
+	gain := level * math.Sqrt((1+breathiness)*(1+breathiness)-1) / bandLevel
+	for i, v := range band {
+		result[i] += gain * v
+	}
+
+	return result
+}
+
+// newBandPass returns a constant 0 dB peak gain band-pass biquad from the
+// Audio EQ Cookbook, with its bandwidth given in octaves.
+func newBandPass(center, octaves, sampleRate float64) *filter.Biquad {
+	w0 := 2 * math.Pi * center / sampleRate
+	alpha := math.Sin(w0) * math.Sinh(math.Ln2/2*octaves*w0/math.Sin(w0))
+	a0 := 1 + alpha
+
+	return filter.NewBiquad(alpha/a0, 0, -alpha/a0, -2*math.Cos(w0)/a0, (1-alpha)/a0)
+}
+
+// estimateFundamental counts the upward zero crossings of signal and
+// returns their rate in Hz, or 0 when there are fewer than two.
+func estimateFundamental(signal []float64, sampleRate float64) float64 {
+	first, last, count := -1, -1, 0
+
+	for i := 1; i < len(signal); i++ {
+		if signal[i-1] < 0 && signal[i] >= 0 {
+			if first < 0 {
+				first = i
+			}
+			last = i
+			count++
+		}
+	}
+
+	if count < 2 {
+		return 0
+	}
+	return float64(count-1) * sampleRate / float64(last-first)
+}
+
+// rms returns the root mean square level of samples.
+func rms(samples []float64) float64 {
+	if len(samples) == 0 {
+		return 0
+	}
+
+	var sum float64
+	for _, v := range samples {
+		sum += v * v
+	}
+	return math.Sqrt(sum / float64(len(samples)))
+}
@@ -0,0 +1,85 @@
+package noise
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/ECecillo/lib.go.sound/pkg/sine"
+	"github.com/stretchr/testify/require"
+)
+
+const sampleRate = 44100.0
+
+func tone(t *testing.T, freq float64) []float64 {
+	t.Helper()
+	samples, err := sine.NewSine(freq, 500*time.Millisecond, sine.WithAmplitude(0.5)).Generate()
+	require.NoError(t, err)
+	return samples
+}
+
+func TestApplyBreathiness_Zero(t *testing.T) {
+	signal := tone(t, 440)
+	require.Equal(t, signal, ApplyBreathiness(signal, 0, sampleRate))
+}
+
+func TestApplyBreathiness_RaisesRMS(t *testing.T) {
+	signal := tone(t, 440)
+
+	tests := []struct {
+		breathiness float64
+		ratio       float64
+	}{
+		{breathiness: 0.25, ratio: 1.25},
+		{breathiness: 0.5, ratio: 1.5},
+		{breathiness: 1, ratio: 2},
+		{breathiness: 3, ratio: 2},
+	}
+
+	for _, tt := range tests {
+		result := ApplyBreathiness(signal, tt.breathiness, sampleRate)
+		require.Len(t, result, len(signal))
+		require.InDelta(t, tt.ratio, rms(result)/rms(signal), 0.05, "breathiness %v", tt.breathiness)
+	}
+}
+
+func TestApplyBreathiness_Deterministic(t *testing.T) {
+	signal := tone(t, 440)
+	require.Equal(t, ApplyBreathiness(signal, 0.5, sampleRate), ApplyBreathiness(signal, 0.5, sampleRate))
+}
+
+func TestBreathNoise_FundamentalOverride(t *testing.T) {
+	signal := tone(t, 440)
+
+	estimated := NewBreathNoise(0.5).Apply(signal, sampleRate)
+	explicit := BreathNoise{Breathiness: 0.5, Fundamental: 440}.Apply(signal, sampleRate)
+	for i := range estimated {
+		require.InDelta(t, estimated[i], explicit[i], 1e-3)
+	}
+
+	other := BreathNoise{Breathiness: 0.5, Fundamental: 2000}.Apply(signal, sampleRate)
+	require.NotEqual(t, estimated, other)
+}
+
+func TestApplyBreathiness_Silence(t *testing.T) {
+	silence := make([]float64, 1000)
+	require.Equal(t, silence, ApplyBreathiness(silence, 0.5, sampleRate))
+}
+
+func TestEstimateFundamental(t *testing.T) {
+	for _, freq := range []float64{110, 440, 1234} {
+		require.InDelta(t, freq, estimateFundamental(tone(t, freq), sampleRate), 1.0)
+	}
+	require.Zero(t, estimateFundamental(make([]float64, 100), sampleRate))
+}
+
+func TestNewBandPass(t *testing.T) {
+	center := 1320.0
+	bp := newBandPass(center, breathBandwidth, sampleRate)
+
+	require.InDelta(t, 1.0, bp.MagnitudeResponse(center, sampleRate), 1e-9)
+	// The band edges sit an octave either side of the center.
+	require.InDelta(t, -3.0, 20*math.Log10(bp.MagnitudeResponse(center*2, sampleRate)), 0.5)
+	require.InDelta(t, -3.0, 20*math.Log10(bp.MagnitudeResponse(center/2, sampleRate)), 0.5)
+	require.Less(t, bp.MagnitudeResponse(center*8, sampleRate), 0.3)
+}
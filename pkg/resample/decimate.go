@@ -0,0 +1,71 @@
+package resample
+
+import (
+	"errors"
+
+	"github.com/ECecillo/lib.go.sound/pkg/filter"
+)
+
+var (
+	// ErrInvalidFactor is returned when a resampling factor is below 1.
+	ErrInvalidFactor = errors.New("factor must be at least 1")
+	// ErrInvalidFilterLength is returned when a filter has no taps.
+	ErrInvalidFilterLength = errors.New("filter length must be positive")
+)
+
+// decimationRolloff places the anti-aliasing cutoff as a fraction of the
+// output sample rate, leaving a transition band below the output Nyquist.
+const decimationRolloff = 0.45
+
+// Decimate lowers the sample rate of samples by factor with a polyphase
+// FIR. The Hann-windowed sinc prototype h of filterLen taps has its cutoff
+// at 0.45 times the output rate and is split into factor phases
+//
+//	e[p][k] = h[p + k*factor]
+//
+// Phase p only ever meets the inputs x[m*factor - p - k*factor], so each
+// output sample is the sum of the factor phases run over their own
+// subsampled stream:
+//
+//	y[m] = Σ_p Σ_k e[p][k] * x[m*factor - p - k*factor]
+//
+// and the samples that would be dropped are never filtered. The filter
+// delay is compensated: output sample m lines up with input sample
+// m*factor. The output holds ceil(len(samples)/factor) samples and a
+// factor of 1 returns a copy of samples.
+func Decimate(samples []float64, factor int, filterLen int) ([]float64, error) {
+	if factor < 1 {
+		return nil, ErrInvalidFactor
+	}
+	if filterLen < 1 {
+		return nil, ErrInvalidFilterLength
+	}
+	if factor == 1 {
+		return append([]float64(nil), samples...), nil
+	}
+
+	prototype := filter.NewFIRLowPass(decimationRolloff/float64(factor), 1, filterLen)
+	phases := make([][]float64, factor)
+	for j, h := range prototype {
+		phases[j%factor] = append(phases[j%factor], h)
+	}
+
+	delay := (filterLen - 1) / 2
+
+	result := make([]float64, (len(samples)+factor-1)/factor)
+	for m := range result {
+		center := m*factor + delay
+
+		var y float64
+		for p, phase := range phases {
+			for k, h := range phase {
+				if i := center - p - k*factor; i >= 0 && i < len(samples) {
+					y += h * samples[i]
+				}
+			}
+		}
+		result[m] = y
+	}
+
+	return result, nil
+}
@@ -0,0 +1,89 @@
+package resample
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ECecillo/lib.go.sound/pkg/analysis"
+	"github.com/ECecillo/lib.go.sound/pkg/filter"
+)
+
+func TestDecimate_Length(t *testing.T) {
+	for _, tt := range []struct {
+		length, factor, want int
+	}{
+		{length: 1000, factor: 2, want: 500},
+		{length: 1001, factor: 2, want: 501},
+		{length: 1000, factor: 3, want: 334},
+		{length: 7, factor: 8, want: 1},
+		{length: 0, factor: 4, want: 0},
+	} {
+		output, err := Decimate(make([]float64, tt.length), tt.factor, 63)
+		require.NoError(t, err)
+		require.Len(t, output, tt.want, "length %d, factor %d", tt.length, tt.factor)
+	}
+}
+
+func TestDecimate_KeepsFrequency(t *testing.T) {
+	output, err := Decimate(sineWave(440, 44100, 44100), 2, 127)
+	require.NoError(t, err)
+
+	peak, err := analysis.PeakFrequency(output, 22050)
+	require.NoError(t, err)
+	require.InDelta(t, 440.0, peak, 1.0)
+
+	// The passband is left at unity gain.
+	require.InDelta(t, rms(sineWave(440, 44100, 44100), 256), rms(output, 128), 0.01)
+}
+
+func TestDecimate_RejectsAliases(t *testing.T) {
+	// 20 kHz would fold down to 2.05 kHz at 22.05 kHz without filtering.
+	output, err := Decimate(sineWave(20000, 44100, 8192), 2, 127)
+	require.NoError(t, err)
+	require.Less(t, rms(output, 128), 0.001)
+}
+
+func TestDecimate_MatchesDirectFiltering(t *testing.T) {
+	input := sineWave(1000, 44100, 2048)
+	for n := range input {
+		input[n] += 0.3 * sineWave(7300, 44100, 2048)[n]
+	}
+
+	output, err := Decimate(input, 3, 64)
+	require.NoError(t, err)
+
+	// Filtering at full rate and keeping every third sample, with the same
+	// delay compensation, gives the same result.
+	taps := filter.NewFIRLowPass(0.45/3, 1, 64)
+	filtered := filter.ApplyFIR(taps, append(input, make([]float64, 64)...))
+	for m, v := range output {
+		require.InDelta(t, filtered[3*m+31], v, 1e-12, "sample %d", m)
+	}
+}
+
+func TestDecimator_UsesDecimate(t *testing.T) {
+	input := sineWave(1000, 48000, 4800)
+
+	for _, factor := range []int{2, 3, 4} {
+		expected, err := Decimate(input, factor, tapsPerFactor*factor+1)
+		require.NoError(t, err)
+		require.Equal(t, expected, Decimator{Factor: factor}.Process(input), "factor %d", factor)
+	}
+}
+
+func TestDecimate_FactorOne(t *testing.T) {
+	input := sineWave(440, 44100, 100)
+
+	output, err := Decimate(input, 1, 63)
+	require.NoError(t, err)
+	require.Equal(t, input, output)
+}
+
+func TestDecimate_Errors(t *testing.T) {
+	_, err := Decimate(make([]float64, 10), 0, 63)
+	require.ErrorIs(t, err, ErrInvalidFactor)
+
+	_, err = Decimate(make([]float64, 10), 2, 0)
+	require.ErrorIs(t, err, ErrInvalidFilterLength)
+}
@@ -0,0 +1,121 @@
+package resample
+
+import (
+	"errors"
+
+	"github.com/ECecillo/lib.go.sound/pkg/filter"
+)
+
+// ErrInvalidRate is returned when a sample rate is not a positive whole
+// number of Hz.
+var ErrInvalidRate = errors.New("sample rates must be positive integers")
+
+// polyphaseRolloff places the prototype cutoff as a fraction of the lower
+// Nyquist frequency.
+const polyphaseRolloff = 0.9
+
+// PolyphaseResampler converts a signal between two sample rates whose
+// ratio is rational:
+//
+//	L/M = ToRate/FromRate,  L = ToRate/gcd,  M = FromRate/gcd
+//
+// Conceptually the signal is upsampled by L with zeros, low-pass filtered
+// and decimated by M. The prototype FIR is split into L phases of
+// TapsPerPhase taps so that each output sample only costs TapsPerPhase
+// multiplications and no intermediate signal is stored.
+type PolyphaseResampler struct {
+	FromRate     float64 // Input sampling frequency in Hz
+	ToRate       float64 // Output sampling frequency in Hz
+	TapsPerPhase int     // Length of each polyphase branch
+
+	up, down int         // L and M
+	phases   [][]float64 // phases[p][k] = L * h[p + k*L]
+}
+
+// NewPolyphaseResampler returns a resampler from fromRate to toRate whose
+// prototype filter holds tapsPerPhase taps per output phase. Longer
+// filters give a sharper transition band at a higher cost.
+func NewPolyphaseResampler(fromRate, toRate float64, tapsPerPhase int) (*PolyphaseResampler, error) {
+	r := &PolyphaseResampler{
+		FromRate:     fromRate,
+		ToRate:       toRate,
+		TapsPerPhase: tapsPerPhase,
+	}
+	if err := r.prepare(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Resample converts samples from FromRate to ToRate. The output holds
+// ceil(len(samples)*L/M) samples and the filter delay is compensated, so
+// output sample m lines up with input time m/ToRate.
+func (r *PolyphaseResampler) Resample(samples []float64) ([]float64, error) {
+	if err := r.prepare(); err != nil {
+		return nil, err
+	}
+
+	// The prototype holds up*TapsPerPhase+1 taps, hence a delay of half
+	// that length minus one in the upsampled domain.
+	delay := r.up * len(r.phases[0]) / 2
+
+	result := make([]float64, (len(samples)*r.up+r.down-1)/r.down)
+	for m := range result {
+		// Position in the upsampled signal, shifted by the filter delay.
+		n := m*r.down + delay
+		phase := r.phases[n%r.up]
+		base := n / r.up
+
+		var y float64
+		for k, h := range phase {
+			if i := base - k; i >= 0 && i < len(samples) {
+				y += h * samples[i]
+			}
+		}
+		result[m] = y
+	}
+
+	return result, nil
+}
+
+// prepare validates the rates and builds the polyphase filter bank when
+// the configuration changed since the last call.
+func (r *PolyphaseResampler) prepare() error {
+	from, to := int(r.FromRate), int(r.ToRate)
+	if from <= 0 || to <= 0 || float64(from) != r.FromRate || float64(to) != r.ToRate || r.TapsPerPhase <= 0 {
+		return ErrInvalidRate
+	}
+
+	g := gcd(from, to)
+	up, down := to/g, from/g
+	if r.phases != nil && r.up == up && r.down == down && len(r.phases[0]) == r.TapsPerPhase {
+		return nil
+	}
+
+	// The cutoff sits just below the Nyquist frequency of the lower rate,
+	// expressed relative to the upsampled rate, so that the transition band
+	// is over before the aliases start. An odd length keeps the delay whole.
+	cutoff := polyphaseRolloff * 0.5 / float64(max(up, down))
+	prototype := filter.NewFIRLowPass(cutoff, 1, up*r.TapsPerPhase+1)
+
+	phases := make([][]float64, up)
+	for p := range phases {
+		phases[p] = make([]float64, r.TapsPerPhase)
+		for k := range phases[p] {
+			if idx := p + k*up; idx < len(prototype) {
+				phases[p][k] = float64(up) * prototype[idx]
+			}
+		}
+	}
+
+	r.up, r.down, r.phases = up, down, phases
+	return nil
+}
+
+// gcd returns the greatest common divisor of two positive integers.
+func gcd(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
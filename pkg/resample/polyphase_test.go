@@ -0,0 +1,89 @@
+package resample
+
+import (
+	"testing"
+
+	"github.com/ECecillo/lib.go.sound/pkg/sine"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPolyphaseResampler_Frequency(t *testing.T) {
+	tests := []struct {
+		from, to float64
+	}{
+		{from: 44100, to: 48000},
+		{from: 48000, to: 44100},
+		{from: 44100, to: 22050},
+		{from: 8000, to: 44100},
+	}
+
+	for _, tt := range tests {
+		resampler, err := NewPolyphaseResampler(tt.from, tt.to, 32)
+		require.NoError(t, err)
+
+		input := sineWave(440, tt.from, int(tt.from))
+		output, err := resampler.Resample(input)
+		require.NoError(t, err)
+		require.InDelta(t, tt.to, float64(len(output)), 1, "%v -> %v", tt.from, tt.to)
+
+		// Skip the edges where the filter sees the signal boundaries.
+		margin := int(tt.to / 100)
+		body := output[margin : len(output)-margin]
+		measured, err := sine.MeasureFrequency(body, tt.to)
+		require.NoError(t, err)
+		require.InDelta(t, 440.0, measured, 0.5, "%v -> %v", tt.from, tt.to)
+		require.InDelta(t, 1/1.4142135, rms(output, margin), 0.01, "%v -> %v level", tt.from, tt.to)
+	}
+}
+
+func TestPolyphaseResampler_Alignment(t *testing.T) {
+	resampler, err := NewPolyphaseResampler(44100, 48000, 32)
+	require.NoError(t, err)
+
+	input := sineWave(1000, 44100, 44100)
+	output, err := resampler.Resample(input)
+	require.NoError(t, err)
+
+	// With the delay compensated, the output follows the same sine sampled
+	// at the new rate.
+	expected := sineWave(1000, 48000, len(output))
+	for m := 1000; m < len(output)-1000; m++ {
+		require.InDelta(t, expected[m], output[m], 1e-2, "sample %d", m)
+	}
+}
+
+func TestPolyphaseResampler_RejectsAliases(t *testing.T) {
+	resampler, err := NewPolyphaseResampler(48000, 44100, 32)
+	require.NoError(t, err)
+
+	// 23 kHz is above the 22.05 kHz output Nyquist frequency and must be
+	// filtered out rather than folded back to 21.1 kHz.
+	output, err := resampler.Resample(sineWave(23000, 48000, 48000))
+	require.NoError(t, err)
+	require.Less(t, rms(output, 1000), 0.1)
+}
+
+func TestNewPolyphaseResampler_Errors(t *testing.T) {
+	for _, rates := range [][2]float64{{0, 48000}, {44100, -1}, {44100.5, 48000}} {
+		_, err := NewPolyphaseResampler(rates[0], rates[1], 32)
+		require.ErrorIs(t, err, ErrInvalidRate)
+	}
+
+	_, err := NewPolyphaseResampler(44100, 48000, 0)
+	require.ErrorIs(t, err, ErrInvalidRate)
+
+	r := &PolyphaseResampler{FromRate: 44100, ToRate: 48000}
+	_, err = r.Resample([]float64{1})
+	require.ErrorIs(t, err, ErrInvalidRate)
+}
+
+func BenchmarkPolyphaseResampler_44100To48000(b *testing.B) {
+	resampler, err := NewPolyphaseResampler(44100, 48000, 32)
+	require.NoError(b, err)
+	input := sineWave(440, 44100, 44100)
+
+	for b.Loop() {
+		_, _ = resampler.Resample(input)
+	}
+	b.ReportMetric(float64(b.N*len(input))/b.Elapsed().Seconds(), "samples/s")
+}
@@ -0,0 +1,72 @@
+package resample
+
+import (
+	"github.com/ECecillo/lib.go.sound/pkg/filter"
+)
+
+// tapsPerFactor sets the length of the anti-aliasing FIR relative to the
+// resampling factor, keeping the transition band a constant fraction of
+// the reduced bandwidth.
+const tapsPerFactor = 32
+
+// Decimator lowers the sample rate of a signal by an integer Factor.
+type Decimator struct {
+	Factor int
+}
+
+// Interpolator raises the sample rate of a signal by an integer Factor.
+type Interpolator struct {
+	Factor int
+}
+
+// Process runs Decimate with a filter of tapsPerFactor*Factor+1 taps: the
+// samples are low-pass filtered below the reduced Nyquist frequency to
+// prevent aliasing and every Factor-th sample is kept. Only the kept
+// samples are computed, so the cost is a fraction 1/Factor of filtering at
+// full rate. The filter delay is compensated: output sample m lines up with
+// input sample m*Factor. A Factor below 2 returns a copy of samples.
+func (d Decimator) Process(samples []float64) []float64 {
+	if d.Factor < 2 {
+		return append([]float64(nil), samples...)
+	}
+
+	// The factor and the filter length are valid, so Decimate cannot fail.
+	result, _ := Decimate(samples, d.Factor, tapsPerFactor*d.Factor+1)
+	return result
+}
+
+// Process inserts Factor-1 zeros between samples and low-pass filters the
+// result at the original Nyquist frequency, scaled by Factor to restore
+// the signal level. The output holds len(samples)*Factor samples aligned
+// with the input. A Factor below 2 returns a copy of samples.
+func (i Interpolator) Process(samples []float64) []float64 {
+	if i.Factor < 2 {
+		return append([]float64(nil), samples...)
+	}
+
+	taps := antiAliasing(i.Factor)
+	delay := (len(taps) - 1) / 2
+
+	result := make([]float64, len(samples)*i.Factor)
+	for n := range result {
+		center := n + delay
+
+		// Only every Factor-th input of the zero-stuffed signal is non-zero,
+		// so start at the first tap that lands on one.
+		var y float64
+		for k := center % i.Factor; k < len(taps); k += i.Factor {
+			if idx := center - k; idx >= 0 && idx/i.Factor < len(samples) {
+				y += taps[k] * samples[idx/i.Factor]
+			}
+		}
+		result[n] = y * float64(i.Factor)
+	}
+
+	return result
+}
+
+// antiAliasing returns the low-pass FIR of Interpolator, with its cutoff at
+// the Nyquist frequency of the lower sample rate.
+func antiAliasing(factor int) []float64 {
+	return filter.NewFIRLowPass(0.5/float64(factor), 1, tapsPerFactor*factor+1)
+}
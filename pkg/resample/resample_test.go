@@ -0,0 +1,96 @@
+package resample
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ECecillo/lib.go.sound/pkg/filter"
+)
+
+// sineWave returns n samples of a unit sine at freq.
+func sineWave(freq, sampleRate float64, n int) []float64 {
+	samples := make([]float64, n)
+	for i := range samples {
+		samples[i] = math.Sin(2 * math.Pi * freq * float64(i) / sampleRate)
+	}
+	return samples
+}
+
+// rms returns the RMS of samples, ignoring margin samples at each end
+// where the filters see the edges of the signal.
+func rms(samples []float64, margin int) float64 {
+	var sum float64
+	body := samples[margin : len(samples)-margin]
+	for _, v := range body {
+		sum += v * v
+	}
+	return math.Sqrt(sum / float64(len(body)))
+}
+
+func TestRoundTrip(t *testing.T) {
+	sampleRate := 44100.0
+
+	for _, freq := range []float64{100, 1000, 5000, 8000} {
+		input := sineWave(freq, sampleRate, 8192)
+
+		decimated := Decimator{Factor: 2}.Process(input)
+		require.Len(t, decimated, 4096)
+
+		output := Interpolator{Factor: 2}.Process(decimated)
+		require.Len(t, output, len(input))
+
+		gainDB := 20 * math.Log10(rms(output, 256)/rms(input, 256))
+		require.InDelta(t, 0.0, gainDB, 1.0, "frequency %.0f Hz", freq)
+
+		// The filter delays are compensated, so the waveforms line up.
+		for n := 256; n < len(input)-256; n++ {
+			require.InDelta(t, input[n], output[n], 0.12, "frequency %.0f Hz, sample %d", freq, n)
+		}
+	}
+}
+
+func TestDecimator_RejectsAliases(t *testing.T) {
+	sampleRate := 44100.0
+
+	// 18 kHz would fold down to 4.05 kHz at 22.05 kHz without filtering.
+	output := Decimator{Factor: 2}.Process(sineWave(18000, sampleRate, 8192))
+	require.Less(t, rms(output, 128), 0.01)
+}
+
+func TestDecimator_KeepsEveryFactorSample(t *testing.T) {
+	input := make([]float64, 400)
+	for n := range input {
+		input[n] = 1
+	}
+
+	output := Decimator{Factor: 4}.Process(input)
+	require.Len(t, output, 100)
+	require.InDelta(t, 1.0, output[50], 1e-9)
+}
+
+func TestFactorBelowTwo(t *testing.T) {
+	input := []float64{1, 2, 3}
+
+	require.Equal(t, input, Decimator{Factor: 1}.Process(input))
+	require.Equal(t, input, Interpolator{Factor: 0}.Process(input))
+}
+
+func BenchmarkFullRate(b *testing.B) {
+	input := sineWave(1000, 44100, 44100)
+	taps := antiAliasing(4)
+
+	for b.Loop() {
+		filter.ApplyFIR(taps, input)
+	}
+}
+
+func BenchmarkDecimator_Factor4(b *testing.B) {
+	input := sineWave(1000, 44100, 44100)
+	decimator := Decimator{Factor: 4}
+
+	for b.Loop() {
+		decimator.Process(input)
+	}
+}
@@ -0,0 +1,80 @@
+package sequencer
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ECecillo/lib.go.sound/pkg/format"
+	"github.com/ECecillo/lib.go.sound/pkg/silence"
+	"github.com/ECecillo/lib.go.sound/pkg/sine"
+)
+
+// fadeTime is the length of the fade-in and fade-out applied to every note
+// to avoid clicks at its edges.
+const fadeTime = 5 * time.Millisecond
+
+// Step is one note of a Sequencer pattern.
+type Step struct {
+	FreqHz         float64       // Pitch of the note in Hz
+	DurationMs     int           // Length of the note in milliseconds
+	AmplitudeScale float64       // Peak amplitude of the note, relative to full scale
+	Gap            time.Duration // Silence inserted after the note
+}
+
+// Sequencer renders a list of steps one after the other.
+type Sequencer struct {
+	Steps      []Step
+	SampleRate float64
+}
+
+// NewSequencer creates a Sequencer.
+func NewSequencer(sampleRate float64, steps ...Step) *Sequencer {
+	return &Sequencer{
+		Steps:      steps,
+		SampleRate: sampleRate,
+	}
+}
+
+// Render generates every step as a sine.Sine in format f, shapes it with a
+// linear fade-in and fade-out of fadeTime, follows it with its Gap of
+// silence and concatenates the result.
+func (s Sequencer) Render(f format.AudioFormat) ([]float64, error) {
+	var result []float64
+
+	for i, step := range s.Steps {
+		note := sine.NewSine(
+			step.FreqHz,
+			time.Duration(step.DurationMs)*time.Millisecond,
+			sine.WithAmplitude(step.AmplitudeScale),
+			sine.WithSampleRate(s.SampleRate),
+			sine.WithFormat(f),
+		)
+
+		samples, err := note.Generate()
+		if err != nil {
+			return nil, fmt.Errorf("unable to generate step %d, err: %w", i, err)
+		}
+		s.fade(samples)
+		result = append(result, samples...)
+
+		gap, err := silence.NewSilence(step.Gap, silence.WithSamplingRate(s.SampleRate), silence.WithFormat(f)).Generate()
+		if err != nil {
+			return nil, fmt.Errorf("unable to generate gap after step %d, err: %w", i, err)
+		}
+		result = append(result, gap...)
+	}
+
+	return result, nil
+}
+
+// fade applies the fade-in and fade-out in place. Notes shorter than two
+// fades are faded over half their length on each side.
+func (s Sequencer) fade(samples []float64) {
+	length := min(int(fadeTime.Seconds()*s.SampleRate), len(samples)/2)
+
+	for i := range length {
+		gain := float64(i) / float64(length)
+		samples[i] *= gain
+		samples[len(samples)-1-i] *= gain
+	}
+}
@@ -0,0 +1,78 @@
+package sequencer
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/ECecillo/lib.go.sound/pkg/format"
+	"github.com/ECecillo/lib.go.sound/pkg/sine"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRender_Length(t *testing.T) {
+	sampleRate := 44100.0
+	seq := NewSequencer(sampleRate,
+		Step{FreqHz: 440, DurationMs: 200, AmplitudeScale: 1, Gap: 50 * time.Millisecond},
+		Step{FreqHz: 550, DurationMs: 100, AmplitudeScale: 0.5},
+		Step{FreqHz: 660, DurationMs: 300, AmplitudeScale: 0.25, Gap: 10 * time.Millisecond},
+	)
+
+	samples, err := seq.Render(format.PCM16{})
+	require.NoError(t, err)
+
+	expected := 0
+	for _, step := range seq.Steps {
+		expected += int(sampleRate*float64(step.DurationMs)/1000) + int(sampleRate*step.Gap.Seconds())
+	}
+	require.Len(t, samples, expected)
+}
+
+func TestRender_SegmentPeaks(t *testing.T) {
+	sampleRate := 8000.0
+	seq := NewSequencer(sampleRate,
+		Step{FreqHz: 400, DurationMs: 100, AmplitudeScale: 0.8, Gap: 25 * time.Millisecond},
+		Step{FreqHz: 500, DurationMs: 100, AmplitudeScale: 0.3, Gap: 25 * time.Millisecond},
+	)
+
+	samples, err := seq.Render(format.Float32{})
+	require.NoError(t, err)
+
+	peak := func(segment []float64) float64 {
+		p := 0.0
+		for _, v := range segment {
+			p = math.Max(p, math.Abs(v))
+		}
+		return p
+	}
+
+	// Each note is 800 samples followed by 200 samples of silence.
+	require.InDelta(t, 0.8, peak(samples[0:800]), 1e-3)
+	require.Zero(t, peak(samples[800:1000]))
+	require.InDelta(t, 0.3, peak(samples[1000:1800]), 1e-3)
+	require.Zero(t, peak(samples[1800:2000]))
+}
+
+func TestRender_Fades(t *testing.T) {
+	sampleRate := 8000.0
+	seq := NewSequencer(sampleRate, Step{FreqHz: 250, DurationMs: 100, AmplitudeScale: 1})
+
+	samples, err := seq.Render(format.PCM16{})
+	require.NoError(t, err)
+
+	raw, err := sine.NewSine(250, 100*time.Millisecond, sine.WithSamplingRate(sampleRate)).Generate()
+	require.NoError(t, err)
+
+	// 5 ms at 8 kHz is 40 samples of fade on each side.
+	require.Zero(t, samples[0])
+	require.InDelta(t, raw[20]*0.5, samples[20], 1e-12)
+	require.Equal(t, raw[400], samples[400])
+	require.Zero(t, samples[799])
+	require.InDelta(t, raw[779]*0.5, samples[779], 1e-12)
+}
+
+func TestRender_Empty(t *testing.T) {
+	samples, err := NewSequencer(44100).Render(format.PCM16{})
+	require.NoError(t, err)
+	require.Empty(t, samples)
+}
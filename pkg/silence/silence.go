@@ -0,0 +1,33 @@
+package silence
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+)
+
+// WriteTo writes the encoded silence to the given Writer. Every sample
+// encodes to the same bytes, so the whole block is built at once instead of
+// converting samples one by one.
+func (s Silence) WriteTo(w io.Writer) (int64, error) {
+	// Zero does not always encode to zero bytes (e.g. unsigned PCM8), so
+	// we let the format tell us what silence looks like.
+	sample := s.Format.ConvertSample(0.0)
+	data := bytes.Repeat(sample, s.totalSamples())
+
+	n, err := w.Write(data)
+	if err != nil {
+		return int64(n), fmt.Errorf("unable to write data, err: %w", err)
+	}
+
+	return int64(n), nil
+}
+
+// Generate returns a zero-filled slice holding one value per sample.
+func (s Silence) Generate() ([]float64, error) {
+	return make([]float64, s.totalSamples()), nil
+}
+
+func (s Silence) totalSamples() int {
+	return max(int(s.SamplingRate*s.Duration.Seconds()), 0)
+}
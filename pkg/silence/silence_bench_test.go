@@ -0,0 +1,31 @@
+package silence
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	"github.com/ECecillo/lib.go.sound/pkg/sine"
+)
+
+// BenchmarkWriteTo compares writing silence against writing a sine of the
+// same length, the latter converting every sample individually.
+func BenchmarkWriteTo(b *testing.B) {
+	b.Run("Silence_1sec", func(b *testing.B) {
+		silence := NewSilence(time.Second)
+		for b.Loop() {
+			if _, err := silence.WriteTo(io.Discard); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("Sine_1sec", func(b *testing.B) {
+		s := sine.NewSine(440.0, time.Second)
+		for b.Loop() {
+			if _, err := s.WriteTo(io.Discard); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
@@ -0,0 +1,60 @@
+package silence
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ECecillo/lib.go.sound/pkg/format"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerate(t *testing.T) {
+	silence := NewSilence(500*time.Millisecond, WithSamplingRate(48000.0))
+
+	samples, err := silence.Generate()
+	require.NoError(t, err)
+	require.Len(t, samples, 24000)
+
+	for _, v := range samples {
+		require.Equal(t, 0.0, v)
+	}
+}
+
+func TestWriteTo(t *testing.T) {
+	formats := []format.AudioFormat{format.PCM16{}, format.PCM32{}, format.Float32{}, format.Float64{}}
+
+	for _, f := range formats {
+		silence := NewSilence(100*time.Millisecond, WithFormat(f))
+
+		var buf bytes.Buffer
+		bytesWritten, err := silence.WriteTo(&buf)
+		require.NoError(t, err)
+
+		expected := int(silence.SamplingRate*silence.Duration.Seconds()) * f.BitDepth() / 8
+		require.Equal(t, int64(expected), bytesWritten)
+		require.Equal(t, expected, buf.Len())
+		require.Equal(t, make([]byte, expected), buf.Bytes(), "%T silence should only contain 0x00", f)
+	}
+}
+
+func TestWriteTo_UnsignedFormat(t *testing.T) {
+	silence := NewSilence(10*time.Millisecond, WithFormat(format.PCM8{}))
+
+	var buf bytes.Buffer
+	_, err := silence.WriteTo(&buf)
+	require.NoError(t, err)
+	require.Equal(t, bytes.Repeat([]byte{0x80}, 441), buf.Bytes())
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+func TestWriteTo_Error(t *testing.T) {
+	_, err := NewSilence(time.Second).WriteTo(failingWriter{})
+	require.Error(t, err)
+}
@@ -0,0 +1,41 @@
+package silence
+
+import (
+	"time"
+
+	"github.com/ECecillo/lib.go.sound/pkg/format"
+)
+
+type Silence struct {
+	Format       format.AudioFormat
+	Duration     time.Duration // Duration of the silence
+	SamplingRate float64       // Sampling frequency in Hz
+}
+
+type Option func(*Silence)
+
+func NewSilence(duration time.Duration, options ...Option) *Silence {
+	silence := &Silence{
+		Duration:     duration,
+		SamplingRate: 44100.0,
+		Format:       format.PCM16{},
+	}
+
+	for _, opt := range options {
+		opt(silence)
+	}
+
+	return silence
+}
+
+func WithSamplingRate(rate float64) Option {
+	return func(s *Silence) {
+		s.SamplingRate = rate
+	}
+}
+
+func WithFormat(fmt format.AudioFormat) Option {
+	return func(s *Silence) {
+		s.Format = fmt
+	}
+}
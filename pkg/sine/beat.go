@@ -0,0 +1,80 @@
+package sine
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/ECecillo/lib.go.sound/pkg/format"
+)
+
+// BeatTone is a pair of sines detuned symmetrically around Frequency, as
+// used in psychoacoustic research on beating and binaural illusions. The
+// sum is a carrier at Frequency whose amplitude is modulated at
+// BeatFrequency:
+//
+//	sin(2π(f+Δ/2)t) + sin(2π(f-Δ/2)t) = 2cos(πΔt) * sin(2πft)
+//
+// Carrier holds Frequency and every setting shared by the two tones,
+// configured with the usual options. It is a named field rather than an
+// embedded Sine so that the methods of Sine, which only know about the
+// carrier, are not promoted to BeatTone.
+type BeatTone struct {
+	Carrier       Sine    // Center tone and shared settings
+	BeatFrequency float64 // Frequency difference between the two tones in Hz
+}
+
+// NewBeatTone returns a beat tone centered at frequency beating at
+// beatFrequency.
+func NewBeatTone(frequency, beatFrequency float64, duration time.Duration, options ...Option) *BeatTone {
+	return &BeatTone{
+		Carrier:       *NewSine(frequency, duration, options...),
+		BeatFrequency: beatFrequency,
+	}
+}
+
+// Generate sums the two tones at Frequency ± BeatFrequency/2, each at half
+// the Amplitude so the peak of the beating waveform is Amplitude.
+func (b BeatTone) Generate() ([]float64, error) {
+	var result []float64
+
+	for i, offset := range []float64{b.BeatFrequency / 2, -b.BeatFrequency / 2} {
+		tone := b.Carrier.Clone(WithAmplitude(b.Carrier.Amplitude / 2))
+		tone.Frequency = b.Carrier.Frequency + offset
+
+		samples, err := tone.Generate()
+		if err != nil {
+			return nil, fmt.Errorf("unable to generate tone %d, err: %w", i, err)
+		}
+
+		if result == nil {
+			result = samples
+			continue
+		}
+		for n, v := range samples {
+			result[n] += v
+		}
+	}
+
+	return result, nil
+}
+
+// WriteTo generates the beating waveform and writes it encoded with Format.
+func (b BeatTone) WriteTo(w io.Writer) (int64, error) {
+	samples, err := b.Generate()
+	if err != nil {
+		return 0, fmt.Errorf("unable to generate samples, err: %w", err)
+	}
+
+	n, err := format.WriteAll(b.Carrier.Format, samples, w)
+	if err != nil {
+		return n, newWriteError(n, b.Carrier.Format, err)
+	}
+	return n, nil
+}
+
+// String returns a short description of the beat tone, for example
+// "BeatTone{Sine{440.0Hz, 1s, amp=1.0, rate=44100Hz, PCM16}, beat=4.0Hz}".
+func (b BeatTone) String() string {
+	return fmt.Sprintf("BeatTone{%s, beat=%sHz}", b.Carrier, decimal(b.BeatFrequency))
+}
@@ -0,0 +1,138 @@
+package sine
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"math"
+	"math/cmplx"
+	"testing"
+	"time"
+
+	"github.com/ECecillo/lib.go.sound/pkg/analysis"
+	"github.com/ECecillo/lib.go.sound/pkg/format"
+	"github.com/stretchr/testify/require"
+)
+
+// beatEnvelope demodulates samples at the carrier frequency and returns the
+// signed envelope, averaged over windows of ten carrier periods.
+func beatEnvelope(samples []float64, carrier, sampleRate float64) []float64 {
+	window := int(10 * sampleRate / carrier)
+	envelope := make([]float64, 0, len(samples)/window)
+
+	for start := 0; start+window <= len(samples); start += window {
+		var sum float64
+		for n := start; n < start+window; n++ {
+			sum += samples[n] * math.Sin(2*math.Pi*carrier*float64(n)/sampleRate)
+		}
+		envelope = append(envelope, 2*sum/float64(window))
+	}
+
+	return envelope
+}
+
+func TestBeatTone_BeatRate(t *testing.T) {
+	tests := []struct {
+		frequency float64
+		beat      float64
+	}{
+		{frequency: 440, beat: 4},
+		{frequency: 1000, beat: 7},
+		{frequency: 250, beat: 2},
+	}
+
+	for _, tt := range tests {
+		tone := NewBeatTone(tt.frequency, tt.beat, 2*time.Second, WithAmplitude(0.8))
+		samples, err := tone.Generate()
+		require.NoError(t, err)
+		require.Len(t, samples, 88200)
+
+		// 2cos(πΔt) crosses zero Δ times per second.
+		envelope := beatEnvelope(samples, tt.frequency, tone.Carrier.SamplingRate)
+		crossings := 0
+		for i := 1; i < len(envelope); i++ {
+			if (envelope[i-1] < 0) != (envelope[i] < 0) {
+				crossings++
+			}
+		}
+		require.Equal(t, int(2*tt.beat), crossings, "frequency %v", tt.frequency)
+
+		peak := 0.0
+		for _, v := range samples {
+			peak = math.Max(peak, math.Abs(v))
+		}
+		require.InDelta(t, 0.8, peak, 0.01)
+	}
+}
+
+func TestBeatTone_CarrierFrequency(t *testing.T) {
+	const frequency = 440.0
+	tone := NewBeatTone(frequency, 4, time.Second)
+	samples, err := tone.Generate()
+	require.NoError(t, err)
+
+	input := make([]float64, 65536)
+	copy(input, samples)
+	spectrum, err := analysis.FFT(input)
+	require.NoError(t, err)
+	binWidth := tone.Carrier.SamplingRate / float64(len(spectrum))
+
+	// The two partials sit symmetrically around the carrier, so the
+	// magnitude-weighted centroid of the band is the carrier frequency.
+	var weighted, total float64
+	for k := int((frequency - 20) / binWidth); k <= int((frequency+20)/binWidth); k++ {
+		magnitude := cmplx.Abs(spectrum[k])
+		weighted += magnitude * float64(k) * binWidth
+		total += magnitude
+	}
+	require.InDelta(t, frequency, weighted/total, 0.5)
+}
+
+func TestBeatTone_WriteTo(t *testing.T) {
+	tone := NewBeatTone(440, 3, 100*time.Millisecond, WithFormat(format.PCM32{}))
+	samples, err := tone.Generate()
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	n, err := tone.WriteTo(&buf)
+	require.NoError(t, err)
+	require.Equal(t, int64(4*len(samples)), n)
+	require.Equal(t, format.PCM32{}.ConvertSample(samples[10]), buf.Bytes()[40:44])
+}
+
+func TestBeatTone_NyquistCheck(t *testing.T) {
+	tone := NewBeatTone(22040, 40, 10*time.Millisecond, WithNyquistCheck())
+	_, err := tone.Generate()
+	require.ErrorIs(t, err, ErrNyquistViolation)
+}
+
+func TestBeatTone_WriteError(t *testing.T) {
+	b := NewBeatTone(440.0, 4.0, 100*time.Millisecond, WithFormat(format.Float32{}))
+
+	n, err := b.WriteTo(&limitWriter{limit: 40})
+
+	var writeErr *WriteError
+	require.ErrorAs(t, err, &writeErr)
+	require.Equal(t, int64(40), n)
+	require.Equal(t, 10, writeErr.SampleIndex)
+}
+
+func TestBeatTone_CarrierMethodsNotPromoted(t *testing.T) {
+	tone := NewBeatTone(440.0, 4.0, time.Second)
+
+	// Sine methods would act on the plain carrier.
+	_, ok := any(tone).(interface{ SampleAt(int) (float64, error) })
+	require.False(t, ok)
+	_, ok = any(tone).(interface {
+		WriteToChunked(io.Writer, int) (int64, error)
+	})
+	require.False(t, ok)
+
+	require.Equal(t, "BeatTone{Sine{440.0Hz, 1s, amp=1.0, rate=44100Hz, PCM16}, beat=4.0Hz}", tone.String())
+
+	data, err := json.Marshal(tone)
+	require.NoError(t, err)
+	var decoded BeatTone
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	require.Equal(t, *tone, decoded)
+}
@@ -0,0 +1,188 @@
+package sine
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/ECecillo/lib.go.sound/pkg/format"
+)
+
+// ErrInvalidChunkSize is returned by WriteToChunked when chunkSamples is not
+// positive.
+var ErrInvalidChunkSize = errors.New("chunk size must be positive")
+
+// progressInterval is the number of samples written between two calls of
+// the WriteToWithProgress callback.
+const progressInterval = 4096
+
+// WriteToFile creates or truncates the file at path, writes the generated
+// samples to it and closes it, even when writing fails. Write and close
+// errors are both reported, as with WriteToAndClose.
+func (s Sine) WriteToFile(path string) (int64, error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return 0, fmt.Errorf("unable to create file, err: %w", err)
+	}
+
+	return s.WriteToAndClose(file)
+}
+
+// WriteToAndClose writes the generated samples to wc and closes it, even
+// when writing fails. Write and close errors are joined, so errors.Is
+// matches either of them.
+func (s Sine) WriteToAndClose(wc io.WriteCloser) (int64, error) {
+	n, err := s.WriteTo(wc)
+
+	if closeErr := wc.Close(); closeErr != nil {
+		err = errors.Join(err, fmt.Errorf("unable to close writer, err: %w", closeErr))
+	}
+
+	return n, err
+}
+
+// WriteToBuffered works like WriteTo but batches the per-sample writes in a
+// bufio.Writer of bufSize bytes, flushed before returning. This avoids one
+// system call per sample on unbuffered destinations such as files, pipes or
+// network connections.
+//
+// Write and flush errors are reported as a *WriteError counting the bytes
+// that actually reached w, not the bytes accepted by the buffer.
+func (s Sine) WriteToBuffered(w io.Writer, bufSize int) (int64, error) {
+	samples, err := s.Generate()
+	if err != nil {
+		return 0, fmt.Errorf("unable to generate samples, err: %w", err)
+	}
+
+	counter := &countingWriter{w: w}
+	buffered := bufio.NewWriterSize(counter, bufSize)
+
+	_, err = format.WriteAll(s.Format, samples, buffered)
+	if err == nil {
+		if err = buffered.Flush(); err != nil {
+			err = fmt.Errorf("unable to flush data, err: %w", err)
+		}
+	}
+	if err != nil {
+		return counter.n, newWriteError(counter.n, s.Format, err)
+	}
+
+	return counter.n, nil
+}
+
+// countingWriter forwards writes to w and counts the bytes it accepted.
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// WriteToChunked works like WriteTo but only keeps chunkSamples samples in
+// memory at a time, so signals too large for Generate can still be written.
+// Each chunk resumes from a running sample counter, which makes the output
+// byte-identical to WriteTo.
+func (s Sine) WriteToChunked(w io.Writer, chunkSamples int) (int64, error) {
+	if chunkSamples <= 0 {
+		return 0, ErrInvalidChunkSize
+	}
+
+	if err := s.validate(); err != nil {
+		return 0, fmt.Errorf("unable to generate samples, err: %w", err)
+	}
+
+	voices := s.voiceSamplers()
+	totalSamples := s.totalSamples()
+	chunk := make([]float64, 0, min(chunkSamples, totalSamples))
+
+	var totalBytesWritten int64
+
+	for start := 0; start < totalSamples; start += chunkSamples {
+		chunk = chunk[:0]
+		for n := start; n < min(start+chunkSamples, totalSamples); n++ {
+			chunk = append(chunk, mixAt(voices, n))
+		}
+
+		n, err := format.WriteAll(s.Format, chunk, w)
+		totalBytesWritten += n
+		if err != nil {
+			return totalBytesWritten, newWriteError(totalBytesWritten, s.Format, err)
+		}
+	}
+
+	return totalBytesWritten, nil
+}
+
+// voiceSamplers returns the sampler of s, or one per unison voice.
+func (s Sine) voiceSamplers() []func(n int) float64 {
+	if s.UnisonVoices <= 1 {
+		return []func(n int) float64{s.sampler()}
+	}
+
+	var samplers []func(n int) float64
+	for _, voice := range s.unisonVoices() {
+		samplers = append(samplers, voice.sampler())
+	}
+	return samplers
+}
+
+// mixAt averages every voice at the given sample, in the same order
+// Generate accumulates them.
+func mixAt(voices []func(n int) float64, sampleIndex int) float64 {
+	var value float64
+	for _, voice := range voices {
+		value += voice(sampleIndex) / float64(len(voices))
+	}
+	return value
+}
+
+// WriteToWithProgress works like WriteTo but reports progress to cb every
+// 4096 samples, and once more after the last sample, with the cumulative
+// number of bytes written and the total expected. A nil cb makes it
+// identical to WriteTo.
+func (s Sine) WriteToWithProgress(w io.Writer, cb func(written, total int64)) (int64, error) {
+	if cb == nil {
+		return s.WriteTo(w)
+	}
+
+	samples, err := s.Generate()
+	if err != nil {
+		return 0, fmt.Errorf("unable to generate samples, err: %w", err)
+	}
+
+	total := int64(len(samples) * s.Format.BitDepth() / 8)
+
+	var totalBytesWritten int64
+
+	for start := 0; start < len(samples); start += progressInterval {
+		end := min(start+progressInterval, len(samples))
+
+		n, err := format.WriteAll(s.Format, samples[start:end], w)
+		totalBytesWritten += n
+		if err != nil {
+			return totalBytesWritten, newWriteError(totalBytesWritten, s.Format, err)
+		}
+
+		if end < len(samples) {
+			cb(totalBytesWritten, total)
+		}
+	}
+
+	cb(totalBytesWritten, total)
+
+	return totalBytesWritten, nil
+}
+
+// WriteToDetailed works like WriteTo but also returns the number of whole
+// samples written, so callers do not have to divide the byte count by the
+// sample size of Format.
+func (s Sine) WriteToDetailed(w io.Writer) (bytesWritten int64, samplesWritten int, err error) {
+	bytesWritten, err = s.WriteTo(w)
+	return bytesWritten, int(bytesWritten / int64(s.Format.BitDepth()/8)), err
+}
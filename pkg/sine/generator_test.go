@@ -0,0 +1,326 @@
+package sine
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ECecillo/lib.go.sound/pkg/format"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	errWrite = errors.New("write failed")
+	errClose = errors.New("close failed")
+)
+
+// closeRecorder is an io.WriteCloser that records whether it was closed
+// and can be configured to fail on write or close.
+type closeRecorder struct {
+	bytes.Buffer
+	closed   bool
+	writeErr error
+	closeErr error
+}
+
+func (c *closeRecorder) Write(p []byte) (int, error) {
+	if c.writeErr != nil {
+		return 0, c.writeErr
+	}
+	return c.Buffer.Write(p)
+}
+
+func (c *closeRecorder) Close() error {
+	c.closed = true
+	return c.closeErr
+}
+
+func TestWriteToAndClose(t *testing.T) {
+	sine := NewSine(440.0, 10*time.Millisecond)
+
+	var expected bytes.Buffer
+	_, err := sine.WriteTo(&expected)
+	require.NoError(t, err)
+
+	t.Run("success", func(t *testing.T) {
+		wc := &closeRecorder{}
+		n, err := sine.WriteToAndClose(wc)
+		require.NoError(t, err)
+		require.True(t, wc.closed)
+		require.Equal(t, int64(expected.Len()), n)
+		require.Equal(t, expected.Bytes(), wc.Bytes())
+	})
+
+	t.Run("close_error", func(t *testing.T) {
+		wc := &closeRecorder{closeErr: errClose}
+		_, err := sine.WriteToAndClose(wc)
+		require.ErrorIs(t, err, errClose)
+	})
+
+	t.Run("write_and_close_errors", func(t *testing.T) {
+		wc := &closeRecorder{writeErr: errWrite, closeErr: errClose}
+		_, err := sine.WriteToAndClose(wc)
+		require.ErrorIs(t, err, errWrite)
+		require.ErrorIs(t, err, errClose)
+		require.True(t, wc.closed, "writer must be closed even when writing fails")
+	})
+}
+
+func TestWriteToBuffered(t *testing.T) {
+	sine := NewSine(440.0, 100*time.Millisecond)
+
+	var expected bytes.Buffer
+	expectedBytes, err := sine.WriteTo(&expected)
+	require.NoError(t, err)
+
+	for _, size := range []int{1, 16, 4096} {
+		var buf bytes.Buffer
+		n, err := sine.WriteToBuffered(&buf, size)
+		require.NoError(t, err)
+		require.Equal(t, expectedBytes, n)
+		require.Equal(t, expected.Bytes(), buf.Bytes(), "buffer size %d", size)
+	}
+
+	_, err = sine.WriteToBuffered(&closeRecorder{writeErr: errWrite}, 4096)
+	require.ErrorIs(t, err, errWrite)
+
+	// Everything fits in the buffer, so the failure happens on Flush and
+	// only the bytes that reached the writer are reported.
+	n, err := sine.WriteToBuffered(&limitWriter{limit: 1001}, 1<<20)
+	require.ErrorIs(t, err, errWrite)
+	require.Equal(t, int64(1001), n)
+}
+
+func TestWriteToChunked(t *testing.T) {
+	configs := []struct {
+		name string
+		sine *Sine
+	}{
+		{name: "plain", sine: NewSine(440.0, time.Second)},
+		{name: "pcm8", sine: NewSine(440.0, time.Second, WithFormat(format.PCM8{}))},
+		{name: "unison", sine: NewSine(440.0, time.Second, WithUnison(3, 5.0))},
+		{name: "automation", sine: NewSine(440.0, time.Second,
+			WithGainAutomation([]AutoPoint{{SampleIndex: 0, Gain: 0}, {SampleIndex: 44099, Gain: 1}}))},
+	}
+
+	for _, tt := range configs {
+		t.Run(tt.name, func(t *testing.T) {
+			var expected bytes.Buffer
+			expectedBytes, err := tt.sine.WriteTo(&expected)
+			require.NoError(t, err)
+
+			for _, chunkSamples := range []int{441, 1000, 100000} {
+				var buf bytes.Buffer
+				n, err := tt.sine.WriteToChunked(&buf, chunkSamples)
+				require.NoError(t, err)
+				require.Equal(t, expectedBytes, n)
+				require.Equal(t, expected.Bytes(), buf.Bytes(), "chunk size %d", chunkSamples)
+			}
+		})
+	}
+}
+
+func TestWriteToChunked_Errors(t *testing.T) {
+	sine := NewSine(440.0, 10*time.Millisecond)
+
+	for _, chunkSamples := range []int{0, -441} {
+		_, err := sine.WriteToChunked(&bytes.Buffer{}, chunkSamples)
+		require.ErrorIs(t, err, ErrInvalidChunkSize)
+	}
+
+	_, err := sine.WriteToChunked(&closeRecorder{writeErr: errWrite}, 441)
+	require.ErrorIs(t, err, errWrite)
+
+	_, err = NewSine(30000.0, 10*time.Millisecond, WithNyquistCheck()).WriteToChunked(&bytes.Buffer{}, 441)
+	require.ErrorIs(t, err, ErrNyquistViolation)
+}
+
+func TestWriteToWithProgress(t *testing.T) {
+	sine := NewSine(440.0, time.Second)
+
+	var expected bytes.Buffer
+	expectedBytes, err := sine.WriteTo(&expected)
+	require.NoError(t, err)
+
+	type progress struct{ written, total int64 }
+	var calls []progress
+
+	var buf bytes.Buffer
+	n, err := sine.WriteToWithProgress(&buf, func(written, total int64) {
+		calls = append(calls, progress{written, total})
+	})
+	require.NoError(t, err)
+	require.Equal(t, expectedBytes, n)
+	require.Equal(t, expected.Bytes(), buf.Bytes())
+
+	// 44100 samples give 10 intermediate reports plus the final one.
+	require.Len(t, calls, 11)
+
+	last := calls[len(calls)-1]
+	require.Equal(t, expectedBytes, last.written)
+	require.Equal(t, expectedBytes, last.total)
+
+	for i, call := range calls[:len(calls)-1] {
+		require.Less(t, call.written, call.total)
+		require.Equal(t, int64((i+1)*progressInterval*2), call.written, "PCM16 samples are 2 bytes")
+	}
+}
+
+func TestWriteToWithProgress_NilCallback(t *testing.T) {
+	sine := NewSine(440.0, 100*time.Millisecond)
+
+	var expected bytes.Buffer
+	_, err := sine.WriteTo(&expected)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NotPanics(t, func() {
+		_, err = sine.WriteToWithProgress(&buf, nil)
+	})
+	require.NoError(t, err)
+	require.Equal(t, expected.Bytes(), buf.Bytes())
+}
+
+func TestWriteToWithProgress_WriteError(t *testing.T) {
+	called := false
+	_, err := NewSine(440.0, 100*time.Millisecond).WriteToWithProgress(
+		&closeRecorder{writeErr: errWrite},
+		func(written, total int64) { called = true },
+	)
+	require.ErrorIs(t, err, errWrite)
+	require.False(t, called)
+}
+
+func TestWriteToDetailed(t *testing.T) {
+	formats := []format.AudioFormat{format.PCM8{}, format.PCM16{}, format.PCM32{}, format.Float64{}}
+
+	for _, f := range formats {
+		s := NewSine(440.0, 250*time.Millisecond, WithFormat(f), WithSamplingRate(48000))
+
+		var buf bytes.Buffer
+		bytesWritten, samplesWritten, err := s.WriteToDetailed(&buf)
+		require.NoError(t, err)
+		require.Equal(t, int(s.SamplingRate*s.Duration.Seconds()), samplesWritten, "%T", f)
+		require.Equal(t, int64(samplesWritten*f.BitDepth()/8), bytesWritten, "%T", f)
+		require.Equal(t, int(bytesWritten), buf.Len())
+	}
+}
+
+func TestWriteToDetailed_WriteError(t *testing.T) {
+	bytesWritten, samplesWritten, err := NewSine(440.0, 100*time.Millisecond).WriteToDetailed(&closeRecorder{writeErr: errWrite})
+	require.ErrorIs(t, err, errWrite)
+	require.Zero(t, bytesWritten)
+	require.Zero(t, samplesWritten)
+}
+
+// limitWriter accepts limit bytes then fails with errWrite.
+type limitWriter struct {
+	bytes.Buffer
+	limit int
+}
+
+func (l *limitWriter) Write(p []byte) (int, error) {
+	n := min(len(p), l.limit-l.Len())
+	l.Buffer.Write(p[:n])
+	if n < len(p) {
+		return n, errWrite
+	}
+	return n, nil
+}
+
+func TestWriteTo_WriteError(t *testing.T) {
+	s := NewSine(440.0, 100*time.Millisecond, WithFormat(format.PCM16{}))
+
+	writers := []struct {
+		name  string
+		write func(w *limitWriter) (int64, error)
+	}{
+		{name: "WriteTo", write: func(w *limitWriter) (int64, error) { return s.WriteTo(w) }},
+		{name: "WriteToChunked", write: func(w *limitWriter) (int64, error) { return s.WriteToChunked(w, 64) }},
+		// The samples fit in the buffer: the failure happens on Flush.
+		{name: "WriteToBuffered", write: func(w *limitWriter) (int64, error) { return s.WriteToBuffered(w, 1<<20) }},
+		{name: "WriteToWithProgress", write: func(w *limitWriter) (int64, error) {
+			return s.WriteToWithProgress(w, func(written, total int64) {})
+		}},
+	}
+
+	for _, tt := range writers {
+		t.Run(tt.name, func(t *testing.T) {
+			// 1001 bytes hold 500 whole PCM16 samples and half of the next.
+			n, err := tt.write(&limitWriter{limit: 1001})
+			require.ErrorIs(t, err, errWrite)
+			require.Equal(t, int64(1001), n)
+
+			var writeErr *WriteError
+			require.ErrorAs(t, err, &writeErr)
+			require.Equal(t, int64(1001), writeErr.BytesWritten)
+			require.Equal(t, 500, writeErr.SampleIndex)
+			require.Contains(t, writeErr.Error(), "sample 500")
+		})
+	}
+}
+
+// limitCloser is a limitWriter that records whether it was closed.
+type limitCloser struct {
+	limitWriter
+	closed   bool
+	closeErr error
+}
+
+func (l *limitCloser) Close() error {
+	l.closed = true
+	return l.closeErr
+}
+
+func TestWriteToFile(t *testing.T) {
+	s := NewSine(440.0, 100*time.Millisecond, WithFormat(format.PCM16{}))
+	path := filepath.Join(t.TempDir(), "sine.raw")
+
+	// An existing file is truncated.
+	require.NoError(t, os.WriteFile(path, make([]byte, 100000), 0o644))
+
+	n, err := s.WriteToFile(path)
+	require.NoError(t, err)
+	require.Equal(t, int64(4410*2), n)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Len(t, data, 4410*2)
+
+	var expected bytes.Buffer
+	_, err = s.WriteTo(&expected)
+	require.NoError(t, err)
+	require.Equal(t, expected.Bytes(), data)
+}
+
+func TestWriteToFile_CreateError(t *testing.T) {
+	_, err := NewSine(440.0, 10*time.Millisecond).WriteToFile(filepath.Join(t.TempDir(), "missing", "sine.raw"))
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestWriteToAndClose_ClosesOnPartialWrite(t *testing.T) {
+	file := &limitCloser{limitWriter: limitWriter{limit: 100}, closeErr: errClose}
+
+	n, err := NewSine(440.0, 10*time.Millisecond).WriteToAndClose(file)
+	require.ErrorIs(t, err, errWrite)
+	require.ErrorIs(t, err, errClose)
+	require.Equal(t, int64(100), n)
+	require.True(t, file.closed)
+
+	var writeErr *WriteError
+	require.ErrorAs(t, err, &writeErr)
+	require.Equal(t, 50, writeErr.SampleIndex)
+}
+
+func TestWriteToAndClose_CloseError(t *testing.T) {
+	file := &limitCloser{limitWriter: limitWriter{limit: 1 << 20}, closeErr: errClose}
+
+	n, err := NewSine(440.0, 10*time.Millisecond).WriteToAndClose(file)
+	require.ErrorIs(t, err, errClose)
+	require.NotErrorIs(t, err, errWrite)
+	require.Equal(t, int64(441*2), n)
+	require.True(t, file.closed)
+}
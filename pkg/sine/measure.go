@@ -0,0 +1,62 @@
+package sine
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrNotEnoughCrossings is returned by MeasuredFrequency when the signal
+// does not complete a full period.
+var ErrNotEnoughCrossings = errors.New("not enough zero crossings to measure frequency")
+
+// MeasuredFrequency generates the signal and estimates its fundamental from
+// the rising zero crossings. Each crossing time is refined by linear
+// interpolation between the two samples around it, and the frequency is the
+// number of whole periods between the first and last crossing divided by
+// the time separating them.
+//
+// Counting crossings alone is only accurate to about one period over the
+// whole signal, i.e. 1/Duration Hz. Interpolating the crossing times brings
+// the error well below half a DFT bin, 0.5*SamplingRate/totalSamples Hz,
+// so it shrinks as the duration grows: a one second tone is typically
+// measured to within a few microhertz.
+func (s Sine) MeasuredFrequency() (float64, error) {
+	samples, err := s.Generate()
+	if err != nil {
+		return 0, fmt.Errorf("unable to generate samples, err: %w", err)
+	}
+
+	return MeasureFrequency(samples, s.SamplingRate)
+}
+
+// MeasureFrequency estimates the fundamental of samples, taken at
+// samplingRate, with the interpolated rising zero crossings described in
+// MeasuredFrequency. It works on any periodic signal crossing zero once
+// upwards per period, such as a resampled or filtered sine.
+func MeasureFrequency(samples []float64, samplingRate float64) (float64, error) {
+	var first, last float64
+	crossings := 0
+
+	for n := 1; n < len(samples); n++ {
+		prev, cur := samples[n-1], samples[n]
+		if prev >= 0 || cur < 0 {
+			continue
+		}
+
+		// Fraction of the sample interval at which the line from prev to
+		// cur crosses zero.
+		position := float64(n-1) + prev/(prev-cur)
+		if crossings == 0 {
+			first = position
+		}
+		last = position
+		crossings++
+	}
+
+	if crossings < 2 {
+		return 0, ErrNotEnoughCrossings
+	}
+
+	periods := float64(crossings - 1)
+	return periods * samplingRate / (last - first), nil
+}
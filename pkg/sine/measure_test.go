@@ -0,0 +1,43 @@
+package sine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMeasuredFrequency(t *testing.T) {
+	tests := []struct {
+		name         string
+		frequency    float64
+		duration     time.Duration
+		samplingRate float64
+	}{
+		{name: "a4_1s", frequency: 440.0, duration: time.Second, samplingRate: 44100.0},
+		{name: "non_integer", frequency: 261.63, duration: 500 * time.Millisecond, samplingRate: 44100.0},
+		{name: "low_frequency", frequency: 20.0, duration: 2 * time.Second, samplingRate: 8000.0},
+		{name: "high_frequency", frequency: 15000.0, duration: 100 * time.Millisecond, samplingRate: 48000.0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sine := NewSine(tt.frequency, tt.duration, WithSamplingRate(tt.samplingRate))
+
+			measured, err := sine.MeasuredFrequency()
+			require.NoError(t, err)
+
+			totalSamples := float64(int(tt.samplingRate * tt.duration.Seconds()))
+			require.InDelta(t, tt.frequency, measured, 0.5*tt.samplingRate/totalSamples)
+		})
+	}
+}
+
+func TestMeasuredFrequency_Errors(t *testing.T) {
+	// Less than one period has a single rising crossing at most.
+	_, err := NewSine(10.0, 50*time.Millisecond).MeasuredFrequency()
+	require.ErrorIs(t, err, ErrNotEnoughCrossings)
+
+	_, err = NewSine(30000.0, 50*time.Millisecond, WithNyquistCheck()).MeasuredFrequency()
+	require.ErrorIs(t, err, ErrNyquistViolation)
+}
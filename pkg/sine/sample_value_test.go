@@ -0,0 +1,31 @@
+package sine_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/ECecillo/lib.go.sound/pkg/sine"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSampleValue(t *testing.T) {
+	require.Equal(t, 0.0, sine.SampleValue(440, 1.0, 44100, 0))
+
+	// A quarter period in, the sine reaches its amplitude.
+	require.InDelta(t, 0.5, sine.SampleValue(1000, 0.5, 4000, 1), 1e-12)
+	require.InDelta(t, math.Sin(2*math.Pi*440*7/44100), sine.SampleValue(440, 1.0, 44100, 7), 1e-12)
+
+	// Frequencies the sampling rate cannot represent are filtered out.
+	require.Equal(t, 0.0, sine.SampleValue(30000, 1.0, 44100, 3))
+}
+
+func TestSampleValue_MatchesGenerate(t *testing.T) {
+	s := sine.NewSine(523.25, 10*time.Millisecond, sine.WithAmplitude(0.7))
+	samples, err := s.Generate()
+	require.NoError(t, err)
+
+	for n, v := range samples {
+		require.Equal(t, v, sine.SampleValue(s.Frequency, s.Amplitude, s.SamplingRate, n))
+	}
+}
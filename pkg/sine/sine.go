@@ -1,11 +1,60 @@
 package sine
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"math"
+	"time"
+
+	"github.com/ECecillo/lib.go.sound/pkg/format"
 )
 
+// ErrNyquistViolation is returned by Generate when WithNyquistCheck is set
+// and the frequency cannot be represented at the sampling rate.
+var ErrNyquistViolation = errors.New("frequency violates Nyquist criterion")
+
+// ErrUnsortedPoints is returned by Generate when the gain automation points
+// are not sorted by SampleIndex.
+var ErrUnsortedPoints = errors.New("automation points are not sorted by sample index")
+
+// ErrIndexOutOfRange is returned by SampleAt when the index does not fall
+// within the generated samples.
+var ErrIndexOutOfRange = errors.New("sample index out of range")
+
+// ErrInitialValuesConflict is returned by Generate when
+// WithInitialSampleValues is combined with a setting the recursive
+// oscillator cannot honor: a non-zero Phase, unison voices or a negative
+// start index.
+var ErrInitialValuesConflict = errors.New("initial sample values conflict with phase, unison or start index")
+
+// WriteError is returned by the WriteTo methods of Sine when the
+// destination fails mid-stream. It tells how far the stream got before the
+// failure; errors.Is and errors.As see through it to Cause.
+type WriteError struct {
+	BytesWritten int64 // Bytes accepted by the destination
+	SampleIndex  int   // Index of the first sample not written in full
+	Cause        error // Error returned while writing
+}
+
+func (e *WriteError) Error() string {
+	return fmt.Sprintf("write failed at sample %d after %d bytes, err: %v", e.SampleIndex, e.BytesWritten, e.Cause)
+}
+
+func (e *WriteError) Unwrap() error {
+	return e.Cause
+}
+
+// newWriteError builds the WriteError of a stream of samples encoded with
+// f that failed after bytesWritten bytes.
+func newWriteError(bytesWritten int64, f format.AudioFormat, cause error) *WriteError {
+	return &WriteError{
+		BytesWritten: bytesWritten,
+		SampleIndex:  int(bytesWritten / int64(f.BitDepth()/8)),
+		Cause:        cause,
+	}
+}
+
 // WriteTo will generate samples and write them to the given Writer.
 func (s Sine) WriteTo(w io.Writer) (int64, error) {
 	samples, err := s.Generate()
@@ -13,39 +62,236 @@ func (s Sine) WriteTo(w io.Writer) (int64, error) {
 		return 0, fmt.Errorf("unable to generate samples, err: %w", err)
 	}
 
-	// Will help us count the number of bytes written.
-	var totalBytesWritten int64
+	n, err := format.WriteAll(s.Format, samples, w)
+	if err != nil {
+		return n, newWriteError(n, s.Format, err)
+	}
+	return n, nil
+}
 
-	for i := range len(samples) {
-		data := s.Format.ConvertSample(samples[i])
+// Generate returns the samples of the sine. With WithEndPhase it also
+// records the phase reached at the last sample in AchievedEndPhase.
+func (s *Sine) Generate() ([]float64, error) {
+	samples, err := s.generate()
+	if err != nil {
+		return nil, err
+	}
 
-		n, err := w.Write(data)
-		if err != nil {
-			return totalBytesWritten, fmt.Errorf("unable to write data, err: %w", err)
-		}
-		totalBytesWritten += int64(n)
+	if s.endPhase != nil {
+		s.AchievedEndPhase = s.phaseAt(len(samples) - 1)
+	}
+	return samples, nil
+}
 
+// generate computes the samples returned by Generate.
+func (s Sine) generate() ([]float64, error) {
+	if err := s.validate(); err != nil {
+		return nil, err
 	}
 
-	return totalBytesWritten, nil
-}
+	if s.UnisonVoices > 1 {
+		return s.generateUnison()
+	}
 
-func (s Sine) Generate() ([]float64, error) {
-	totalSamples := int(s.SamplingRate * s.Duration.Seconds())
+	totalSamples := s.totalSamples()
 	result := make([]float64, 0, totalSamples)
 
+	sample := s.sampler()
 	for n := range totalSamples {
-		value := s.calculateSampleValue(n)
-		result = append(result, value)
+		result = append(result, sample(n))
+	}
+	return result, nil
+}
+
+// sampler returns the function computing sample n of s, gain automation
+// included. Every output path goes through it so they all agree. The
+// recursive oscillator of WithInitialSampleValues can only step forward:
+// samples are cheapest when requested in increasing order, and asking for
+// an earlier one replays the recursion from the seeds.
+func (s Sine) sampler() func(n int) float64 {
+	if s.initialValues == nil {
+		// The terms that do not depend on n are hoisted out of the
+		// per-sample call.
+		scale := s.Amplitude * s.compensationGain()
+		if len(s.Automation) == 0 {
+			return func(n int) float64 {
+				return sampleAt(s.Frequency, scale, s.SamplingRate, s.Phase, s.StartIndex+n)
+			}
+		}
+		return func(n int) float64 {
+			return sampleAt(s.Frequency, scale, s.SamplingRate, s.Phase, s.StartIndex+n) * s.gainAt(n)
+		}
+	}
+
+	coefficient := 2 * math.Cos(2*math.Pi*s.Frequency/s.SamplingRate)
+	scale := s.Amplitude * s.compensationGain()
+
+	// y1 holds y[next-1] and y2 holds y[next-2] of the virtual signal.
+	var y1, y2 float64
+	next := math.MaxInt
+
+	return func(n int) float64 {
+		index := s.StartIndex + n
+		if index < next-1 {
+			y1, y2 = s.initialValues[0], s.initialValues[1]
+			next = 0
+		}
+		for ; next <= index; next++ {
+			y1, y2 = coefficient*y1-y2, y1
+		}
+
+		return s.applyAntiAliasingFilter(scale*y1) * s.gainAt(n)
+	}
+}
+
+// SampleAt returns the value Generate would produce at index without
+// generating the other samples. With WithInitialSampleValues the recursion
+// still has to run up to index.
+func (s Sine) SampleAt(index int) (float64, error) {
+	if err := s.validate(); err != nil {
+		return 0, err
+	}
+
+	if index < 0 || index >= s.totalSamples() {
+		return 0, ErrIndexOutOfRange
+	}
+
+	if s.UnisonVoices > 1 {
+		var result float64
+		for i, voice := range s.unisonVoices() {
+			value, err := voice.SampleAt(index)
+			if err != nil {
+				return 0, fmt.Errorf("unable to compute unison voice %d, err: %w", i, err)
+			}
+			result += value / float64(s.UnisonVoices)
+		}
+		return result, nil
+	}
+
+	return s.sampler()(index), nil
+}
+
+// validate reports configuration errors that prevent generation.
+func (s Sine) validate() error {
+	if s.NyquistCheck && s.Frequency >= s.SamplingRate/2 {
+		return ErrNyquistViolation
 	}
+
+	for i := 1; i < len(s.Automation); i++ {
+		if s.Automation[i].SampleIndex < s.Automation[i-1].SampleIndex {
+			return ErrUnsortedPoints
+		}
+	}
+
+	if s.initialValues != nil && (s.Phase != 0 || s.UnisonVoices > 1 || s.StartIndex < 0) {
+		return ErrInitialValuesConflict
+	}
+
+	return nil
+}
+
+func (s Sine) totalSamples() int {
+	if s.SampleCount > 0 {
+		return s.SampleCount
+	}
+	return int(s.SamplingRate * s.Duration.Seconds())
+}
+
+// resolveSampleCount derives Duration from the count set by
+// WithSampleCount.
+func (s *Sine) resolveSampleCount() {
+	if s.SampleCount <= 0 || s.SamplingRate <= 0 {
+		return
+	}
+	s.Duration = time.Duration(math.Round(float64(s.SampleCount) / s.SamplingRate * float64(time.Second)))
+}
+
+// unisonVoices returns one temporary Sine per voice, each detuned by an
+// even share of UnisonDetune.
+func (s Sine) unisonVoices() []*Sine {
+	voices := make([]*Sine, s.UnisonVoices)
+
+	for i := range voices {
+		offset := s.UnisonDetune * (2*float64(i)/float64(s.UnisonVoices-1) - 1)
+
+		voices[i] = s.Clone(WithUnison(0, 0))
+		voices[i].Frequency = s.Frequency + offset
+	}
+
+	return voices
+}
+
+// generateUnison sums the unison voices and averages them so the peak never
+// exceeds Amplitude.
+func (s Sine) generateUnison() ([]float64, error) {
+	var result []float64
+
+	for i, voice := range s.unisonVoices() {
+		samples, err := voice.Generate()
+		if err != nil {
+			return nil, fmt.Errorf("unable to generate unison voice %d, err: %w", i, err)
+		}
+
+		if result == nil {
+			result = make([]float64, len(samples))
+		}
+		for n, v := range samples {
+			result[n] += v / float64(s.UnisonVoices)
+		}
+	}
+
 	return result, nil
 }
 
+// gainAt interpolates the automation curve at the given sample, offset by
+// StartIndex. Samples before the first point or after the last one
+// hold that point's gain.
+func (s Sine) gainAt(sampleIndex int) float64 {
+	points := s.Automation
+	if len(points) == 0 {
+		return 1.0
+	}
+
+	sampleIndex += s.StartIndex
+
+	if sampleIndex <= points[0].SampleIndex {
+		return points[0].Gain
+	}
+
+	for i := 1; i < len(points); i++ {
+		next := points[i]
+		if sampleIndex > next.SampleIndex {
+			continue
+		}
+
+		prev := points[i-1]
+		span := next.SampleIndex - prev.SampleIndex
+		if span == 0 {
+			return next.Gain
+		}
+		ratio := float64(sampleIndex-prev.SampleIndex) / float64(span)
+		return prev.Gain + ratio*(next.Gain-prev.Gain)
+	}
+
+	return points[len(points)-1].Gain
+}
+
 // continuousSignalAt simulates the continuous sine wave signal at time t.
 // This represents the physical sound wave before any electronic processing.
 func (s Sine) continuousSignalAt(t float64) float64 {
-	angle := 2 * math.Pi * s.Frequency * t
-	return s.Amplitude * math.Sin(angle)
+	angle := 2*math.Pi*s.Frequency*t + s.Phase
+	return s.Amplitude * s.compensationGain() * math.Sin(angle)
+}
+
+// compensationGain returns the inverse zero-order hold response at the sine
+// frequency when WithNyquistCompensation is set, and 1 otherwise.
+func (s Sine) compensationGain() float64 {
+	x := math.Pi * s.Frequency / s.SamplingRate
+	if !s.NyquistComp || x == 0 {
+		return 1.0
+	}
+
+	return x / math.Sin(x)
 }
 
 // applyAntiAliasingFilter simulates an analog anti-aliasing filter.
@@ -61,19 +307,34 @@ func (s Sine) applyAntiAliasingFilter(signal float64) float64 {
 	return signal
 }
 
-// calculateSampleValue orchestrates the signal processing pipeline:
-// 1. Generate the continuous signal at time t
-// 2. Apply anti-aliasing filter
-// 3. Return the filtered sample value
-func (s Sine) calculateSampleValue(sampleIndex int) float64 {
-	// Calculate time for this sample
-	t := float64(sampleIndex) / s.SamplingRate
+// SampleValue returns the value at sampleIndex of a sine of the given
+// frequency and amplitude starting at phase 0, computed with the same
+// sampleAt core as Generate, anti-aliasing filter included:
+//
+//	x[n] = amplitude * sin(2π * frequency * n / samplingRate)
+func SampleValue(frequency, amplitude, samplingRate float64, sampleIndex int) float64 {
+	return sampleAt(frequency, amplitude, samplingRate, 0, sampleIndex)
+}
 
-	// Step 1: Get the continuous signal value
-	signal := s.continuousSignalAt(t)
+// calculateSampleValue returns sample sampleIndex of s without gain
+// automation: the sampleAt core with the phase, start index and Nyquist
+// compensation of s applied.
+func (s Sine) calculateSampleValue(sampleIndex int) float64 {
+	return sampleAt(s.Frequency, s.Amplitude*s.compensationGain(), s.SamplingRate, s.Phase, s.StartIndex+sampleIndex)
+}
 
-	// Step 2: Apply anti-aliasing filter
-	filteredSignal := s.applyAntiAliasingFilter(signal)
+// sampleAt is the single implementation of the sampled sine shared by
+// SampleValue, calculateSampleValue and the samplers of Generate:
+//
+//	x[n] = amplitude * sin(2π * frequency * n / samplingRate + phase)
+//
+// Frequencies at or above the Nyquist limit are cut off by the simulated
+// anti-aliasing filter and give 0.
+func sampleAt(frequency, amplitude, samplingRate, phase float64, index int) float64 {
+	if frequency >= samplingRate/2.0 {
+		return 0.0
+	}
 
-	return filteredSignal
+	t := float64(index) / samplingRate
+	return amplitude * math.Sin(2*math.Pi*frequency*t+phase)
 }
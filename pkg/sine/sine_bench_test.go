@@ -2,6 +2,7 @@ package sine
 
 import (
 	"bytes"
+	"io"
 	"testing"
 	"time"
 
@@ -110,6 +111,31 @@ func BenchmarkWriteTo(b *testing.B) {
 		b.ReportMetric(float64(totalSamples*2)/b.Elapsed().Seconds(), "bytes/sec")
 	})
 
+	// PCM16_1sec_PerSample is the per-sample loop WriteTo used before
+	// format.WriteAll, kept as a baseline for PCM16_1sec. Both include
+	// Generate, which dominates: PCM16_1sec is only about 30% faster, short
+	// of the 50% aimed for. BenchmarkPCM16_WriteAll in pkg/format isolates
+	// the encoding, which WriteAll makes about twice as fast.
+	b.Run("PCM16_1sec_PerSample", func(b *testing.B) {
+		sine := NewSine(440.0, time.Second, WithFormat(format.PCM16{}))
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			var buf bytes.Buffer
+			samples, err := sine.Generate()
+			if err != nil {
+				b.Fatal(err)
+			}
+			for _, sample := range samples {
+				if _, err := buf.Write(sine.Format.ConvertSample(sample)); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+		totalSamples := int(44100 * float64(b.N))
+		b.ReportMetric(float64(totalSamples)/b.Elapsed().Seconds(), "samples/sec")
+		b.ReportMetric(float64(totalSamples*2)/b.Elapsed().Seconds(), "bytes/sec")
+	})
+
 	b.Run("PCM32_1sec", func(b *testing.B) {
 		sine := NewSine(440.0, time.Second, WithFormat(format.PCM32{}))
 		b.ResetTimer()
@@ -286,3 +312,36 @@ func BenchmarkParallelWriteTo(b *testing.B) {
 		}
 	})
 }
+
+// BenchmarkWriteTo_Pipe compares per-sample writes with WriteToBuffered on
+// a pipe, where every Write is a synchronous hand-off to the reader.
+func BenchmarkWriteTo_Pipe(b *testing.B) {
+	sine := NewSine(440.0, 100*time.Millisecond)
+
+	run := func(b *testing.B, write func(w io.Writer) (int64, error)) {
+		for b.Loop() {
+			r, w := io.Pipe()
+			done := make(chan struct{})
+			go func() {
+				_, _ = io.Copy(io.Discard, r)
+				close(done)
+			}()
+
+			if _, err := write(w); err != nil {
+				b.Fatal(err)
+			}
+			_ = w.Close()
+			<-done
+		}
+	}
+
+	b.Run("unbuffered", func(b *testing.B) {
+		run(b, sine.WriteTo)
+	})
+
+	b.Run("buffered_32KiB", func(b *testing.B) {
+		run(b, func(w io.Writer) (int64, error) {
+			return sine.WriteToBuffered(w, 32*1024)
+		})
+	})
+}
@@ -2,13 +2,17 @@ package sine
 
 import (
 	"bytes"
+	"encoding/json"
 	"flag"
+	"fmt"
 	"math"
+	"math/cmplx"
 	"os"
 	"path/filepath"
 	"testing"
 	"time"
 
+	"github.com/ECecillo/lib.go.sound/pkg/analysis"
 	"github.com/ECecillo/lib.go.sound/pkg/format"
 	"github.com/stretchr/testify/require"
 )
@@ -76,6 +80,22 @@ func TestCorrectness(t *testing.T) {
 	}
 }
 
+func TestFrequencyAccuracy(t *testing.T) {
+	for _, samplingRate := range []float64{44100, 48000} {
+		for _, frequency := range []float64{20, 100, 440, 1000, 4000, 10000, 20000} {
+			t.Run(fmt.Sprintf("%.0f_Hz_at_%.0f_Hz", frequency, samplingRate), func(t *testing.T) {
+				s := NewSine(frequency, time.Second, WithSamplingRate(samplingRate))
+
+				measured, err := s.MeasuredFrequency()
+				require.NoError(t, err)
+
+				tolerance := samplingRate / (2 * float64(s.totalSamples()))
+				require.InDelta(t, frequency, measured, tolerance)
+			})
+		}
+	}
+}
+
 func TestWriteTo(t *testing.T) {
 	sine := NewSine(440.0, time.Second)
 	buffer := &bytes.Buffer{}
@@ -204,6 +224,173 @@ func TestNyquistFiltering(t *testing.T) {
 	}
 }
 
+func TestNyquistCheck(t *testing.T) {
+	tests := []struct {
+		name         string
+		frequency    float64
+		samplingRate float64
+		expectError  bool
+	}{
+		{name: "below_nyquist_limit", frequency: 1000.0, samplingRate: 44100.0},
+		{name: "at_nyquist_limit", frequency: 22050.0, samplingRate: 44100.0, expectError: true},
+		{name: "above_nyquist_limit", frequency: 30000.0, samplingRate: 44100.0, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checked := NewSine(tt.frequency, 10*time.Millisecond,
+				WithSamplingRate(tt.samplingRate), WithNyquistCheck())
+
+			_, err := checked.Generate()
+			if tt.expectError {
+				require.ErrorIs(t, err, ErrNyquistViolation)
+
+				_, err = checked.WriteTo(&bytes.Buffer{})
+				require.ErrorIs(t, err, ErrNyquistViolation)
+			} else {
+				require.NoError(t, err)
+			}
+
+			// Without the option the call stays permissive.
+			permissive := NewSine(tt.frequency, 10*time.Millisecond, WithSamplingRate(tt.samplingRate))
+			samples, err := permissive.Generate()
+			require.NoError(t, err)
+			require.NotEmpty(t, samples)
+		})
+	}
+}
+
+func TestUnison(t *testing.T) {
+	t.Run("single_voice_matches_sine", func(t *testing.T) {
+		plain, err := NewSine(440.0, 50*time.Millisecond).Generate()
+		require.NoError(t, err)
+
+		unison, err := NewSine(440.0, 50*time.Millisecond, WithUnison(1, 5.0)).Generate()
+		require.NoError(t, err)
+
+		require.Equal(t, plain, unison)
+	})
+
+	t.Run("three_voices_spectrum", func(t *testing.T) {
+		// One second at 8192 Hz gives a power-of-two length and 1 Hz bins,
+		// so each detuned voice lands exactly on its own FFT bin.
+		const samplingRate = 8192.0
+		frequency := 1000.0
+
+		sine := NewSine(frequency, time.Second, WithSamplingRate(samplingRate), WithUnison(3, 5.0))
+		samples, err := sine.Generate()
+		require.NoError(t, err)
+		require.Len(t, samples, 8192)
+
+		spectrum, err := analysis.FFT(samples)
+		require.NoError(t, err)
+
+		peaks := map[int]bool{995: true, 1000: true, 1005: true}
+		for k := 1; k < len(spectrum)/2; k++ {
+			magnitude := cmplx.Abs(spectrum[k]) / float64(len(samples)/2)
+			if peaks[k] {
+				require.InDelta(t, 1.0/3.0, magnitude, 1e-6, "missing peak at %d Hz", k)
+			} else {
+				require.Less(t, magnitude, 1e-6, "unexpected energy at %d Hz", k)
+			}
+		}
+	})
+
+	t.Run("peak_within_amplitude", func(t *testing.T) {
+		sine := NewSine(440.0, time.Second, WithAmplitude(0.8), WithUnison(5, 7.0))
+		samples, err := sine.Generate()
+		require.NoError(t, err)
+
+		for _, v := range samples {
+			require.LessOrEqual(t, math.Abs(v), sine.Amplitude+1e-12)
+		}
+	})
+}
+
+func TestGainAutomation(t *testing.T) {
+	// 101 samples so that the middle sample sits exactly halfway along the ramp.
+	const samplingRate = 1000.0
+	duration := 101 * time.Millisecond
+
+	t.Run("linear_ramp", func(t *testing.T) {
+		expected, err := NewSine(30.0, duration, WithSamplingRate(samplingRate)).Generate()
+		require.NoError(t, err)
+		last := len(expected) - 1
+
+		samples, err := NewSine(30.0, duration, WithSamplingRate(samplingRate),
+			WithGainAutomation([]AutoPoint{{SampleIndex: 0, Gain: 0}, {SampleIndex: last, Gain: 1.0}}),
+		).Generate()
+		require.NoError(t, err)
+
+		mid := last / 2
+		require.NotZero(t, expected[mid])
+		require.InDelta(t, 0.5*expected[mid], samples[mid], 1e-9)
+		require.Zero(t, samples[0])
+		require.InDelta(t, expected[last], samples[last], 1e-9)
+	})
+
+	t.Run("constant_matches_amplitude", func(t *testing.T) {
+		expected, err := NewSine(30.0, duration, WithSamplingRate(samplingRate), WithAmplitude(0.4)).Generate()
+		require.NoError(t, err)
+
+		samples, err := NewSine(30.0, duration, WithSamplingRate(samplingRate),
+			WithGainAutomation([]AutoPoint{{SampleIndex: 0, Gain: 0.4}, {SampleIndex: 100, Gain: 0.4}}),
+		).Generate()
+		require.NoError(t, err)
+
+		require.InDeltaSlice(t, expected, samples, 1e-12)
+	})
+
+	t.Run("unsorted_points", func(t *testing.T) {
+		sine := NewSine(30.0, duration, WithSamplingRate(samplingRate),
+			WithGainAutomation([]AutoPoint{{SampleIndex: 50, Gain: 1}, {SampleIndex: 10, Gain: 0}}),
+		)
+
+		_, err := sine.Generate()
+		require.ErrorIs(t, err, ErrUnsortedPoints)
+	})
+}
+
+func TestSampleAt(t *testing.T) {
+	configs := []struct {
+		name string
+		sine *Sine
+	}{
+		{name: "plain", sine: NewSine(440.0, 20*time.Millisecond, WithAmplitude(0.7))},
+		{name: "unison", sine: NewSine(440.0, 20*time.Millisecond, WithUnison(3, 6.0))},
+		{name: "automation", sine: NewSine(440.0, 20*time.Millisecond,
+			WithGainAutomation([]AutoPoint{{SampleIndex: 0, Gain: 0}, {SampleIndex: 800, Gain: 1}}))},
+	}
+
+	for _, tt := range configs {
+		t.Run(tt.name, func(t *testing.T) {
+			samples, err := tt.sine.Generate()
+			require.NoError(t, err)
+
+			for i, expected := range samples {
+				value, err := tt.sine.SampleAt(i)
+				require.NoError(t, err)
+				require.Equal(t, expected, value, "sample %d", i)
+			}
+
+			_, err = tt.sine.SampleAt(len(samples))
+			require.ErrorIs(t, err, ErrIndexOutOfRange)
+			_, err = tt.sine.SampleAt(-1)
+			require.ErrorIs(t, err, ErrIndexOutOfRange)
+		})
+	}
+}
+
+func TestSampleAt_DoesNotGenerate(t *testing.T) {
+	// A thousand hours of audio is far too large to generate; SampleAt
+	// must only compute the requested value.
+	sine := NewSine(440.0, 1000*time.Hour)
+
+	value, err := sine.SampleAt(150_000_000_000)
+	require.NoError(t, err)
+	require.Equal(t, sine.calculateSampleValue(150_000_000_000), value)
+}
+
 func TestAntiAliasingFilter(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -510,3 +697,397 @@ func TestGoldenFiles_Consistency(t *testing.T) {
 	require.Equal(t, outputs[0], outputs[2], "First and third generation differ")
 	require.Equal(t, outputs[1], outputs[2], "Second and third generation differ")
 }
+
+func TestClone(t *testing.T) {
+	original := NewSine(440.0, 100*time.Millisecond, WithAmplitude(0.8))
+
+	expected, err := original.Generate()
+	require.NoError(t, err)
+
+	clone := original.Clone()
+	require.NotSame(t, original, clone)
+
+	samples, err := clone.Generate()
+	require.NoError(t, err)
+	require.Equal(t, expected, samples, "clone without options should generate identical samples")
+}
+
+func TestCloneWithOptions(t *testing.T) {
+	original := NewSine(440.0, 100*time.Millisecond, WithAmplitude(1.0))
+	expected, err := original.Generate()
+	require.NoError(t, err)
+
+	clone := original.Clone(WithAmplitude(0.5), WithFormat(format.PCM32{}))
+	require.Equal(t, 0.5, clone.Amplitude)
+	require.Equal(t, format.PCM32{}, clone.Format)
+
+	halved, err := clone.Generate()
+	require.NoError(t, err)
+	require.Len(t, halved, len(expected))
+	for i := range expected {
+		require.InDelta(t, expected[i]/2, halved[i], 1e-12, "sample %d is not half the original", i)
+	}
+
+	// The original must be left untouched.
+	require.Equal(t, 1.0, original.Amplitude)
+	require.Equal(t, format.PCM16{}, original.Format)
+
+	after, err := original.Generate()
+	require.NoError(t, err)
+	require.Equal(t, expected, after)
+}
+
+func TestWithSampleCount(t *testing.T) {
+	s := NewSine(440.0, 0, WithSampleCount(4410))
+	require.Equal(t, 100*time.Millisecond, s.Duration)
+
+	samples, err := s.Generate()
+	require.NoError(t, err)
+	require.Len(t, samples, 4410)
+
+	// Going through a nanosecond Duration truncates the count for many
+	// lengths, while WithSampleCount always yields exactly n samples.
+	truncated := 0
+	for n := 1; n <= 2000; n++ {
+		counted, err := NewSine(440.0, 0, WithSampleCount(n)).Generate()
+		require.NoError(t, err)
+		require.Len(t, counted, n)
+
+		duration := time.Duration(n) * time.Second / 44100
+		timed, err := NewSine(440.0, duration).Generate()
+		require.NoError(t, err)
+		if len(timed) != n {
+			truncated++
+		}
+	}
+	require.Positive(t, truncated)
+}
+
+func TestWithSampleCount_OptionOrder(t *testing.T) {
+	before := NewSine(440.0, 0, WithSampleCount(4800), WithSamplingRate(48000))
+	after := NewSine(440.0, 0, WithSamplingRate(48000), WithSampleCount(4800))
+
+	require.Equal(t, before, after)
+	require.Equal(t, 100*time.Millisecond, before.Duration)
+
+	// Changing the sampling rate keeps the count and adjusts Duration.
+	clone := before.Clone(WithSamplingRate(96000))
+	require.Equal(t, 4800, clone.SampleCount)
+	require.Equal(t, 50*time.Millisecond, clone.Duration)
+
+	// A sweep follows its own duration.
+	require.Zero(t, before.SweepTo(880.0, time.Second).SampleCount)
+}
+
+func TestWithInitialSampleValues(t *testing.T) {
+	amplitude, phase := 0.8, 0.3
+	omega := 2 * math.Pi * 440.0 / 44100.0
+
+	direct, err := NewSine(440.0, 0, WithSampleCount(1000), WithAmplitude(amplitude), WithPhase(phase)).Generate()
+	require.NoError(t, err)
+
+	recursive, err := NewSine(440.0, 0, WithSampleCount(1000), WithInitialSampleValues(
+		amplitude*math.Sin(phase-omega),
+		amplitude*math.Sin(phase-2*omega),
+	)).Generate()
+	require.NoError(t, err)
+
+	require.Len(t, recursive, len(direct))
+	for n := range direct {
+		require.InDelta(t, direct[n], recursive[n], 1e-10, "sample %d", n)
+	}
+}
+
+func TestWithInitialSampleValues_RestState(t *testing.T) {
+	// A resonator at rest stays silent, whatever Amplitude says.
+	samples, err := NewSine(440.0, 10*time.Millisecond, WithInitialSampleValues(0, 0)).Generate()
+	require.NoError(t, err)
+	for _, v := range samples {
+		require.Zero(t, v)
+	}
+}
+
+func TestWithStartSampleIndex_Chunks(t *testing.T) {
+	options := []Option{WithAmplitude(0.7), WithPhase(0.4), WithSamplingRate(48000)}
+
+	whole, err := NewSine(997.0, 0, append(options, WithSampleCount(3000))...).Generate()
+	require.NoError(t, err)
+
+	var chunked []float64
+	for start := 0; start < 3000; start += 1000 {
+		chunk, err := NewSine(997.0, 0, append(options, WithSampleCount(1000), WithStartSampleIndex(start))...).Generate()
+		require.NoError(t, err)
+		chunked = append(chunked, chunk...)
+	}
+
+	require.Equal(t, whole, chunked)
+}
+
+func TestWithStartSampleIndex_FollowsAutomation(t *testing.T) {
+	automation := WithGainAutomation([]AutoPoint{{SampleIndex: 0, Gain: 0}, {SampleIndex: 2000, Gain: 1}})
+
+	whole, err := NewSine(440.0, 0, automation, WithSampleCount(2000)).Generate()
+	require.NoError(t, err)
+
+	second := NewSine(440.0, 0, automation, WithSampleCount(1000), WithStartSampleIndex(1000))
+	chunk, err := second.Generate()
+	require.NoError(t, err)
+	require.Equal(t, whole[1000:], chunk)
+
+	value, err := second.SampleAt(10)
+	require.NoError(t, err)
+	require.Equal(t, whole[1010], value)
+
+	// The next segment starts where the whole signal ends.
+	full := NewSine(440.0, 0, WithSampleCount(2000))
+	require.InDelta(t, full.NextStartPhase(), second.NextStartPhase(), 1e-9)
+}
+
+func TestString(t *testing.T) {
+	tests := []struct {
+		name string
+		sine *Sine
+		want string
+	}{
+		{
+			name: "defaults",
+			sine: NewSine(440.0, 2*time.Second),
+			want: "Sine{440.0Hz, 2s, amp=1.0, rate=44100Hz, PCM16}",
+		},
+		{
+			name: "float32",
+			sine: NewSine(261.63, 500*time.Millisecond, WithAmplitude(0.75), WithSamplingRate(48000), WithFormat(format.Float32{})),
+			want: "Sine{261.63Hz, 500ms, amp=0.75, rate=48000Hz, Float32}",
+		},
+		{
+			name: "custom format",
+			sine: NewSine(1000.0, time.Second, WithFormat(format.CrossfadeFormat(format.PCM8{}, format.PCM16{}, 0.5))),
+			want: "Sine{1000.0Hz, 1s, amp=1.0, rate=44100Hz, custom}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, tt.sine.String())
+			require.Equal(t, tt.want, fmt.Sprintf("%v", tt.sine))
+			require.Equal(t, tt.want, fmt.Sprintf("%v", *tt.sine))
+		})
+	}
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	formats := []format.AudioFormat{
+		format.PCM8{},
+		format.PCM16{},
+		format.PCM32{},
+		format.Float32{},
+		format.Float64{},
+	}
+
+	for _, f := range formats {
+		t.Run(fmt.Sprintf("%T", f), func(t *testing.T) {
+			original := NewSine(
+				440.0,
+				2*time.Second,
+				WithAmplitude(0.75),
+				WithSamplingRate(48000.0),
+				WithFormat(f),
+				WithNyquistCheck(),
+				WithUnison(3, 4.5),
+				WithGainAutomation([]AutoPoint{{SampleIndex: 0, Gain: 0.2}, {SampleIndex: 100, Gain: 0.9}}),
+				WithSampleCount(96017),
+				WithStartSampleIndex(12),
+			)
+			original.Phase = 1.25
+
+			data, err := json.Marshal(original)
+			require.NoError(t, err)
+
+			var decoded Sine
+			require.NoError(t, json.Unmarshal(data, &decoded))
+			require.Equal(t, *original, decoded)
+		})
+	}
+}
+
+func TestJSONEncoding(t *testing.T) {
+	data, err := json.Marshal(NewSine(440.0, 2*time.Second))
+	require.NoError(t, err)
+	require.JSONEq(t,
+		`{"format":"PCM16","duration":"2s","frequency":440,"amplitude":1,"samplingRate":44100}`,
+		string(data))
+}
+
+func TestJSONUnmarshalDefaults(t *testing.T) {
+	var s Sine
+	require.NoError(t, json.Unmarshal([]byte(`{"frequency":220,"duration":"500ms"}`), &s))
+	require.Equal(t, *NewSine(220.0, 500*time.Millisecond), s)
+}
+
+func TestJSONErrors(t *testing.T) {
+	var s Sine
+	require.Error(t, json.Unmarshal([]byte(`{"duration":"forever"}`), &s))
+	require.Error(t, json.Unmarshal([]byte(`{"format":"PCM24"}`), &s))
+
+	type unregistered struct{ format.PCM16 }
+	_, err := json.Marshal(NewSine(440.0, time.Second, WithFormat(unregistered{})))
+	require.Error(t, err)
+}
+
+// zeroOrderHoldRMS renders samples through an oversampled zero-order hold
+// DAC model and returns the RMS of the reconstructed component at freq.
+func zeroOrderHoldRMS(samples []float64, freq, sampleRate float64) float64 {
+	const oversampling = 16
+
+	var sum complex128
+	n := 0
+	for _, v := range samples {
+		for range oversampling {
+			angle := -2 * math.Pi * freq * float64(n) / (sampleRate * oversampling)
+			sum += complex(v, 0) * cmplx.Exp(complex(0, angle))
+			n++
+		}
+	}
+
+	return 2 * cmplx.Abs(sum) / float64(n) / math.Sqrt2
+}
+
+func TestWithNyquistCompensation(t *testing.T) {
+	const sampleRate = 44100.0
+	duration := 100 * time.Millisecond
+
+	measure := func(freq float64, options ...Option) float64 {
+		samples, err := NewSine(freq, duration, options...).Generate()
+		require.NoError(t, err)
+		return 20 * math.Log10(zeroOrderHoldRMS(samples, freq, sampleRate))
+	}
+
+	reference := measure(1000, WithAmplitude(0.5), WithNyquistCompensation())
+
+	drooped := measure(18000, WithAmplitude(0.5))
+	require.Greater(t, reference-drooped, 2.0, "uncompensated tone should droop near Nyquist")
+
+	compensated := measure(18000, WithAmplitude(0.5), WithNyquistCompensation())
+	require.InDelta(t, reference, compensated, 0.5)
+
+	plain, err := NewSine(1000, duration).Generate()
+	require.NoError(t, err)
+	boosted, err := NewSine(1000, duration, WithNyquistCompensation()).Generate()
+	require.NoError(t, err)
+	gain := math.Pi * 1000 / sampleRate / math.Sin(math.Pi*1000/sampleRate)
+	for i := range plain {
+		require.InDelta(t, plain[i]*gain, boosted[i], 1e-12)
+	}
+}
+
+func TestWithSampleRate(t *testing.T) {
+	withSampleRate := NewSine(440.0, 100*time.Millisecond, WithSampleRate(22050))
+	withSamplingRate := NewSine(440.0, 100*time.Millisecond, WithSamplingRate(22050))
+	require.Equal(t, withSamplingRate, withSampleRate)
+
+	a, err := withSampleRate.Generate()
+	require.NoError(t, err)
+	b, err := withSamplingRate.Generate()
+	require.NoError(t, err)
+	require.Equal(t, b, a)
+	require.Len(t, a, 2205)
+
+	require.Equal(t, 22050.0, withSampleRate.SampleRate())
+	require.Equal(t, 44100.0, NewSine(440.0, time.Second).SampleRate())
+}
+
+func TestWithInitialSampleValues_OutputPathsAgree(t *testing.T) {
+	omega := 2 * math.Pi * 440.0 / 44100.0
+	s := NewSine(440.0, 0,
+		WithSampleCount(2000),
+		WithAmplitude(0.5),
+		WithFormat(format.Float64{}),
+		WithInitialSampleValues(math.Sin(0.3-omega), math.Sin(0.3-2*omega)),
+	)
+
+	generated, err := s.Generate()
+	require.NoError(t, err)
+
+	// The seeds describe a unit sine of phase 0.3, scaled by Amplitude.
+	for n := range generated {
+		require.InDelta(t, 0.5*math.Sin(omega*float64(n)+0.3), generated[n], 1e-10, "sample %d", n)
+	}
+
+	for _, index := range []int{0, 5, 1999, 3} {
+		value, err := s.SampleAt(index)
+		require.NoError(t, err)
+		require.Equal(t, generated[index], value, "SampleAt(%d)", index)
+	}
+
+	var chunked, whole bytes.Buffer
+	_, err = s.WriteToChunked(&chunked, 128)
+	require.NoError(t, err)
+	_, err = s.WriteTo(&whole)
+	require.NoError(t, err)
+	require.Equal(t, whole.Bytes(), chunked.Bytes())
+
+	buf := make([]float64, len(generated))
+	FillBuffer(s.AsSynthesizer(), buf)
+	require.Equal(t, generated, buf)
+
+	// Chunks cut with a start index continue the same recursion.
+	second, err := s.Clone(WithSampleCount(1000), WithStartSampleIndex(1000)).Generate()
+	require.NoError(t, err)
+	require.Equal(t, generated[1000:], second)
+
+	// A sweep picks up the phase implied by the seeds.
+	require.InDelta(t, wrapPhase(omega*2000+0.3), s.NextStartPhase(), 1e-9)
+}
+
+func TestWithInitialSampleValues_Conflicts(t *testing.T) {
+	seeds := WithInitialSampleValues(0.1, 0.2)
+
+	for name, s := range map[string]*Sine{
+		"phase":          NewSine(440.0, time.Second, seeds, WithPhase(1)),
+		"unison":         NewSine(440.0, time.Second, seeds, WithUnison(3, 2)),
+		"negative start": NewSine(440.0, time.Second, seeds, WithStartSampleIndex(-4)),
+	} {
+		_, err := s.Generate()
+		require.ErrorIs(t, err, ErrInitialValuesConflict, name)
+		_, err = s.SampleAt(0)
+		require.ErrorIs(t, err, ErrInitialValuesConflict, name)
+		_, err = s.WriteToChunked(&bytes.Buffer{}, 64)
+		require.ErrorIs(t, err, ErrInitialValuesConflict, name)
+	}
+}
+
+func TestWithInitialSampleValues_CloneJSONSweep(t *testing.T) {
+	s := NewSine(440.0, 10*time.Millisecond, WithInitialSampleValues(0.1, 0.2))
+
+	data, err := json.Marshal(s)
+	require.NoError(t, err)
+	var decoded Sine
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	require.Equal(t, *s, decoded)
+
+	clone := s.Clone()
+	require.Equal(t, s, clone)
+	require.NotSame(t, s.initialValues, clone.initialValues)
+
+	next := s.SweepTo(880.0, 10*time.Millisecond)
+	require.Nil(t, next.initialValues)
+	_, err = next.Generate()
+	require.NoError(t, err)
+}
+
+func TestSampler_MatchesCalculateSampleValue(t *testing.T) {
+	for name, s := range map[string]*Sine{
+		"plain":        NewSine(440.0, 10*time.Millisecond),
+		"phase":        NewSine(1000.0, 10*time.Millisecond, WithPhase(1.2), WithAmplitude(0.3)),
+		"start index":  NewSine(440.0, 10*time.Millisecond, WithStartSampleIndex(12345)),
+		"compensation": NewSine(15000.0, 10*time.Millisecond, WithNyquistCompensation()),
+		"automation": NewSine(440.0, 10*time.Millisecond,
+			WithGainAutomation([]AutoPoint{{SampleIndex: 0, Gain: 0}, {SampleIndex: 441, Gain: 1}})),
+		"above nyquist": NewSine(30000.0, 10*time.Millisecond),
+	} {
+		sample := s.sampler()
+		for n := range s.totalSamples() {
+			require.Equal(t, s.calculateSampleValue(n)*s.gainAt(n), sample(n), "%s: sample %d", name, n)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package sine
+
+import (
+	"math"
+	"time"
+)
+
+// SweepTo returns a Sine at newFreq lasting sweepDuration that starts at the
+// phase reached at the end of s, so that writing both back to back does not
+// produce a click at the boundary:
+//
+//	phase = (2π * Frequency * Duration + Phase) mod 2π
+//
+// Duration is measured in whole samples, as generated, so that the phase
+// stays continuous when it is not a multiple of the sampling period.
+// Every other setting is inherited from s, except the gain automation which
+// is tied to the sample indexes of s, and the seeds of
+// WithInitialSampleValues, which only describe the start of s: the new
+// segment is computed directly, at Amplitude.
+func (s Sine) SweepTo(newFreq float64, sweepDuration time.Duration) *Sine {
+	next := s.Clone()
+	next.Frequency = newFreq
+	next.Duration = sweepDuration
+	next.SampleCount = 0
+	next.StartIndex = 0
+	next.Phase = s.NextStartPhase()
+	next.Automation = nil
+	next.endPhase = nil
+	next.initialValues = nil
+	next.AchievedEndPhase = 0
+
+	return next
+}
+
+// NextStartPhase returns the phase, in [0, 2π), at which a segment written
+// right after s must start to continue it without a discontinuity:
+//
+//	phase = (2π * Frequency * Duration + Phase) mod 2π
+//
+// Like SweepTo, Duration is measured in whole samples, counted from
+// StartIndex.
+func (s Sine) NextStartPhase() float64 {
+	elapsed := float64(s.StartIndex+s.totalSamples()) / s.SamplingRate
+	return wrapPhase(2*math.Pi*s.Frequency*elapsed + s.startPhase())
+}
+
+// startPhase returns the phase of the sine at virtual sample 0. With
+// WithInitialSampleValues it is the phase φ of the sine A*sin(ωn+φ) that
+// goes through the seeds:
+//
+//	A*sin(φ) = y[0] = 2*cos(ω)*y[-1] - y[-2]
+//	A*cos(φ) = (y[0]*cos(ω) - y[-1]) / sin(ω)
+func (s Sine) startPhase() float64 {
+	omega := 2 * math.Pi * s.Frequency / s.SamplingRate
+	if s.initialValues == nil || math.Sin(omega) == 0 {
+		return s.Phase
+	}
+
+	y1, y2 := s.initialValues[0], s.initialValues[1]
+	y0 := 2*math.Cos(omega)*y1 - y2
+	return math.Atan2(y0, (y0*math.Cos(omega)-y1)/math.Sin(omega))
+}
+
+// phaseAt returns the phase, in [0, 2π), of the sine at generated sample
+// n, counted from StartIndex. Unison voices are measured at Frequency.
+func (s Sine) phaseAt(n int) float64 {
+	t := float64(s.StartIndex+n) / s.SamplingRate
+	return wrapPhase(2*math.Pi*s.Frequency*t + s.startPhase())
+}
+
+// EndPhaseError returns how far the phase reached at the last sample of
+// the previous Generate is from the target of WithEndPhase, wrapped into
+// [-π, π). It is 0 when WithEndPhase is not used.
+func (s Sine) EndPhaseError() float64 {
+	if s.endPhase == nil {
+		return 0
+	}
+	return wrapPhase(s.AchievedEndPhase-*s.endPhase+math.Pi) - math.Pi
+}
+
+// wrapPhase brings phase into [0, 2π).
+func wrapPhase(phase float64) float64 {
+	phase = math.Mod(phase, 2*math.Pi)
+	if phase < 0 {
+		phase += 2 * math.Pi
+	}
+	return phase
+}
@@ -0,0 +1,177 @@
+package sine
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSweepTo_PhaseContinuity(t *testing.T) {
+	// One PCM16 quantization step.
+	lsb := 1.0 / math.MaxInt16
+
+	tests := []struct {
+		name    string
+		from    float64
+		to      float64
+		initial time.Duration
+	}{
+		{name: "up_an_octave", from: 440.0, to: 880.0, initial: 100 * time.Millisecond},
+		{name: "down_non_integer", from: 523.25, to: 261.63, initial: 37 * time.Millisecond},
+		{name: "small_step", from: 1000.0, to: 1010.0, initial: 250 * time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			first := NewSine(tt.from, tt.initial, WithAmplitude(0.9))
+			second := first.SweepTo(tt.to, 50*time.Millisecond)
+
+			require.Equal(t, tt.to, second.Frequency)
+			require.Equal(t, 50*time.Millisecond, second.Duration)
+			require.Equal(t, first.Amplitude, second.Amplitude)
+
+			a, err := first.Generate()
+			require.NoError(t, err)
+			b, err := second.Generate()
+			require.NoError(t, err)
+
+			// The first sample of the new segment is where the old one would
+			// have continued, and where the new frequency starts.
+			boundary := len(a)
+			continued := first.calculateSampleValue(boundary)
+			require.InDelta(t, continued, b[0], lsb)
+			require.InDelta(t, first.Amplitude*math.Sin(second.Phase), b[0], lsb)
+
+			// The step across the boundary is no larger than the steepest
+			// step inside either segment.
+			maxStep := 0.0
+			for _, samples := range [][]float64{a, b} {
+				for i := 1; i < len(samples); i++ {
+					maxStep = math.Max(maxStep, math.Abs(samples[i]-samples[i-1]))
+				}
+			}
+			require.LessOrEqual(t, math.Abs(b[0]-a[len(a)-1]), maxStep+lsb)
+		})
+	}
+}
+
+func TestSweepTo_KeepsOriginal(t *testing.T) {
+	original := NewSine(440.0, 100*time.Millisecond,
+		WithGainAutomation([]AutoPoint{{SampleIndex: 0, Gain: 0}, {SampleIndex: 100, Gain: 1}}))
+
+	next := original.SweepTo(660.0, time.Second)
+
+	require.Equal(t, 440.0, original.Frequency)
+	require.Zero(t, original.Phase)
+	require.NotEmpty(t, original.Automation)
+	require.Empty(t, next.Automation)
+	require.GreaterOrEqual(t, next.Phase, 0.0)
+	require.Less(t, next.Phase, 2*math.Pi)
+
+	// 100ms is a whole number of samples, so the phase follows the
+	// duration directly.
+	expected := math.Mod(2*math.Pi*440.0*0.1, 2*math.Pi)
+	require.InDelta(t, expected, next.Phase, 1e-9)
+}
+
+func TestNextStartPhase_GaplessSegments(t *testing.T) {
+	const frequency = 437.3
+	durations := []time.Duration{30 * time.Millisecond, 40 * time.Millisecond, 30 * time.Millisecond}
+
+	var concatenated []float64
+	phase := 0.3
+	for i, duration := range durations {
+		segment := NewSine(frequency, duration, WithStartPhase(phase))
+		require.Equal(t, phase, segment.Phase, "segment %d", i)
+
+		samples, err := segment.Generate()
+		require.NoError(t, err)
+		concatenated = append(concatenated, samples...)
+
+		phase = segment.NextStartPhase()
+		require.GreaterOrEqual(t, phase, 0.0)
+		require.Less(t, phase, 2*math.Pi)
+	}
+
+	// Back to back, the segments match one continuous tone.
+	whole := NewSine(frequency, 100*time.Millisecond, WithPhase(0.3))
+	expected, err := whole.Generate()
+	require.NoError(t, err)
+	require.Len(t, concatenated, len(expected))
+	require.InDeltaSlice(t, expected, concatenated, 1e-9)
+}
+
+func TestNextStartPhase_Formula(t *testing.T) {
+	s := NewSine(440.0, time.Second, WithPhase(1.0))
+	require.InDelta(t, math.Mod(2*math.Pi*440.0*1.0+1.0, 2*math.Pi), s.NextStartPhase(), 1e-9)
+}
+
+func TestWithEndPhase(t *testing.T) {
+	tests := []struct {
+		name    string
+		options []Option
+	}{
+		{name: "zero start phase"},
+		{name: "start phase", options: []Option{WithStartPhase(1)}},
+		{name: "start phase set after", options: []Option{WithEndPhase(2), WithPhase(-0.5)}},
+		{name: "sampling rate", options: []Option{WithSamplingRate(8000)}},
+		{name: "start index", options: []Option{WithStartSampleIndex(1000)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := append([]Option{WithEndPhase(math.Pi / 2)}, tt.options...)
+			s := NewSine(523.25, 37*time.Millisecond, options...)
+			phase := s.Phase
+			require.Zero(t, s.AchievedEndPhase)
+
+			samples, err := s.Generate()
+			require.NoError(t, err)
+			require.Equal(t, phase, s.Phase, "the start phase is kept as given")
+			require.GreaterOrEqual(t, s.AchievedEndPhase, 0.0)
+			require.Less(t, s.AchievedEndPhase, 2*math.Pi)
+
+			// The phase of the last sample is one sampling period behind
+			// the start of the next segment.
+			last := samples[len(samples)-1]
+			require.InDelta(t, math.Sin(s.AchievedEndPhase), last, 1e-9)
+			step := 2 * math.Pi * s.Frequency / s.SamplingRate
+			require.InDelta(t, s.NextStartPhase(), wrapPhase(s.AchievedEndPhase+step), 1e-9)
+		})
+	}
+}
+
+func TestWithEndPhase_FollowsChanges(t *testing.T) {
+	s := NewSine(440.0, 10*time.Millisecond, WithEndPhase(0))
+	_, err := s.Generate()
+	require.NoError(t, err)
+	short := s.AchievedEndPhase
+
+	s.Duration = 20 * time.Millisecond
+	samples, err := s.Generate()
+	require.NoError(t, err)
+	require.NotEqual(t, short, s.AchievedEndPhase)
+	require.InDelta(t, math.Sin(s.AchievedEndPhase), samples[len(samples)-1], 1e-9)
+
+	plain := NewSine(440.0, 10*time.Millisecond)
+	_, err = plain.Generate()
+	require.NoError(t, err)
+	require.Zero(t, plain.AchievedEndPhase)
+	require.Zero(t, plain.EndPhaseError())
+}
+
+func TestEndPhaseError(t *testing.T) {
+	// 441 samples of 100 Hz at 44100 Hz cover one period: the last sample
+	// sits one sampling period before the start phase.
+	step := 2 * math.Pi * 100 / 44100
+	s := NewSine(100.0, 10*time.Millisecond, WithEndPhase(2*math.Pi-step))
+
+	_, err := s.Generate()
+	require.NoError(t, err)
+	require.InDelta(t, 0.0, s.EndPhaseError(), 1e-9)
+
+	s.endPhase = new(float64)
+	require.InDelta(t, -step, s.EndPhaseError(), 1e-9)
+}
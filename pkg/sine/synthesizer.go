@@ -0,0 +1,44 @@
+package sine
+
+// Synthesizer produces one sample per call, as expected by real-time audio
+// engines that pull samples from a callback.
+type Synthesizer interface {
+	NextSample() float64
+}
+
+// sineSynthesizer walks through the samples of a Sine, keeping track of
+// the next sample index.
+type sineSynthesizer struct {
+	voices       []func(n int) float64
+	totalSamples int
+	next         int
+}
+
+// AsSynthesizer returns a stateful Synthesizer yielding the same values as
+// Generate, one at a time, then 0.0 once Duration is exhausted. Validation
+// errors such as ErrNyquistViolation cannot be reported through
+// NextSample; call Generate or SampleAt first when they matter.
+func (s Sine) AsSynthesizer() Synthesizer {
+	return &sineSynthesizer{
+		voices:       s.voiceSamplers(),
+		totalSamples: s.totalSamples(),
+	}
+}
+
+func (ss *sineSynthesizer) NextSample() float64 {
+	if ss.next >= ss.totalSamples {
+		return 0.0
+	}
+
+	value := mixAt(ss.voices, ss.next)
+	ss.next++
+
+	return value
+}
+
+// FillBuffer fills buf by calling s.NextSample once per element.
+func FillBuffer(s Synthesizer, buf []float64) {
+	for i := range buf {
+		buf[i] = s.NextSample()
+	}
+}
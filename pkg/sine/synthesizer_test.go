@@ -0,0 +1,66 @@
+package sine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFillBuffer(t *testing.T) {
+	configs := []struct {
+		name string
+		sine *Sine
+	}{
+		{name: "plain", sine: NewSine(440.0, 50*time.Millisecond, WithAmplitude(0.6))},
+		{name: "unison", sine: NewSine(440.0, 50*time.Millisecond, WithUnison(3, 4.0))},
+	}
+
+	for _, tt := range configs {
+		t.Run(tt.name, func(t *testing.T) {
+			expected, err := tt.sine.Generate()
+			require.NoError(t, err)
+
+			// 2205 samples is not a multiple of 300, so the last buffer straddles
+			// the end of the signal.
+			synth := tt.sine.AsSynthesizer()
+			buf := make([]float64, 300)
+
+			var got []float64
+			for len(got) < len(expected) {
+				FillBuffer(synth, buf)
+				got = append(got, buf...)
+			}
+
+			require.Equal(t, expected, got[:len(expected)])
+			for i, v := range got[len(expected):] {
+				require.Zero(t, v, "sample %d after the end should be silent", len(expected)+i)
+			}
+		})
+	}
+}
+
+func TestNextSample_AfterExhaustion(t *testing.T) {
+	sine := NewSine(440.0, time.Millisecond)
+	synth := sine.AsSynthesizer()
+
+	for range 44 {
+		synth.NextSample()
+	}
+
+	for range 10 {
+		require.Equal(t, 0.0, synth.NextSample())
+	}
+}
+
+func TestAsSynthesizer_IndependentState(t *testing.T) {
+	sine := NewSine(440.0, 10*time.Millisecond)
+
+	first := sine.AsSynthesizer()
+	first.NextSample()
+	first.NextSample()
+
+	second := sine.AsSynthesizer()
+	require.Equal(t, sine.calculateSampleValue(0), second.NextSample())
+	require.Equal(t, sine.calculateSampleValue(2), first.NextSample())
+}
@@ -1,6 +1,10 @@
 package sine
 
 import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/ECecillo/lib.go.sound/pkg/format"
@@ -11,7 +15,29 @@ type Sine struct {
 	Duration     time.Duration // Duration of the signal
 	Frequency    float64       // Frequency in Hz
 	Amplitude    float64       // Amplitude (optional, default 1.0)
+	Phase        float64       // Initial phase in radians at sample 0
 	SamplingRate float64       // Sampling frequency in Hz
+	NyquistCheck bool          // Reject frequencies at or above SamplingRate/2 in Generate
+	NyquistComp  bool          // Boost Amplitude to undo zero-order hold droop
+	UnisonVoices int           // Number of stacked detuned voices (0 or 1 disables unison)
+	UnisonDetune float64       // Maximum detuning in Hz applied to the outermost voices
+	Automation   []AutoPoint   // Gain breakpoints interpolated per sample in Generate
+	SampleCount  int           // Exact number of samples, overrides Duration when positive
+	StartIndex   int           // Virtual index of the first generated sample
+
+	// AchievedEndPhase is the phase in radians, in [0, 2π), of the last
+	// sample produced by Generate. It is only filled in when WithEndPhase
+	// is used.
+	AchievedEndPhase float64
+
+	endPhase      *float64    // Target phase of the last sample set by WithEndPhase
+	initialValues *[2]float64 // y[-1] and y[-2] set by WithInitialSampleValues
+}
+
+// AutoPoint is a gain breakpoint used by WithGainAutomation.
+type AutoPoint struct {
+	SampleIndex int     `json:"sampleIndex"`
+	Gain        float64 `json:"gain"`
 }
 
 type Option func(*Sine)
@@ -28,6 +54,7 @@ func NewSine(frequency float64, duration time.Duration, options ...Option) *Sine
 	for _, opt := range options {
 		opt(sine)
 	}
+	sine.resolveSampleCount()
 
 	return sine
 }
@@ -38,14 +65,265 @@ func WithAmplitude(amplitude float64) Option {
 	}
 }
 
+// WithSamplingRate sets the sampling frequency in Hz.
+//
+// Deprecated: use WithSampleRate, which follows the "sample rate" naming
+// of the WAV format and of the other packages.
 func WithSamplingRate(rate float64) Option {
 	return func(s *Sine) {
 		s.SamplingRate = rate
 	}
 }
 
+// WithSampleRate sets the sampling frequency in Hz.
+func WithSampleRate(rate float64) Option {
+	return WithSamplingRate(rate)
+}
+
 func WithFormat(fmt format.AudioFormat) Option {
 	return func(s *Sine) {
 		s.Format = fmt
 	}
 }
+
+// WithPhase sets the phase in radians of the sine at sample 0.
+func WithPhase(phi float64) Option {
+	return func(s *Sine) {
+		s.Phase = phi
+	}
+}
+
+// WithStartPhase is an alias for WithPhase, paired with WithEndPhase.
+func WithStartPhase(phi float64) Option {
+	return WithPhase(phi)
+}
+
+// WithEndPhase sets phi, in radians, as the phase the last sample is meant
+// to reach. Phase is left as given: Generate measures the phase actually
+// reached at its last sample and stores it in AchievedEndPhase, and
+// EndPhaseError compares it with phi.
+func WithEndPhase(phi float64) Option {
+	return func(s *Sine) {
+		s.endPhase = &phi
+	}
+}
+
+// WithSampleCount makes the sine exactly n samples long, for callers that
+// size their signals after a hardware buffer. Deriving the count from
+// Duration goes through nanoseconds and can fall one sample short, so n is
+// kept in SampleCount and used as is by Generate. Duration is set to
+//
+//	Duration = n * 1s / SamplingRate
+//
+// once every option has been applied, so it does not depend on the order
+// of WithSamplingRate.
+func WithSampleCount(n int) Option {
+	return func(s *Sine) {
+		s.SampleCount = n
+	}
+}
+
+// WithInitialSampleValues makes Generate run the sine as a second-order
+// recursive oscillator, the resonator obtained with an IIR filter whose
+// poles sit on the unit circle at ±ω:
+//
+//	y[n] = 2*cos(ω)*y[n-1] - y[n-2],    ω = 2π * Frequency / SamplingRate
+//
+// seeded with y[-1] = y1 and y[-2] = y2, the samples preceding virtual
+// sample 0. The seeds set the phase and the relative level of the sine:
+// seeding with sin(φ-ω) and sin(φ-2ω) reproduces a sine of phase φ, which
+// Amplitude, Nyquist compensation and gain automation then scale as usual.
+// Phase must stay 0 and unison is not supported, Generate returns
+// ErrInitialValuesConflict otherwise. Each sample only costs a
+// multiplication and a subtraction, but rounding errors are fed back and
+// accumulate, so the output slowly drifts away from the exact sine in
+// amplitude and phase over long signals. Every output path, SampleAt and
+// WriteToChunked included, runs the same recursion.
+func WithInitialSampleValues(y1, y2 float64) Option {
+	return func(s *Sine) {
+		s.initialValues = &[2]float64{y1, y2}
+	}
+}
+
+// WithStartSampleIndex makes the sine start at virtual sample n instead of
+// 0, so that Generate returns samples n to n+totalSamples-1 of the same
+// endless sine. Streaming code can produce consecutive chunks that join
+// without a gap by advancing n by the chunk length, usually together with
+// WithSampleCount. Gain automation points are virtual indexes too.
+func WithStartSampleIndex(n int) Option {
+	return func(s *Sine) {
+		s.StartIndex = n
+	}
+}
+
+// WithNyquistCheck makes Generate return ErrNyquistViolation instead of
+// silently filtering frequencies the sampling rate cannot represent.
+func WithNyquistCheck() Option {
+	return func(s *Sine) {
+		s.NyquistCheck = true
+	}
+}
+
+// WithNyquistCompensation pre-emphasizes the sine to cancel the droop of a
+// zero-order hold DAC, whose response at frequency f is
+//
+//	H(f) = sin(πf/fs) / (πf/fs)
+//
+// Amplitude is divided by H(f) so the reconstructed tone keeps the same
+// level as a low-frequency one. The boost can push peaks above Amplitude.
+func WithNyquistCompensation() Option {
+	return func(s *Sine) {
+		s.NyquistComp = true
+	}
+}
+
+// WithUnison stacks voices detuned copies of the sine, spread evenly between
+// Frequency-detuneHz and Frequency+detuneHz and mixed at equal amplitude.
+func WithUnison(voices int, detuneHz float64) Option {
+	return func(s *Sine) {
+		s.UnisonVoices = voices
+		s.UnisonDetune = detuneHz
+	}
+}
+
+// WithGainAutomation applies a gain curve during generation, interpolated
+// linearly between points. Points must be sorted by SampleIndex, otherwise
+// Generate returns ErrUnsortedPoints.
+func WithGainAutomation(points []AutoPoint) Option {
+	return func(s *Sine) {
+		s.Automation = append([]AutoPoint(nil), points...)
+	}
+}
+
+// Clone returns an independent copy of the generator with the given
+// options applied on top of the current configuration.
+func (s *Sine) Clone(options ...Option) *Sine {
+	clone := *s
+	if s.Automation != nil {
+		clone.Automation = append([]AutoPoint(nil), s.Automation...)
+	}
+	if s.initialValues != nil {
+		seeds := *s.initialValues
+		clone.initialValues = &seeds
+	}
+
+	for _, opt := range options {
+		opt(&clone)
+	}
+	clone.resolveSampleCount()
+
+	return &clone
+}
+
+// SampleRate returns the sampling frequency in Hz. It reads the
+// SamplingRate field, kept under its original name for compatibility.
+func (s Sine) SampleRate() float64 {
+	return s.SamplingRate
+}
+
+// String summarizes the configuration, e.g.
+//
+//	Sine{440.0Hz, 2s, amp=1.0, rate=44100Hz, PCM16}
+func (s Sine) String() string {
+	return fmt.Sprintf("Sine{%sHz, %s, amp=%s, rate=%sHz, %s}",
+		decimal(s.Frequency), s.Duration, decimal(s.Amplitude),
+		strconv.FormatFloat(s.SamplingRate, 'f', -1, 64), format.FormatName(s.Format))
+}
+
+// decimal formats v with as many digits as needed and at least one after
+// the decimal point.
+func decimal(v float64) string {
+	text := strconv.FormatFloat(v, 'f', -1, 64)
+	if !strings.Contains(text, ".") {
+		text += ".0"
+	}
+	return text
+}
+
+// sineJSON is the serialized form of a Sine. The format is stored by its
+// registered name and the duration as a time.Duration string (e.g. "2s").
+type sineJSON struct {
+	Format       string      `json:"format"`
+	Duration     string      `json:"duration"`
+	Frequency    float64     `json:"frequency"`
+	Amplitude    float64     `json:"amplitude"`
+	Phase        float64     `json:"phase,omitempty"`
+	SamplingRate float64     `json:"samplingRate"`
+	NyquistCheck bool        `json:"nyquistCheck,omitempty"`
+	NyquistComp  bool        `json:"nyquistCompensation,omitempty"`
+	UnisonVoices int         `json:"unisonVoices,omitempty"`
+	UnisonDetune float64     `json:"unisonDetune,omitempty"`
+	Automation   []AutoPoint `json:"automation,omitempty"`
+	SampleCount  int         `json:"sampleCount,omitempty"`
+	StartIndex   int         `json:"startSampleIndex,omitempty"`
+	Initial      *[2]float64 `json:"initialSampleValues,omitempty"`
+}
+
+// MarshalJSON encodes the generator configuration. The format must be
+// registered with format.RegisterFormat.
+func (s Sine) MarshalJSON() ([]byte, error) {
+	name, err := format.NameOf(s.Format)
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal sine, err: %w", err)
+	}
+
+	return json.Marshal(sineJSON{
+		Format:       name,
+		Duration:     s.Duration.String(),
+		Frequency:    s.Frequency,
+		Amplitude:    s.Amplitude,
+		Phase:        s.Phase,
+		SamplingRate: s.SamplingRate,
+		NyquistCheck: s.NyquistCheck,
+		NyquistComp:  s.NyquistComp,
+		UnisonVoices: s.UnisonVoices,
+		UnisonDetune: s.UnisonDetune,
+		Automation:   s.Automation,
+		SampleCount:  s.SampleCount,
+		StartIndex:   s.StartIndex,
+		Initial:      s.initialValues,
+	})
+}
+
+// UnmarshalJSON decodes a configuration produced by MarshalJSON. Missing
+// fields keep the defaults used by NewSine.
+func (s *Sine) UnmarshalJSON(data []byte) error {
+	defaults := NewSine(0, 0)
+	aux := sineJSON{
+		Format:       "PCM16",
+		Duration:     "0s",
+		Amplitude:    defaults.Amplitude,
+		SamplingRate: defaults.SamplingRate,
+	}
+
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	duration, err := time.ParseDuration(aux.Duration)
+	if err != nil {
+		return fmt.Errorf("unable to parse duration, err: %w", err)
+	}
+
+	audioFormat, err := format.Lookup(aux.Format)
+	if err != nil {
+		return fmt.Errorf("unable to resolve format, err: %w", err)
+	}
+
+	s.Format = audioFormat
+	s.Duration = duration
+	s.Frequency = aux.Frequency
+	s.Amplitude = aux.Amplitude
+	s.Phase = aux.Phase
+	s.SamplingRate = aux.SamplingRate
+	s.NyquistCheck = aux.NyquistCheck
+	s.NyquistComp = aux.NyquistComp
+	s.UnisonVoices = aux.UnisonVoices
+	s.UnisonDetune = aux.UnisonDetune
+	s.Automation = aux.Automation
+	s.SampleCount = aux.SampleCount
+	s.StartIndex = aux.StartIndex
+	s.initialValues = aux.Initial
+
+	return nil
+}
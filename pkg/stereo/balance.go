@@ -0,0 +1,28 @@
+package stereo
+
+import (
+	"github.com/ECecillo/lib.go.sound/pkg/format"
+)
+
+// Balance trims the level of each channel of a stereo pair. balance is in
+// [-1, 1]: -1 keeps only the left channel, 0 leaves both untouched and +1
+// keeps only the right channel. It uses a linear taper that only ever
+// attenuates:
+//
+//	leftGain  = Clamp(1 - balance, 0, 1)
+//	rightGain = Clamp(1 + balance, 0, 1)
+func Balance(left, right []float64, balance float64) ([]float64, []float64) {
+	leftGain := format.Clamp(1-balance, 0, 1)
+	rightGain := format.Clamp(1+balance, 0, 1)
+
+	return scale(left, leftGain), scale(right, rightGain)
+}
+
+// scale returns a copy of samples multiplied by gain.
+func scale(samples []float64, gain float64) []float64 {
+	result := make([]float64, len(samples))
+	for i, v := range samples {
+		result[i] = v * gain
+	}
+	return result
+}
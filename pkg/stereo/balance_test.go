@@ -0,0 +1,64 @@
+package stereo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func power(samples []float64) float64 {
+	var sum float64
+	for _, v := range samples {
+		sum += v * v
+	}
+	return sum
+}
+
+func TestBalance(t *testing.T) {
+	left, right := testChannels()
+
+	tests := []struct {
+		name      string
+		balance   float64
+		leftGain  float64
+		rightGain float64
+	}{
+		{name: "center", balance: 0, leftGain: 1, rightGain: 1},
+		{name: "full right", balance: 1, leftGain: 0, rightGain: 1},
+		{name: "full left", balance: -1, leftGain: 1, rightGain: 0},
+		{name: "half right", balance: 0.5, leftGain: 0.5, rightGain: 1},
+		{name: "half left", balance: -0.25, leftGain: 1, rightGain: 0.75},
+		{name: "out of range", balance: 3, leftGain: 0, rightGain: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l, r := Balance(left, right, tt.balance)
+			require.Len(t, l, len(left))
+			require.Len(t, r, len(right))
+
+			for i := range left {
+				require.InDelta(t, left[i]*tt.leftGain, l[i], 1e-15)
+				require.InDelta(t, right[i]*tt.rightGain, r[i], 1e-15)
+			}
+		})
+	}
+}
+
+func TestBalance_CenterConservesPower(t *testing.T) {
+	left, right := testChannels()
+
+	l, r := Balance(left, right, 0)
+	require.Equal(t, left, l)
+	require.Equal(t, right, r)
+	require.InDelta(t, power(left)+power(right), power(l)+power(r), 1e-9)
+}
+
+func TestBalance_DoesNotModifyInput(t *testing.T) {
+	left := []float64{1, 2}
+	right := []float64{3, 4}
+
+	Balance(left, right, 1)
+	require.Equal(t, []float64{1, 2}, left)
+	require.Equal(t, []float64{3, 4}, right)
+}
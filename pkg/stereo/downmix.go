@@ -0,0 +1,47 @@
+package stereo
+
+import (
+	"math"
+)
+
+// DownmixToMono averages a stereo pair into one channel:
+//
+//	M = (L + R) * 0.5
+//
+// Identical channels come out at their original level, while content
+// present in one channel only drops by 6 dB. The result is as long as the
+// shorter channel.
+func DownmixToMono(left, right []float64) []float64 {
+	return downmix(left, right, 0.5)
+}
+
+// DownmixToMonoPower sums a stereo pair into one channel with a
+// constant-power gain:
+//
+//	M = (L + R) / sqrt(2)
+//
+// Uncorrelated channels come out at the mean power of the two channels,
+// twice the power left by DownmixToMono, so content present in one channel
+// only drops by 3 dB instead of 6 dB. Identical channels gain 3 dB. The
+// result is as long as the shorter channel.
+func DownmixToMonoPower(left, right []float64) []float64 {
+	return downmix(left, right, 1/math.Sqrt2)
+}
+
+// UpmixToStereo returns two independent copies of mono.
+func UpmixToStereo(mono []float64) (left, right []float64) {
+	left = make([]float64, len(mono))
+	right = make([]float64, len(mono))
+	copy(left, mono)
+	copy(right, mono)
+	return left, right
+}
+
+// downmix sums both channels and scales the result by gain.
+func downmix(left, right []float64, gain float64) []float64 {
+	result := make([]float64, min(len(left), len(right)))
+	for i := range result {
+		result[i] = (left[i] + right[i]) * gain
+	}
+	return result
+}
@@ -0,0 +1,74 @@
+package stereo
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDownmixToMono(t *testing.T) {
+	left, right := testChannels()
+
+	// Identical channels keep their amplitude.
+	mono := DownmixToMono(left, left)
+	require.InDeltaSlice(t, left, mono, 1e-15)
+
+	// Opposite polarity cancels out.
+	inverted := make([]float64, len(left))
+	for i, v := range left {
+		inverted[i] = -v
+	}
+	for _, v := range DownmixToMono(left, inverted) {
+		require.Zero(t, v)
+	}
+
+	mono = DownmixToMono(left, right)
+	for i := range mono {
+		require.InDelta(t, (left[i]+right[i])/2, mono[i], 1e-15)
+	}
+}
+
+func TestDownmixToMonoPower(t *testing.T) {
+	left, right := testChannels()
+
+	inverted := make([]float64, len(left))
+	for i, v := range left {
+		inverted[i] = -v
+	}
+	for _, v := range DownmixToMonoPower(left, inverted) {
+		require.Zero(t, v)
+	}
+
+	// A single hard-panned channel only drops by 3 dB.
+	silent := make([]float64, len(left))
+	require.InDelta(t, power(left)/2, power(DownmixToMonoPower(left, silent)), 1e-9)
+
+	// Uncorrelated channels (440 Hz and 660 Hz) come out at their mean
+	// power, twice the power of the plain average.
+	mean := (power(left) + power(right)) / 2
+	require.InDelta(t, mean, power(DownmixToMonoPower(left, right)), 0.01*mean)
+	require.InDelta(t, 2*power(DownmixToMono(left, right)), power(DownmixToMonoPower(left, right)), 1e-9)
+
+	mono := DownmixToMonoPower(left, left)
+	for i := range mono {
+		require.InDelta(t, left[i]*math.Sqrt2, mono[i], 1e-15)
+	}
+}
+
+func TestDownmix_LengthMismatch(t *testing.T) {
+	require.Equal(t, []float64{1, 2}, DownmixToMono([]float64{1, 2, 3}, []float64{1, 2}))
+	require.Len(t, DownmixToMonoPower([]float64{1}, []float64{1, 2}), 1)
+}
+
+func TestUpmixToStereo(t *testing.T) {
+	mono := []float64{0.5, -0.25, 1}
+
+	left, right := UpmixToStereo(mono)
+	require.Equal(t, mono, left)
+	require.Equal(t, mono, right)
+
+	left[0] = 0
+	require.Equal(t, 0.5, right[0], "channels must not share storage")
+	require.Equal(t, 0.5, mono[0])
+}
@@ -0,0 +1,63 @@
+package stereo
+
+import (
+	"errors"
+	"math"
+)
+
+// ErrLengthMismatch is returned when two channels do not hold the same
+// number of samples.
+var ErrLengthMismatch = errors.New("channels have different lengths")
+
+// MSEncode converts a left/right pair into mid/side channels:
+//
+//	M = (L + R) / sqrt(2)
+//	S = (L - R) / sqrt(2)
+//
+// The sqrt(2) normalization keeps the transform orthonormal so that
+// MSDecode is its exact inverse.
+func MSEncode(left, right []float64) (mid, side []float64, err error) {
+	return rotate(left, right)
+}
+
+// MSDecode converts mid/side channels back into a left/right pair:
+//
+//	L = (M + S) / sqrt(2)
+//	R = (M - S) / sqrt(2)
+func MSDecode(mid, side []float64) (left, right []float64, err error) {
+	return rotate(mid, side)
+}
+
+// StereoWidth scales the side content of a stereo pair. A width of 0
+// collapses the signal to mono, 1 leaves it unprocessed and 2 doubles the
+// side level.
+func StereoWidth(left, right []float64, width float64) ([]float64, []float64, error) {
+	mid, side, err := MSEncode(left, right)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for i := range side {
+		side[i] *= width
+	}
+
+	return MSDecode(mid, side)
+}
+
+// rotate applies the sum/difference butterfly shared by the encoder and
+// the decoder.
+func rotate(a, b []float64) (sum, diff []float64, err error) {
+	if len(a) != len(b) {
+		return nil, nil, ErrLengthMismatch
+	}
+
+	sum = make([]float64, len(a))
+	diff = make([]float64, len(a))
+
+	for i := range a {
+		sum[i] = (a[i] + b[i]) / math.Sqrt2
+		diff[i] = (a[i] - b[i]) / math.Sqrt2
+	}
+
+	return sum, diff, nil
+}
@@ -0,0 +1,79 @@
+package stereo
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testChannels() (left, right []float64) {
+	left = make([]float64, 1000)
+	right = make([]float64, 1000)
+	for i := range left {
+		left[i] = math.Sin(2 * math.Pi * 440 * float64(i) / 44100)
+		right[i] = 0.5 * math.Cos(2*math.Pi*660*float64(i)/44100)
+	}
+	return left, right
+}
+
+func TestMSRoundTrip(t *testing.T) {
+	left, right := testChannels()
+
+	mid, side, err := MSEncode(left, right)
+	require.NoError(t, err)
+
+	decodedLeft, decodedRight, err := MSDecode(mid, side)
+	require.NoError(t, err)
+
+	require.InDeltaSlice(t, left, decodedLeft, 1e-15)
+	require.InDeltaSlice(t, right, decodedRight, 1e-15)
+}
+
+func TestMSEncode_KnownValues(t *testing.T) {
+	mid, side, err := MSEncode([]float64{1, 1, 0}, []float64{1, -1, 0})
+	require.NoError(t, err)
+
+	require.InDeltaSlice(t, []float64{math.Sqrt2, 0, 0}, mid, 1e-15)
+	require.InDeltaSlice(t, []float64{0, math.Sqrt2, 0}, side, 1e-15)
+}
+
+func TestMSEncode_LengthMismatch(t *testing.T) {
+	_, _, err := MSEncode([]float64{1, 2}, []float64{1})
+	require.ErrorIs(t, err, ErrLengthMismatch)
+
+	_, _, err = MSDecode([]float64{1}, nil)
+	require.ErrorIs(t, err, ErrLengthMismatch)
+
+	_, _, err = StereoWidth(nil, []float64{1}, 1)
+	require.ErrorIs(t, err, ErrLengthMismatch)
+}
+
+func TestStereoWidth(t *testing.T) {
+	left, right := testChannels()
+
+	t.Run("mono", func(t *testing.T) {
+		l, r, err := StereoWidth(left, right, 0)
+		require.NoError(t, err)
+		require.InDeltaSlice(t, l, r, 1e-15, "width 0 should produce identical channels")
+		for i := range l {
+			require.InDelta(t, (left[i]+right[i])/2, l[i], 1e-15)
+		}
+	})
+
+	t.Run("unprocessed", func(t *testing.T) {
+		l, r, err := StereoWidth(left, right, 1)
+		require.NoError(t, err)
+		require.InDeltaSlice(t, left, l, 1e-15)
+		require.InDeltaSlice(t, right, r, 1e-15)
+	})
+
+	t.Run("double", func(t *testing.T) {
+		l, r, err := StereoWidth(left, right, 2)
+		require.NoError(t, err)
+		for i := range l {
+			require.InDelta(t, left[i]+right[i], l[i]+r[i], 1e-14, "mid content must be preserved")
+			require.InDelta(t, 2*(left[i]-right[i]), l[i]-r[i], 1e-14, "side content must be doubled")
+		}
+	})
+}
@@ -0,0 +1,39 @@
+package units
+
+import (
+	"math"
+)
+
+// LinearToDBFS converts a linear amplitude, where 1.0 is full scale, into
+// dBFS: 20*log10(|linear|). Zero maps to math.Inf(-1).
+func LinearToDBFS(linear float64) float64 {
+	return 20 * math.Log10(math.Abs(linear))
+}
+
+// DBFSToLinear converts dBFS into a linear amplitude: 10^(dbfs/20).
+// math.Inf(-1) maps back to zero.
+func DBFSToLinear(dbfs float64) float64 {
+	return math.Pow(10, dbfs/20)
+}
+
+// LinearToDB converts an amplitude ratio into decibels: 20*log10(|linear|).
+// Zero maps to math.Inf(-1).
+func LinearToDB(linear float64) float64 {
+	return 20 * math.Log10(math.Abs(linear))
+}
+
+// DBToLinear converts decibels into an amplitude ratio: 10^(db/20).
+func DBToLinear(db float64) float64 {
+	return math.Pow(10, db/20)
+}
+
+// PowerToDBFS converts a power value, such as a spectrum bin magnitude
+// squared, into dBFS: 10*log10(power). Zero maps to math.Inf(-1).
+func PowerToDBFS(power float64) float64 {
+	return 10 * math.Log10(math.Abs(power))
+}
+
+// DBFSToPower converts dBFS into a power value: 10^(dbfs/10).
+func DBFSToPower(dbfs float64) float64 {
+	return math.Pow(10, dbfs/10)
+}
@@ -0,0 +1,60 @@
+package units
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLinearToDBFS(t *testing.T) {
+	tests := []struct {
+		name     string
+		linear   float64
+		expected float64
+	}{
+		{name: "full scale", linear: 1.0, expected: 0.0},
+		{name: "half scale", linear: 0.5, expected: -6.0206},
+		{name: "negative half scale", linear: -0.5, expected: -6.0206},
+		{name: "tenth", linear: 0.1, expected: -20.0},
+		{name: "above full scale", linear: 2.0, expected: 6.0206},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.InDelta(t, tt.expected, LinearToDBFS(tt.linear), 1e-4)
+			require.InDelta(t, tt.expected, LinearToDB(tt.linear), 1e-4)
+		})
+	}
+
+	require.Equal(t, 0.0, LinearToDBFS(1.0))
+}
+
+func TestZeroInput(t *testing.T) {
+	require.True(t, math.IsInf(LinearToDBFS(0.0), -1))
+	require.True(t, math.IsInf(LinearToDB(0.0), -1))
+	require.True(t, math.IsInf(PowerToDBFS(0.0), -1))
+
+	require.Equal(t, 0.0, DBFSToLinear(math.Inf(-1)))
+	require.Equal(t, 0.0, DBToLinear(math.Inf(-1)))
+	require.Equal(t, 0.0, DBFSToPower(math.Inf(-1)))
+}
+
+func TestRoundTrip(t *testing.T) {
+	values := []float64{1.0, 0.5, 0.25, 0.1, 0.001, 1e-6, 3.0}
+
+	for _, x := range values {
+		require.InEpsilon(t, x, DBFSToLinear(LinearToDBFS(x)), 1e-12)
+		require.InEpsilon(t, x, DBToLinear(LinearToDB(x)), 1e-12)
+		require.InEpsilon(t, x, DBFSToPower(PowerToDBFS(x)), 1e-12)
+	}
+}
+
+func TestPowerToDBFS(t *testing.T) {
+	require.Equal(t, 0.0, PowerToDBFS(1.0))
+	require.InDelta(t, -3.0103, PowerToDBFS(0.5), 1e-4)
+	require.InDelta(t, -20.0, PowerToDBFS(0.01), 1e-12)
+
+	// Power and amplitude scales agree: amplitude a has power a^2.
+	require.InDelta(t, LinearToDBFS(0.3), PowerToDBFS(0.3*0.3), 1e-12)
+}
@@ -0,0 +1,154 @@
+package voice
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+var (
+	// ErrInvalidFrequency is returned when the fundamental is not positive.
+	ErrInvalidFrequency = errors.New("frequency must be positive")
+	// ErrInvalidOpenQuotient is returned when the open quotient is outside
+	// (0, 1].
+	ErrInvalidOpenQuotient = errors.New("open quotient must be in (0, 1]")
+	// ErrInvalidSampleRate is returned when the sample rate is not positive.
+	ErrInvalidSampleRate = errors.New("sample rate must be positive")
+)
+
+// speedQuotient is the ratio of the opening to the closing time of the
+// glottis, Tp/(Te-Tp). Values around 3 are typical of modal voice.
+const speedQuotient = 3.0
+
+// returnQuotient is the time constant Ta of the return phase relative to
+// the period. Around 1% gives the abrupt closure of modal voice.
+const returnQuotient = 0.01
+
+// GlottalPulse is a voiced excitation source modelling the airflow through
+// the glottis, to be shaped by a vocal tract filter such as the ones of
+// pkg/formant.
+type GlottalPulse struct {
+	Frequency    float64 // Fundamental frequency in Hz
+	OpenQuotient float64 // Fraction of each period before the main excitation Te
+}
+
+// NewGlottalPulse creates a GlottalPulse.
+func NewGlottalPulse(frequency, openQuotient float64) *GlottalPulse {
+	return &GlottalPulse{
+		Frequency:    frequency,
+		OpenQuotient: openQuotient,
+	}
+}
+
+// Generate returns the glottal flow for duration, normalized to a peak of
+// 1. It is the integral of the Liljencrants-Fant flow derivative E(t). Each
+// period T is split around Te = OpenQuotient*T, the instant of main
+// excitation, with Tp = Te*speedQuotient/(1+speedQuotient) the instant of
+// peak flow:
+//
+//	open:   E(t) = E0 * exp(α*t) * sin(π*t/Tp)                  0 <= t < Te
+//	return: E(t) = -Ee/(ε*Ta) * (exp(-ε*(t-Te)) - exp(-ε*(T-Te)))  Te <= t < T
+//
+// The open phase is an exponentially growing sinusoid that reaches its
+// negative peak -Ee at Te, where the flow is cut off abruptly. The return
+// phase then brings E(t) back to zero with the time constant
+// Ta = returnQuotient*T, and ends at T so the closed phase of the model is
+// empty. E0 makes E(t) continuous at Te, ε solves ε*Ta = 1 - exp(-ε*(T-Te))
+// and α makes the net flow over a period zero. The abrupt closure is the
+// main acoustic excitation of the vocal tract.
+func (g GlottalPulse) Generate(duration time.Duration, sampleRate float64) ([]float64, error) {
+	if g.Frequency <= 0 {
+		return nil, ErrInvalidFrequency
+	}
+	if g.OpenQuotient <= 0 || g.OpenQuotient > 1 {
+		return nil, ErrInvalidOpenQuotient
+	}
+	if sampleRate <= 0 {
+		return nil, ErrInvalidSampleRate
+	}
+
+	lf := newLFPulse(1/g.Frequency, g.OpenQuotient)
+	peak := lf.flow(lf.tp)
+
+	result := make([]float64, int(duration.Seconds()*sampleRate))
+	for n := range result {
+		result[n] = lf.flow(math.Mod(float64(n)/sampleRate, lf.period)) / peak
+	}
+
+	return result, nil
+}
+
+// lfPulse holds the solved parameters of one period of the
+// Liljencrants-Fant model, with Ee = 1.
+type lfPulse struct {
+	period float64 // T
+	te     float64 // Instant of main excitation
+	tp     float64 // Instant of peak flow, where E(t) crosses zero
+	ta     float64 // Time constant of the return phase
+	omega  float64 // π/Tp
+	alpha  float64 // Growth rate of the open phase
+	e0     float64 // Gain of the open phase
+	eps    float64 // Decay rate of the return phase
+}
+
+// newLFPulse solves the continuity and zero net flow conditions for the
+// given period and open quotient.
+func newLFPulse(period, openQuotient float64) lfPulse {
+	te := openQuotient * period
+	p := lfPulse{
+		period: period,
+		te:     te,
+		tp:     te * speedQuotient / (1 + speedQuotient),
+		// With OpenQuotient = 1 there is no room left for a return phase.
+		ta: min(returnQuotient*period, (period-te)/2),
+	}
+	p.omega = math.Pi / p.tp
+
+	// ε*Ta = 1 - exp(-ε*(T-Te)) converges by fixed-point iteration from
+	// 1/Ta because Ta is well below T-Te.
+	if p.ta > 0 {
+		p.eps = 1 / p.ta
+		for range 50 {
+			p.eps = (1 - math.Exp(-p.eps*(period-te))) / p.ta
+		}
+	}
+
+	// The flow at T decreases with α: bisect for the α that brings it
+	// back to zero.
+	low, high := -50/te, 50/te
+	for range 100 {
+		p.alpha = (low + high) / 2
+		p.e0 = -1 / (math.Exp(p.alpha*te) * math.Sin(p.omega*te))
+		if p.flow(period) > 0 {
+			low = p.alpha
+		} else {
+			high = p.alpha
+		}
+	}
+
+	return p
+}
+
+// flow returns the integral of E(t) from 0 to t, for t in [0, T].
+func (p lfPulse) flow(t float64) float64 {
+	if t < p.te {
+		return p.openFlow(t)
+	}
+
+	u := p.openFlow(p.te)
+	if p.ta == 0 {
+		return u
+	}
+
+	// ∫ -1/(ε*Ta) * (exp(-ε*s) - exp(-ε*(T-Te))) ds over [0, t-Te]
+	s := t - p.te
+	tail := math.Exp(-p.eps * (p.period - p.te))
+	return u - ((1-math.Exp(-p.eps*s))/p.eps-tail*s)/(p.eps*p.ta)
+}
+
+// openFlow returns ∫ E0 * exp(α*s) * sin(ω*s) ds over [0, t].
+func (p lfPulse) openFlow(t float64) float64 {
+	a, w := p.alpha, p.omega
+	growth := math.Exp(a * t)
+	return p.e0 * (growth*(a*math.Sin(w*t)-w*math.Cos(w*t)) + w) / (a*a + w*w)
+}
@@ -0,0 +1,119 @@
+package voice
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ECecillo/lib.go.sound/pkg/analysis"
+	"github.com/ECecillo/lib.go.sound/pkg/formant"
+)
+
+func TestGlottalPulse_Periodic(t *testing.T) {
+	sampleRate := 16000.0
+	pulse := NewGlottalPulse(100, 0.6)
+
+	samples, err := pulse.Generate(time.Second, sampleRate)
+	require.NoError(t, err)
+	require.Len(t, samples, 16000)
+
+	period := int(sampleRate / pulse.Frequency)
+	for n := period; n < len(samples); n++ {
+		require.InDelta(t, samples[n-period], samples[n], 1e-9)
+	}
+
+	// After Te = 96 samples the return phase decays exponentially with
+	// Ta = 1.6 samples, so the glottis is closed after a few Ta.
+	for n := 97; n < period; n++ {
+		require.Less(t, samples[n], samples[n-1]+1e-12)
+		require.GreaterOrEqual(t, samples[n], -1e-12)
+	}
+	require.Less(t, samples[96+16], 1e-4)
+}
+
+func TestGlottalPulse_Shape(t *testing.T) {
+	sampleRate := 16000.0
+	samples, err := NewGlottalPulse(100, 0.6).Generate(10*time.Millisecond, sampleRate)
+	require.NoError(t, err)
+
+	// Te = 96 samples, Tp = 72 samples.
+	peak := 72
+	require.InDelta(t, 1.0, samples[peak], 1e-9)
+
+	// The opening phase is a negative cosine arch from 0 to 1, skewed
+	// towards Tp by the exponential growth of the flow derivative.
+	maxRise, riseAt := 0.0, 0
+	for n := 1; n <= peak; n++ {
+		arch := 0.5 * (1 - math.Cos(math.Pi*float64(n)/float64(peak)))
+		require.InDelta(t, arch, samples[n], 0.2, "sample %d", n)
+
+		if d := samples[n] - samples[n-1]; d > maxRise {
+			maxRise, riseAt = d, n
+		}
+	}
+	require.Greater(t, riseAt, peak/2)
+
+	// The flow falls much faster at closure than it rises while opening,
+	// with the steepest fall at Te where E(t) reaches -Ee.
+	maxFall, fallAt := 0.0, 0
+	for n := 1; n < len(samples); n++ {
+		if d := samples[n] - samples[n-1]; d < maxFall {
+			maxFall, fallAt = d, n
+		}
+	}
+	require.Greater(t, -maxFall, 2.5*maxRise)
+	require.InDelta(t, 96, fallAt, 1)
+}
+
+func TestGlottalPulse_Formants(t *testing.T) {
+	sampleRate := 16000.0
+	fundamental := 100.0
+
+	excitation, err := NewGlottalPulse(fundamental, 0.6).Generate(time.Second, sampleRate)
+	require.NoError(t, err)
+
+	vowel := formant.NewVowel("a", sampleRate)
+	speech, err := vowel.Synthesize(excitation, sampleRate)
+	require.NoError(t, err)
+
+	source, err := analysis.HarmonicAmplitudes(excitation, fundamental, sampleRate, 30)
+	require.NoError(t, err)
+	output, err := analysis.HarmonicAmplitudes(speech, fundamental, sampleRate, 30)
+	require.NoError(t, err)
+
+	// The spectral envelope of the output relative to the source peaks at
+	// the formants of "a": 730 Hz, 1090 Hz and 2440 Hz.
+	gain := func(h int) float64 { return output[h-1] / source[h-1] }
+
+	require.Greater(t, gain(7), gain(4))
+	require.Greater(t, gain(11), gain(16))
+	require.Greater(t, gain(24), gain(16))
+	require.Greater(t, gain(24), gain(30))
+}
+
+func TestGlottalPulse_Errors(t *testing.T) {
+	_, err := NewGlottalPulse(0, 0.5).Generate(time.Second, 16000)
+	require.ErrorIs(t, err, ErrInvalidFrequency)
+
+	_, err = NewGlottalPulse(100, 0).Generate(time.Second, 16000)
+	require.ErrorIs(t, err, ErrInvalidOpenQuotient)
+
+	_, err = NewGlottalPulse(100, 1.5).Generate(time.Second, 16000)
+	require.ErrorIs(t, err, ErrInvalidOpenQuotient)
+
+	_, err = NewGlottalPulse(100, 0.5).Generate(time.Second, 0)
+	require.ErrorIs(t, err, ErrInvalidSampleRate)
+}
+
+func TestLFPulse_Conditions(t *testing.T) {
+	for _, openQuotient := range []float64{0.3, 0.6, 0.9, 1} {
+		p := newLFPulse(0.01, openQuotient)
+
+		// E(t) reaches -Ee = -1 at Te and the net flow over a period is 0.
+		require.InDelta(t, -1, p.e0*math.Exp(p.alpha*p.te)*math.Sin(p.omega*p.te), 1e-9)
+		require.InDelta(t, 0, p.flow(p.period)/p.flow(p.tp), 1e-9)
+		require.Greater(t, p.alpha, 0.0, "the open phase grows exponentially")
+	}
+}
@@ -0,0 +1,229 @@
+package wav
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/ECecillo/lib.go.sound/pkg/format"
+)
+
+// Cue is a marker pointing at a sample frame of the data chunk, as used by
+// audio workstations for cue points, markers and loop points.
+type Cue struct {
+	ID           uint32 // Unique identifier of the cue point
+	SampleOffset uint32 // Sample frame the cue points at
+	Label        string // Optional name stored in a labl chunk
+}
+
+// cuePoint is one entry of the cue chunk.
+type cuePoint struct {
+	ID           uint32
+	Position     uint32  // Position in the play order, equal to SampleOffset
+	DataChunkID  [4]byte // "data"
+	ChunkStart   uint32  // Always 0 for files with a single data chunk
+	BlockStart   uint32  // Always 0 for uncompressed data
+	SampleOffset uint32
+}
+
+// WriteCueChunk writes a "cue " chunk holding cues, followed by a LIST
+// "adtl" chunk with one labl sub-chunk per cue that has a Label. Chunks
+// are padded to an even number of bytes.
+func WriteCueChunk(w io.Writer, cues []Cue) error {
+	var buf bytes.Buffer
+
+	buf.WriteString("cue ")
+	_ = binary.Write(&buf, binary.LittleEndian, uint32(4+24*len(cues)))
+	_ = binary.Write(&buf, binary.LittleEndian, uint32(len(cues)))
+	for _, cue := range cues {
+		_ = binary.Write(&buf, binary.LittleEndian, cuePoint{
+			ID:           cue.ID,
+			Position:     cue.SampleOffset,
+			DataChunkID:  [4]byte{'d', 'a', 't', 'a'},
+			SampleOffset: cue.SampleOffset,
+		})
+	}
+
+	var labels bytes.Buffer
+	for _, cue := range cues {
+		if cue.Label == "" {
+			continue
+		}
+		// The label is stored as a null-terminated string.
+		size := uint32(4 + len(cue.Label) + 1)
+		labels.WriteString("labl")
+		_ = binary.Write(&labels, binary.LittleEndian, size)
+		_ = binary.Write(&labels, binary.LittleEndian, cue.ID)
+		labels.WriteString(cue.Label)
+		labels.WriteByte(0)
+		if size%2 == 1 {
+			labels.WriteByte(0)
+		}
+	}
+
+	if labels.Len() > 0 {
+		buf.WriteString("LIST")
+		_ = binary.Write(&buf, binary.LittleEndian, uint32(4+labels.Len()))
+		buf.WriteString("adtl")
+		buf.Write(labels.Bytes())
+	}
+
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		return fmt.Errorf("unable to write cue chunk, err: %w", err)
+	}
+	return nil
+}
+
+// WriteWithCues works like Write but stores cues between the fmt chunk and
+// the data chunk.
+func (wr Writer) WriteWithCues(w io.Writer, samples []float64, cues []Cue) (int64, error) {
+	h, err := wr.header(len(samples))
+	if err != nil {
+		return 0, err
+	}
+
+	var chunks bytes.Buffer
+	if err := WriteCueChunk(&chunks, cues); err != nil {
+		return 0, err
+	}
+	h.ChunkSize += uint32(chunks.Len())
+
+	var head bytes.Buffer
+	_ = binary.Write(&head, binary.LittleEndian, h)
+
+	// The cue chunks go right before the DataID and DataSize fields that
+	// close the header.
+	fmtEnd := head.Len() - 8
+	var buf bytes.Buffer
+	buf.Write(head.Bytes()[:fmtEnd])
+	buf.Write(chunks.Bytes())
+	buf.Write(head.Bytes()[fmtEnd:])
+
+	n, err := w.Write(buf.Bytes())
+	if err != nil {
+		return int64(n), fmt.Errorf("unable to write header, err: %w", err)
+	}
+
+	written, err := format.WriteAll(wr.Format, samples, w)
+	return int64(n) + written, err
+}
+
+// ReadCues returns the cue points stored in the WAV file read from r, with
+// the labels of its LIST "adtl" chunk attached. It returns nil when the
+// file has no cue chunk. The read position of r is restored before
+// returning.
+func ReadCues(r io.ReadSeeker) (cues []Cue, err error) {
+	position, err := r.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get read position, err: %w", err)
+	}
+
+	defer func() {
+		if _, seekErr := r.Seek(position, io.SeekStart); seekErr != nil && err == nil {
+			cues, err = nil, fmt.Errorf("unable to restore read position, err: %w", seekErr)
+		}
+	}()
+
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("unable to seek to start, err: %w", err)
+	}
+
+	var riff [12]byte
+	if _, err := io.ReadFull(r, riff[:]); err != nil {
+		return nil, ErrNotWAV
+	}
+	if string(riff[0:4]) != "RIFF" || string(riff[8:12]) != "WAVE" {
+		return nil, ErrNotWAV
+	}
+
+	labels := make(map[uint32]string)
+	for {
+		var id [4]byte
+		var size uint32
+		if _, err := io.ReadFull(r, id[:]); err != nil {
+			break
+		}
+		if err := binary.Read(r, binary.LittleEndian, &size); err != nil {
+			return nil, ErrNotWAV
+		}
+
+		padded := int64(size) + int64(size%2)
+		name := string(id[:])
+		if name != "cue " && name != "LIST" {
+			// Skip the chunk, the data chunk in particular, without
+			// reading it.
+			if _, err := r.Seek(padded, io.SeekCurrent); err != nil {
+				return nil, fmt.Errorf("unable to skip %q chunk, err: %w", name, err)
+			}
+			continue
+		}
+
+		// The payload is read through a LimitReader so that a forged size
+		// cannot allocate more than the file actually holds.
+		payload, err := io.ReadAll(io.LimitReader(r, padded))
+		if err != nil || int64(len(payload)) < int64(size) {
+			return nil, ErrNotWAV
+		}
+		payload = payload[:size]
+
+		if name == "cue " {
+			if cues, err = parseCuePoints(payload); err != nil {
+				return nil, err
+			}
+		} else {
+			parseLabels(payload, labels)
+		}
+	}
+
+	for i := range cues {
+		cues[i].Label = labels[cues[i].ID]
+	}
+	return cues, nil
+}
+
+// parseCuePoints decodes the payload of a cue chunk.
+func parseCuePoints(payload []byte) ([]Cue, error) {
+	if len(payload) < 4 {
+		return nil, ErrNotWAV
+	}
+	count := binary.LittleEndian.Uint32(payload)
+	if uint64(count) > uint64((len(payload)-4)/24) {
+		return nil, ErrNotWAV
+	}
+
+	reader := bytes.NewReader(payload[4:])
+	cues := make([]Cue, 0, count)
+	for range count {
+		var point cuePoint
+		if err := binary.Read(reader, binary.LittleEndian, &point); err != nil {
+			return nil, ErrNotWAV
+		}
+		cues = append(cues, Cue{ID: point.ID, SampleOffset: point.SampleOffset})
+	}
+	return cues, nil
+}
+
+// parseLabels collects the labl sub-chunks of a LIST "adtl" payload.
+// Other LIST types and sub-chunks are ignored.
+func parseLabels(payload []byte, labels map[uint32]string) {
+	if len(payload) < 4 || string(payload[:4]) != "adtl" {
+		return
+	}
+
+	for rest := payload[4:]; len(rest) >= 8; {
+		id := string(rest[:4])
+		size := binary.LittleEndian.Uint32(rest[4:8])
+		end := 8 + int(size)
+		if end > len(rest) {
+			return
+		}
+
+		if id == "labl" && size >= 4 {
+			text := rest[12:end]
+			labels[binary.LittleEndian.Uint32(rest[8:12])] = string(bytes.TrimRight(text, "\x00"))
+		}
+
+		rest = rest[min(end+int(size%2), len(rest)):]
+	}
+}
@@ -0,0 +1,101 @@
+package wav
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/ECecillo/lib.go.sound/pkg/format"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadCues_RoundTrip(t *testing.T) {
+	cues := []Cue{
+		{ID: 1, SampleOffset: 0, Label: "Intro"},
+		{ID: 2, SampleOffset: 22050, Label: "Verse"},
+		{ID: 7, SampleOffset: 44099},
+	}
+	samples := make([]float64, 44100)
+
+	var buf bytes.Buffer
+	n, err := NewWriter(format.PCM16{}, 44100, 1).WriteWithCues(&buf, samples, cues)
+	require.NoError(t, err)
+	require.Equal(t, int64(buf.Len()), n)
+
+	data := buf.Bytes()
+	require.Equal(t, uint32(len(data)-8), binary.LittleEndian.Uint32(data[4:8]))
+
+	// The cue chunk sits between the fmt and data chunks.
+	fmtAt := bytes.Index(data, []byte("fmt "))
+	cueAt := bytes.Index(data, []byte("cue "))
+	dataAt := bytes.Index(data, []byte("data"))
+	require.Less(t, fmtAt, cueAt)
+	require.Less(t, cueAt, dataAt)
+
+	reader := bytes.NewReader(data)
+	_, err = reader.Seek(10, 0)
+	require.NoError(t, err)
+
+	read, err := ReadCues(reader)
+	require.NoError(t, err)
+	require.Equal(t, cues, read)
+
+	position, err := reader.Seek(0, 1)
+	require.NoError(t, err)
+	require.Equal(t, int64(10), position)
+
+	// The extra chunks do not get in the way of decoding.
+	decoded, writer, err := Decode(bytes.NewReader(data))
+	require.NoError(t, err)
+	require.Equal(t, samples, decoded)
+	require.Equal(t, 44100, writer.SampleRate)
+}
+
+func TestReadCues_OddLabel(t *testing.T) {
+	cues := []Cue{{ID: 3, SampleOffset: 10, Label: "ab"}, {ID: 4, SampleOffset: 20, Label: "odd"}}
+
+	var buf bytes.Buffer
+	_, err := NewWriter(format.PCM8{}, 8000, 1).WriteWithCues(&buf, []float64{0, 0.5, -0.5}, cues)
+	require.NoError(t, err)
+
+	read, err := ReadCues(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	require.Equal(t, cues, read)
+}
+
+func TestReadCues_NoCues(t *testing.T) {
+	var buf bytes.Buffer
+	_, err := NewWriter(format.PCM16{}, 44100, 1).Write(&buf, []float64{0.1, 0.2})
+	require.NoError(t, err)
+
+	cues, err := ReadCues(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	require.Nil(t, cues)
+}
+
+func TestReadCues_NotWAV(t *testing.T) {
+	_, err := ReadCues(bytes.NewReader([]byte("not a wav file at all")))
+	require.ErrorIs(t, err, ErrNotWAV)
+}
+
+func TestReadCues_ForgedSizes(t *testing.T) {
+	riff := func(chunks ...byte) []byte {
+		return append([]byte("RIFF\x00\x00\x00\x00WAVE"), chunks...)
+	}
+
+	// A cue chunk claiming far more points than its payload holds.
+	forgedCount := riff(append([]byte("cue \x1c\x00\x00\x00\xff\xff\xff\xff"), make([]byte, 24)...)...)
+	_, err := ReadCues(bytes.NewReader(forgedCount))
+	require.ErrorIs(t, err, ErrNotWAV)
+
+	// A data chunk claiming 4 GiB is skipped, not read.
+	hugeData := riff([]byte("data\xff\xff\xff\xff\x00\x00")...)
+	cues, err := ReadCues(bytes.NewReader(hugeData))
+	require.NoError(t, err)
+	require.Nil(t, cues)
+
+	// A cue chunk claiming more bytes than the file holds.
+	truncated := riff([]byte("cue \xff\xff\xff\xff\x01\x00\x00\x00")...)
+	_, err = ReadCues(bytes.NewReader(truncated))
+	require.ErrorIs(t, err, ErrNotWAV)
+}
@@ -0,0 +1,130 @@
+package wav
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ECecillo/lib.go.sound/pkg/format"
+)
+
+// ErrNotWAV is returned when the input does not start with a RIFF/WAVE
+// header or has no fmt chunk.
+var ErrNotWAV = errors.New("not a WAV file")
+
+// maxFmtChunkSize bounds the fmt chunk payload read by findFmtChunk. The
+// largest standard layout, WAVE_FORMAT_EXTENSIBLE, takes 40 bytes; the
+// margin leaves room for vendor extensions.
+const maxFmtChunkSize = 1024
+
+// fmtChunk is the common part of the fmt chunk payload.
+type fmtChunk struct {
+	AudioFormat   uint16
+	Channels      uint16
+	SampleRate    uint32
+	ByteRate      uint32
+	BlockAlign    uint16
+	BitsPerSample uint16
+}
+
+// DetectFormat reads the RIFF header of r and returns the AudioFormat
+// matching the format code and bit depth of its fmt chunk. The read
+// position of r is restored before returning.
+func DetectFormat(r io.ReadSeeker) (f format.AudioFormat, err error) {
+	position, err := r.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get read position, err: %w", err)
+	}
+
+	defer func() {
+		if _, seekErr := r.Seek(position, io.SeekStart); seekErr != nil && err == nil {
+			f, err = nil, fmt.Errorf("unable to restore read position, err: %w", seekErr)
+		}
+	}()
+
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("unable to seek to start, err: %w", err)
+	}
+
+	chunk, err := findFmtChunk(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return audioFormatOf(chunk)
+}
+
+// findFmtChunk validates the RIFF/WAVE preamble and walks the chunks until
+// it finds the fmt chunk.
+func findFmtChunk(r io.Reader) (fmtChunk, error) {
+	var riff [12]byte
+	if _, err := io.ReadFull(r, riff[:]); err != nil {
+		return fmtChunk{}, ErrNotWAV
+	}
+	if !bytes.Equal(riff[0:4], []byte("RIFF")) || !bytes.Equal(riff[8:12], []byte("WAVE")) {
+		return fmtChunk{}, ErrNotWAV
+	}
+
+	for {
+		var id [4]byte
+		var size uint32
+		if _, err := io.ReadFull(r, id[:]); err != nil {
+			return fmtChunk{}, ErrNotWAV
+		}
+		if err := binary.Read(r, binary.LittleEndian, &size); err != nil {
+			return fmtChunk{}, ErrNotWAV
+		}
+
+		// Chunks are padded to an even number of bytes.
+		padded := int64(size) + int64(size%2)
+		if string(id[:]) != "fmt " {
+			if _, err := io.CopyN(io.Discard, r, padded); err != nil {
+				return fmtChunk{}, ErrNotWAV
+			}
+			continue
+		}
+
+		if size > maxFmtChunkSize {
+			return fmtChunk{}, ErrNotWAV
+		}
+		payload := make([]byte, padded)
+		if _, err := io.ReadFull(r, payload); err != nil {
+			return fmtChunk{}, ErrNotWAV
+		}
+
+		var chunk fmtChunk
+		if err := binary.Read(bytes.NewReader(payload), binary.LittleEndian, &chunk); err != nil {
+			return fmtChunk{}, ErrNotWAV
+		}
+
+		// WAVE_FORMAT_EXTENSIBLE stores the actual code at the start of the
+		// sub-format GUID, after cbSize, wValidBitsPerSample and
+		// dwChannelMask.
+		if chunk.AudioFormat == formatExtensible && len(payload) >= 26 {
+			chunk.AudioFormat = binary.LittleEndian.Uint16(payload[24:26])
+		}
+
+		return chunk, nil
+	}
+}
+
+// audioFormatOf maps a fmt chunk to one of the formats of pkg/format.
+func audioFormatOf(chunk fmtChunk) (format.AudioFormat, error) {
+	switch {
+	case chunk.AudioFormat == formatPCM && chunk.BitsPerSample == 8:
+		return format.PCM8{}, nil
+	case chunk.AudioFormat == formatPCM && chunk.BitsPerSample == 16:
+		return format.PCM16{}, nil
+	case chunk.AudioFormat == formatPCM && chunk.BitsPerSample == 32:
+		return format.PCM32{}, nil
+	case chunk.AudioFormat == formatIEEEFloat && chunk.BitsPerSample == 32:
+		return format.Float32{}, nil
+	case chunk.AudioFormat == formatIEEEFloat && chunk.BitsPerSample == 64:
+		return format.Float64{}, nil
+	default:
+		return nil, fmt.Errorf("%w: code 0x%04x with %d bits per sample",
+			ErrUnsupportedFormat, chunk.AudioFormat, chunk.BitsPerSample)
+	}
+}
@@ -0,0 +1,131 @@
+package wav
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"testing"
+
+	"github.com/ECecillo/lib.go.sound/pkg/format"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectFormat(t *testing.T) {
+	formats := []format.AudioFormat{
+		format.PCM8{},
+		format.PCM16{},
+		format.PCM32{},
+		format.Float32{},
+		format.Float64{},
+	}
+
+	for _, f := range formats {
+		var buf bytes.Buffer
+		_, err := NewWriter(f, 44100, 1).Write(&buf, []float64{0, 0.5, -0.5})
+		require.NoError(t, err)
+
+		detected, err := DetectFormat(bytes.NewReader(buf.Bytes()))
+		require.NoError(t, err)
+		require.IsType(t, f, detected)
+	}
+}
+
+func TestDetectFormat_RestoresPosition(t *testing.T) {
+	var buf bytes.Buffer
+	_, err := NewWriter(format.PCM16{}, 44100, 1).Write(&buf, []float64{0.5})
+	require.NoError(t, err)
+
+	r := bytes.NewReader(buf.Bytes())
+	_, err = r.Seek(44, io.SeekStart)
+	require.NoError(t, err)
+
+	_, err = DetectFormat(r)
+	require.NoError(t, err)
+
+	position, err := r.Seek(0, io.SeekCurrent)
+	require.NoError(t, err)
+	require.Equal(t, int64(44), position)
+}
+
+func TestDetectFormat_SkipsOtherChunks(t *testing.T) {
+	var buf bytes.Buffer
+	_, err := NewWriter(format.Float64{}, 44100, 1).Write(&buf, []float64{0.5})
+	require.NoError(t, err)
+	original := buf.Bytes()
+
+	// Insert an odd-sized LIST chunk, padded to an even size, before fmt.
+	var file bytes.Buffer
+	file.Write(original[:12])
+	file.WriteString("LIST")
+	require.NoError(t, binary.Write(&file, binary.LittleEndian, uint32(3)))
+	file.Write([]byte{'a', 'b', 'c', 0})
+	file.Write(original[12:])
+
+	detected, err := DetectFormat(bytes.NewReader(file.Bytes()))
+	require.NoError(t, err)
+	require.IsType(t, format.Float64{}, detected)
+}
+
+func TestDetectFormat_Extensible(t *testing.T) {
+	var buf bytes.Buffer
+	_, err := NewWriter(format.PCM16{}, 44100, 1).Write(&buf, nil)
+	require.NoError(t, err)
+	original := buf.Bytes()
+
+	// Rewrite the fmt chunk as WAVE_FORMAT_EXTENSIBLE with a float
+	// sub-format.
+	var file bytes.Buffer
+	file.Write(original[:16])
+	require.NoError(t, binary.Write(&file, binary.LittleEndian, uint32(40)))
+	require.NoError(t, binary.Write(&file, binary.LittleEndian, formatExtensible))
+	file.Write(original[22:34])
+	require.NoError(t, binary.Write(&file, binary.LittleEndian, uint16(32)))
+	require.NoError(t, binary.Write(&file, binary.LittleEndian, uint16(22)))
+	require.NoError(t, binary.Write(&file, binary.LittleEndian, uint16(32)))
+	require.NoError(t, binary.Write(&file, binary.LittleEndian, uint32(4)))
+	require.NoError(t, binary.Write(&file, binary.LittleEndian, formatIEEEFloat))
+	file.Write(make([]byte, 14))
+
+	detected, err := DetectFormat(bytes.NewReader(file.Bytes()))
+	require.NoError(t, err)
+	require.IsType(t, format.Float32{}, detected)
+}
+
+func TestDetectFormat_Errors(t *testing.T) {
+	_, err := DetectFormat(bytes.NewReader([]byte("not a wav file at all")))
+	require.ErrorIs(t, err, ErrNotWAV)
+
+	_, err = DetectFormat(bytes.NewReader(nil))
+	require.ErrorIs(t, err, ErrNotWAV)
+
+	// A RIFF/WAVE preamble without any fmt chunk.
+	_, err = DetectFormat(bytes.NewReader([]byte("RIFF\x04\x00\x00\x00WAVE")))
+	require.ErrorIs(t, err, ErrNotWAV)
+
+	var buf bytes.Buffer
+	_, err = NewWriter(format.PCM16{}, 44100, 1).Write(&buf, nil)
+	require.NoError(t, err)
+	data := buf.Bytes()
+
+	// 24-bit PCM has no matching AudioFormat.
+	binary.LittleEndian.PutUint16(data[34:36], 24)
+	_, err = DetectFormat(bytes.NewReader(data))
+	require.ErrorIs(t, err, ErrUnsupportedFormat)
+
+	// A-law is not supported.
+	binary.LittleEndian.PutUint16(data[20:22], 0x0006)
+	binary.LittleEndian.PutUint16(data[34:36], 8)
+	_, err = DetectFormat(bytes.NewReader(data))
+	require.ErrorIs(t, err, ErrUnsupportedFormat)
+}
+
+func TestDetectFormat_ForgedSizes(t *testing.T) {
+	// A chunk of 0xFFFFFFFF bytes before the fmt chunk: the padding must
+	// not wrap the size to zero.
+	_, err := DetectFormat(bytes.NewReader([]byte("RIFF\x00\x00\x00\x00WAVEjunk\xff\xff\xff\xff")))
+	require.ErrorIs(t, err, ErrNotWAV)
+
+	// An oversized fmt chunk is rejected before anything is allocated.
+	_, err = DetectFormat(bytes.NewReader([]byte("RIFF\x00\x00\x00\x00WAVEfmt \xff\xff\xff\x7f")))
+	require.ErrorIs(t, err, ErrNotWAV)
+}
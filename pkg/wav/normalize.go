@@ -0,0 +1,51 @@
+package wav
+
+import (
+	"errors"
+	"io"
+	"math"
+
+	"github.com/ECecillo/lib.go.sound/pkg/analysis"
+	"github.com/ECecillo/lib.go.sound/pkg/effects"
+	"github.com/ECecillo/lib.go.sound/pkg/format"
+	"github.com/ECecillo/lib.go.sound/pkg/units"
+)
+
+// ErrSilentAudio is returned by WriteNormalized when there are no samples or
+// every sample is zero, so no gain can bring the peak to the ceiling.
+var ErrSilentAudio = errors.New("audio is silent")
+
+// WriteNormalized scales samples so that their peak, measured with
+// analysis.Peak, sits at ceilingdBFS and writes them like Write:
+//
+//	gain = 10^(ceilingdBFS/20) / peak
+//
+// It takes the samples rather than an audio.Audio, which is built on top of
+// this package and cannot be imported here. Samples whose peak is within
+// half an LSB of the ceiling in wr.Format are written unchanged, since
+// scaling them could not move the encoded peak any closer.
+func (wr Writer) WriteNormalized(w io.Writer, samples []float64, ceilingdBFS float64) (int64, error) {
+	peak := analysis.Peak(samples)
+	if peak == 0 {
+		return 0, ErrSilentAudio
+	}
+
+	ceiling := units.DBFSToLinear(ceilingdBFS)
+	if math.Abs(peak-ceiling) > halfLSB(wr.Format) {
+		samples = effects.Gain(samples, ceiling/peak)
+	}
+
+	return wr.Write(w, samples)
+}
+
+// halfLSB returns half the quantization step of f at full scale. Floating
+// point formats use half the spacing of their values just below 1.0.
+func halfLSB(f format.AudioFormat) float64 {
+	switch f.(type) {
+	case format.Float32:
+		return math.Exp2(-25)
+	case format.Float64:
+		return math.Exp2(-54)
+	}
+	return 0.5 / (math.Exp2(float64(f.BitDepth()-1)) - 1)
+}
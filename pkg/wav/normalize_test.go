@@ -0,0 +1,107 @@
+package wav
+
+import (
+	"bytes"
+	"math"
+	"testing"
+
+	"github.com/ECecillo/lib.go.sound/pkg/analysis"
+	"github.com/ECecillo/lib.go.sound/pkg/format"
+	"github.com/ECecillo/lib.go.sound/pkg/units"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteNormalized_Peak(t *testing.T) {
+	samples := []float64{0.1, -0.3, 0.2, 0.05, -0.15}
+	writer := NewWriter(format.PCM16{}, 44100, 1)
+
+	for _, ceiling := range []float64{0, -1, -6, -20} {
+		var buf bytes.Buffer
+		n, err := writer.WriteNormalized(&buf, samples, ceiling)
+		require.NoError(t, err)
+		require.Equal(t, int64(44+2*len(samples)), n)
+
+		decoded, _, err := Decode(&buf)
+		require.NoError(t, err)
+
+		peak := 0.0
+		for _, v := range decoded {
+			peak = math.Max(peak, math.Abs(v))
+		}
+		require.InDelta(t, units.DBFSToLinear(ceiling), peak, 1.0/32767, "ceiling %v", ceiling)
+
+		// The relative levels are kept.
+		require.InDelta(t, decoded[2]/decoded[1], samples[2]/samples[1], 1e-3)
+	}
+}
+
+func TestWriteNormalized_DecodedPeakWithinOneLSB(t *testing.T) {
+	samples := []float64{0.1, -0.3, 0.2, 0.05, -0.15}
+
+	formats := []struct {
+		format format.AudioFormat
+		lsb    float64
+	}{
+		{format: format.PCM8{}, lsb: 1.0 / 127},
+		{format: format.PCM16{}, lsb: 1.0 / 32767},
+		{format: format.PCM32{}, lsb: 1.0 / 2147483647},
+		{format: format.Float32{}, lsb: math.Exp2(-23)},
+	}
+
+	for _, tt := range formats {
+		writer := NewWriter(tt.format, 48000, 1)
+
+		for _, ceiling := range []float64{0, -0.1, -3, -12} {
+			var buf bytes.Buffer
+			_, err := writer.WriteNormalized(&buf, samples, ceiling)
+			require.NoError(t, err)
+
+			decoded, _, err := Decode(&buf)
+			require.NoError(t, err)
+			require.InDelta(t, units.DBFSToLinear(ceiling), analysis.Peak(decoded), tt.lsb,
+				"%T at %v dBFS", tt.format, ceiling)
+		}
+	}
+}
+
+func TestWriteNormalized_WithinHalfLSBOfCeiling(t *testing.T) {
+	writer := NewWriter(format.PCM16{}, 44100, 1)
+	ceiling := -6.0
+	target := units.DBFSToLinear(ceiling)
+
+	for _, offset := range []float64{0, 0.4 / 32767, -0.4 / 32767} {
+		samples := []float64{0.1, -(target + offset), 0.25}
+
+		var plain, normalized bytes.Buffer
+		_, err := writer.Write(&plain, samples)
+		require.NoError(t, err)
+		_, err = writer.WriteNormalized(&normalized, samples, ceiling)
+		require.NoError(t, err)
+		require.Equal(t, plain.Bytes(), normalized.Bytes(), "offset %v", offset)
+	}
+}
+
+func TestWriteNormalized_AlreadyAtCeiling(t *testing.T) {
+	samples := []float64{0.5, -1.0, 0.25}
+	writer := NewWriter(format.PCM16{}, 44100, 1)
+
+	var plain, normalized bytes.Buffer
+	_, err := writer.Write(&plain, samples)
+	require.NoError(t, err)
+	_, err = writer.WriteNormalized(&normalized, samples, 0)
+	require.NoError(t, err)
+
+	require.Equal(t, plain.Bytes(), normalized.Bytes())
+}
+
+func TestWriteNormalized_Errors(t *testing.T) {
+	writer := NewWriter(format.PCM16{}, 44100, 1)
+
+	var buf bytes.Buffer
+	_, err := writer.WriteNormalized(&buf, []float64{0, 0, 0}, -1)
+	require.ErrorIs(t, err, ErrSilentAudio)
+	require.Zero(t, buf.Len())
+
+	_, err = writer.WriteNormalized(&buf, nil, -1)
+	require.ErrorIs(t, err, ErrSilentAudio)
+}
@@ -0,0 +1,89 @@
+package wav
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/ECecillo/lib.go.sound/pkg/format"
+)
+
+// Decode reads a WAV file from r and returns its samples, interleaved when
+// there is more than one channel, along with the Writer describing its
+// layout, so that writing the samples back with it reproduces the file.
+// Chunks other than fmt and data are skipped.
+func Decode(r io.Reader) ([]float64, *Writer, error) {
+	chunk, err := findFmtChunk(r)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	f, err := audioFormatOf(chunk)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	data, err := findDataChunk(r)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	decoder, ok := f.(format.Decoder)
+	if !ok {
+		return nil, nil, fmt.Errorf("%w: %T", format.ErrNotDecoder, f)
+	}
+
+	sampleSize := f.BitDepth() / 8
+	samples := make([]float64, len(data)/sampleSize)
+	for i := range samples {
+		samples[i], err = decoder.Decode(data[i*sampleSize : (i+1)*sampleSize])
+		if err != nil {
+			return nil, nil, fmt.Errorf("unable to decode sample %d, err: %w", i, err)
+		}
+	}
+
+	return samples, NewWriter(f, int(chunk.SampleRate), int(chunk.Channels)), nil
+}
+
+// findDataChunk walks the chunks following the fmt chunk until it finds
+// the data chunk and returns its payload.
+func findDataChunk(r io.Reader) ([]byte, error) {
+	for {
+		var id [4]byte
+		var size uint32
+		if _, err := io.ReadFull(r, id[:]); err != nil {
+			return nil, ErrNotWAV
+		}
+		if err := binary.Read(r, binary.LittleEndian, &size); err != nil {
+			return nil, ErrNotWAV
+		}
+
+		if string(id[:]) == "data" && size == sizePlaceholder {
+			// A stream whose header was never patched: the data runs
+			// until EOF.
+			data, err := io.ReadAll(r)
+			if err != nil {
+				return nil, fmt.Errorf("unable to read data chunk, err: %w", err)
+			}
+			return data, nil
+		}
+		if string(id[:]) == "data" {
+			// The size comes straight from the header: read through a
+			// LimitReader so that a forged size cannot allocate more than
+			// the file actually holds.
+			data, err := io.ReadAll(io.LimitReader(r, int64(size)))
+			if err != nil {
+				return nil, fmt.Errorf("unable to read data chunk, err: %w", err)
+			}
+			if len(data) < int(size) {
+				return nil, fmt.Errorf("unable to read data chunk, err: %w", io.ErrUnexpectedEOF)
+			}
+			return data, nil
+		}
+
+		// Chunks are padded to an even number of bytes.
+		if _, err := io.CopyN(io.Discard, r, int64(size)+int64(size%2)); err != nil {
+			return nil, ErrNotWAV
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package wav
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"testing"
+
+	"github.com/ECecillo/lib.go.sound/pkg/format"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecode_RoundTrip(t *testing.T) {
+	samples := []float64{0, 0.5, -0.5, 0.25, -1, 1}
+
+	for _, f := range []format.AudioFormat{format.PCM16{}, format.Float32{}, format.Float64{}} {
+		var buf bytes.Buffer
+		_, err := NewWriter(f, 48000, 2).Write(&buf, samples)
+		require.NoError(t, err)
+
+		decoded, layout, err := Decode(bytes.NewReader(buf.Bytes()))
+		require.NoError(t, err)
+		require.IsType(t, f, layout.Format)
+		require.Equal(t, 48000, layout.SampleRate)
+		require.Equal(t, 2, layout.Channels)
+		require.InDeltaSlice(t, samples, decoded, 1e-4)
+
+		// Writing the samples back with the returned layout reproduces the file.
+		var again bytes.Buffer
+		_, err = layout.Write(&again, decoded)
+		require.NoError(t, err)
+		require.Equal(t, buf.Bytes(), again.Bytes())
+	}
+}
+
+func TestDecode_SkipsChunksBeforeData(t *testing.T) {
+	var buf bytes.Buffer
+	_, err := NewWriter(format.PCM16{}, 44100, 1).Write(&buf, []float64{0.5, -0.5})
+	require.NoError(t, err)
+	original := buf.Bytes()
+
+	// Insert an odd-sized chunk between fmt and data.
+	var file bytes.Buffer
+	file.Write(original[:36])
+	file.WriteString("fact")
+	require.NoError(t, binary.Write(&file, binary.LittleEndian, uint32(1)))
+	file.Write([]byte{7, 0})
+	file.Write(original[36:])
+
+	decoded, _, err := Decode(bytes.NewReader(file.Bytes()))
+	require.NoError(t, err)
+	require.InDeltaSlice(t, []float64{0.5, -0.5}, decoded, 1e-4)
+}
+
+func TestDecode_Errors(t *testing.T) {
+	_, _, err := Decode(bytes.NewReader([]byte("not a wav file at all")))
+	require.ErrorIs(t, err, ErrNotWAV)
+
+	var buf bytes.Buffer
+	_, err = NewWriter(format.PCM16{}, 44100, 1).Write(&buf, []float64{0.5})
+	require.NoError(t, err)
+
+	// No data chunk after fmt.
+	_, _, err = Decode(bytes.NewReader(buf.Bytes()[:36]))
+	require.ErrorIs(t, err, ErrNotWAV)
+
+	// Data chunk shorter than announced.
+	_, _, err = Decode(bytes.NewReader(buf.Bytes()[:45]))
+	require.Error(t, err)
+
+	// A forged data size is not allocated up front.
+	forged := bytes.Clone(buf.Bytes())
+	binary.LittleEndian.PutUint32(forged[40:44], 0x7ffffff0)
+	_, _, err = Decode(bytes.NewReader(forged))
+	require.ErrorIs(t, err, io.ErrUnexpectedEOF)
+}
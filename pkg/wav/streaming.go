@@ -0,0 +1,127 @@
+package wav
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ECecillo/lib.go.sound/pkg/format"
+)
+
+// ErrNotSeekable is returned by StreamingWAVWriter.Close when the
+// underlying writer cannot seek back to patch the header.
+var ErrNotSeekable = errors.New("writer is not seekable")
+
+// sizePlaceholder fills the length fields of a streamed header until they
+// are known. Decode reads a data chunk of this size until EOF, so streams
+// that could not be patched stay readable.
+const sizePlaceholder uint32 = 0xFFFFFFFF
+
+// Offsets of the length fields from the start of the header: ChunkSize
+// follows the "RIFF" id and DataSize closes the header.
+var (
+	riffSizeOffset = int64(binary.Size([4]byte{}))
+	dataSizeOffset = int64(binary.Size(header{}) - binary.Size(uint32(0)))
+)
+
+// StreamingWAVWriter writes a WAV file whose length is not known up front.
+// The header is written with placeholder lengths and samples are appended
+// as they come; Close then seeks back to fill in the real sizes.
+type StreamingWAVWriter struct {
+	Writer // Layout of the stream
+
+	w        io.Writer
+	start    int64 // Position of the header when w is an io.WriteSeeker
+	dataSize int64
+	closed   bool
+}
+
+// NewStreamingWAVWriter writes the placeholder header for the given layout
+// to w and returns a writer ready to accept samples.
+func NewStreamingWAVWriter(w io.Writer, layout *Writer) (*StreamingWAVWriter, error) {
+	h, err := layout.header(0)
+	if err != nil {
+		return nil, err
+	}
+	h.ChunkSize = sizePlaceholder
+	h.DataSize = sizePlaceholder
+
+	s := &StreamingWAVWriter{Writer: *layout, w: w}
+	if seeker, ok := w.(io.WriteSeeker); ok {
+		if s.start, err = seeker.Seek(0, io.SeekCurrent); err != nil {
+			return nil, fmt.Errorf("unable to get write position, err: %w", err)
+		}
+	}
+
+	if err := binary.Write(w, binary.LittleEndian, h); err != nil {
+		return nil, fmt.Errorf("unable to write header, err: %w", err)
+	}
+
+	return s, nil
+}
+
+// Write encodes samples, interleaved when Channels is greater than one,
+// and appends them to the data chunk. It returns the number of bytes
+// written.
+func (s *StreamingWAVWriter) Write(samples []float64) (int64, error) {
+	n, err := format.WriteAll(s.Format, samples, s.w)
+	s.dataSize += n
+	return n, err
+}
+
+// DataSize returns the size in bytes of the data chunk written so far.
+func (s *StreamingWAVWriter) DataSize() uint32 {
+	return uint32(s.dataSize)
+}
+
+// RIFFSize returns the value of the RIFF chunk size field matching
+// DataSize.
+func (s *StreamingWAVWriter) RIFFSize() uint32 {
+	return uint32(36 + s.dataSize)
+}
+
+// Close patches the RIFF and data sizes in the header and leaves the
+// writer positioned at the end of the file. When the underlying writer is
+// not an io.WriteSeeker it returns ErrNotSeekable; the header then keeps
+// its placeholders and callers can patch it with DataSize and RIFFSize.
+// Closing twice is a no-op.
+func (s *StreamingWAVWriter) Close() error {
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+
+	seeker, ok := s.w.(io.WriteSeeker)
+	if !ok {
+		return ErrNotSeekable
+	}
+
+	end, err := seeker.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return fmt.Errorf("unable to get write position, err: %w", err)
+	}
+
+	if err := s.patch(seeker, riffSizeOffset, s.RIFFSize()); err != nil {
+		return err
+	}
+	if err := s.patch(seeker, dataSizeOffset, s.DataSize()); err != nil {
+		return err
+	}
+
+	if _, err := seeker.Seek(end, io.SeekStart); err != nil {
+		return fmt.Errorf("unable to seek to end, err: %w", err)
+	}
+	return nil
+}
+
+// patch overwrites the length field at offset from the start of the header.
+func (s *StreamingWAVWriter) patch(seeker io.WriteSeeker, offset int64, value uint32) error {
+	if _, err := seeker.Seek(s.start+offset, io.SeekStart); err != nil {
+		return fmt.Errorf("unable to seek to header, err: %w", err)
+	}
+	if err := binary.Write(seeker, binary.LittleEndian, value); err != nil {
+		return fmt.Errorf("unable to patch header, err: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package wav
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ECecillo/lib.go.sound/pkg/format"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStreamingWAVWriter_Seekable(t *testing.T) {
+	file, err := os.Create(filepath.Join(t.TempDir(), "stream.wav"))
+	require.NoError(t, err)
+	defer file.Close()
+
+	layout := NewWriter(format.PCM16{}, 48000, 2)
+	stream, err := NewStreamingWAVWriter(file, layout)
+	require.NoError(t, err)
+
+	chunks := [][]float64{{0.5, -0.5}, {0.25, -0.25, 0.1, -0.1}, {0.9, -0.9}}
+	var all []float64
+	for _, chunk := range chunks {
+		n, err := stream.Write(chunk)
+		require.NoError(t, err)
+		require.Equal(t, int64(2*len(chunk)), n)
+		all = append(all, chunk...)
+	}
+	require.NoError(t, stream.Close())
+	require.NoError(t, stream.Close())
+
+	_, err = file.Seek(0, io.SeekStart)
+	require.NoError(t, err)
+	data, err := io.ReadAll(file)
+	require.NoError(t, err)
+
+	// The header matches the one written in a single pass.
+	var expected bytes.Buffer
+	_, err = layout.Write(&expected, all)
+	require.NoError(t, err)
+	require.Equal(t, expected.Bytes(), data)
+	require.Equal(t, uint32(36+16), binary.LittleEndian.Uint32(data[4:8]))
+	require.Equal(t, uint32(16), binary.LittleEndian.Uint32(data[40:44]))
+	require.Equal(t, int64(40), dataSizeOffset)
+
+	// Decode is the package's WAV reader.
+	_, err = file.Seek(0, io.SeekStart)
+	require.NoError(t, err)
+	samples, writer, err := Decode(file)
+	require.NoError(t, err)
+	require.Equal(t, layout, writer)
+	require.InDeltaSlice(t, all, samples, 1.0/32767)
+}
+
+func TestStreamingWAVWriter_NotSeekable(t *testing.T) {
+	var buf bytes.Buffer
+	stream, err := NewStreamingWAVWriter(&buf, NewWriter(format.Float32{}, 44100, 1))
+	require.NoError(t, err)
+
+	data := buf.Bytes()
+	require.Equal(t, sizePlaceholder, binary.LittleEndian.Uint32(data[4:8]))
+	require.Equal(t, sizePlaceholder, binary.LittleEndian.Uint32(data[40:44]))
+
+	_, err = stream.Write([]float64{0.1, 0.2, 0.3})
+	require.NoError(t, err)
+
+	require.ErrorIs(t, stream.Close(), ErrNotSeekable)
+	require.Equal(t, uint32(12), stream.DataSize())
+	require.Equal(t, uint32(36+12), stream.RIFFSize())
+	require.Equal(t, 44+12, buf.Len())
+
+	// The placeholder data size reads as "until EOF".
+	samples, _, err := Decode(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	require.InDeltaSlice(t, []float64{0.1, 0.2, 0.3}, samples, 1e-7)
+}
+
+func TestStreamingWAVWriter_UnsupportedFormat(t *testing.T) {
+	var buf bytes.Buffer
+	_, err := NewStreamingWAVWriter(&buf, NewWriter(unknownFormat{}, 44100, 1))
+	require.ErrorIs(t, err, ErrUnsupportedFormat)
+	require.Zero(t, buf.Len())
+}
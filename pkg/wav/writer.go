@@ -0,0 +1,120 @@
+package wav
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ECecillo/lib.go.sound/pkg/format"
+)
+
+// Format codes stored in the fmt chunk.
+const (
+	formatPCM        uint16 = 0x0001
+	formatIEEEFloat  uint16 = 0x0003
+	formatExtensible uint16 = 0xFFFE
+)
+
+// ErrUnsupportedFormat is returned when an audio format has no WAV
+// representation.
+var ErrUnsupportedFormat = errors.New("unsupported WAV format")
+
+// header is the canonical 44-byte header of a WAV file holding a single
+// fmt chunk followed by the data chunk.
+type header struct {
+	ChunkID       [4]byte // "RIFF"
+	ChunkSize     uint32  // File size minus the 8 bytes of ChunkID and ChunkSize
+	Format        [4]byte // "WAVE"
+	FmtID         [4]byte // "fmt "
+	FmtSize       uint32  // Size of the fmt chunk payload (16)
+	AudioFormat   uint16  // formatPCM or formatIEEEFloat
+	Channels      uint16
+	SampleRate    uint32
+	ByteRate      uint32 // SampleRate * BlockAlign
+	BlockAlign    uint16 // Channels * BitsPerSample / 8
+	BitsPerSample uint16
+	DataID        [4]byte // "data"
+	DataSize      uint32  // Size of the sample data in bytes
+}
+
+// Writer encodes samples into a WAV file.
+type Writer struct {
+	Format     format.AudioFormat // Encoding of the samples
+	SampleRate int                // Sampling frequency in Hz
+	Channels   int                // Number of interleaved channels
+}
+
+// NewWriter returns a WAV writer for the given layout.
+func NewWriter(f format.AudioFormat, sampleRate int, channels int) *Writer {
+	return &Writer{
+		Format:     f,
+		SampleRate: sampleRate,
+		Channels:   channels,
+	}
+}
+
+// Write encodes the header followed by samples, which must already be
+// interleaved when Channels is greater than one, and returns the number of
+// bytes written.
+func (wr Writer) Write(w io.Writer, samples []float64) (int64, error) {
+	h, err := wr.header(len(samples))
+	if err != nil {
+		return 0, err
+	}
+
+	if err := binary.Write(w, binary.LittleEndian, h); err != nil {
+		return 0, fmt.Errorf("unable to write header, err: %w", err)
+	}
+	totalBytesWritten := int64(binary.Size(h))
+
+	for _, sample := range samples {
+		n, err := w.Write(wr.Format.ConvertSample(sample))
+		totalBytesWritten += int64(n)
+		if err != nil {
+			return totalBytesWritten, fmt.Errorf("unable to write data, err: %w", err)
+		}
+	}
+
+	return totalBytesWritten, nil
+}
+
+// header builds the WAV header for the given number of samples.
+func (wr Writer) header(totalSamples int) (header, error) {
+	code, err := formatCode(wr.Format)
+	if err != nil {
+		return header{}, err
+	}
+
+	bits := wr.Format.BitDepth()
+	blockAlign := wr.Channels * bits / 8
+	dataSize := totalSamples * bits / 8
+
+	return header{
+		ChunkID:       [4]byte{'R', 'I', 'F', 'F'},
+		ChunkSize:     uint32(36 + dataSize),
+		Format:        [4]byte{'W', 'A', 'V', 'E'},
+		FmtID:         [4]byte{'f', 'm', 't', ' '},
+		FmtSize:       16,
+		AudioFormat:   code,
+		Channels:      uint16(wr.Channels),
+		SampleRate:    uint32(wr.SampleRate),
+		ByteRate:      uint32(wr.SampleRate * blockAlign),
+		BlockAlign:    uint16(blockAlign),
+		BitsPerSample: uint16(bits),
+		DataID:        [4]byte{'d', 'a', 't', 'a'},
+		DataSize:      uint32(dataSize),
+	}, nil
+}
+
+// formatCode returns the fmt chunk code of f.
+func formatCode(f format.AudioFormat) (uint16, error) {
+	switch f.(type) {
+	case format.PCM8, format.PCM16, format.PCM32:
+		return formatPCM, nil
+	case format.Float32, format.Float64:
+		return formatIEEEFloat, nil
+	default:
+		return 0, fmt.Errorf("%w: %T", ErrUnsupportedFormat, f)
+	}
+}
@@ -0,0 +1,57 @@
+package wav
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/ECecillo/lib.go.sound/pkg/format"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriter_Header(t *testing.T) {
+	writer := NewWriter(format.PCM16{}, 48000, 2)
+	samples := []float64{0.5, -0.5, 0.25, -0.25}
+
+	var buf bytes.Buffer
+	n, err := writer.Write(&buf, samples)
+	require.NoError(t, err)
+	require.Equal(t, int64(44+8), n)
+
+	data := buf.Bytes()
+	require.Equal(t, "RIFF", string(data[0:4]))
+	require.Equal(t, uint32(36+8), binary.LittleEndian.Uint32(data[4:8]))
+	require.Equal(t, "WAVE", string(data[8:12]))
+	require.Equal(t, "fmt ", string(data[12:16]))
+	require.Equal(t, uint32(16), binary.LittleEndian.Uint32(data[16:20]))
+	require.Equal(t, formatPCM, binary.LittleEndian.Uint16(data[20:22]))
+	require.Equal(t, uint16(2), binary.LittleEndian.Uint16(data[22:24]))
+	require.Equal(t, uint32(48000), binary.LittleEndian.Uint32(data[24:28]))
+	require.Equal(t, uint32(48000*4), binary.LittleEndian.Uint32(data[28:32]))
+	require.Equal(t, uint16(4), binary.LittleEndian.Uint16(data[32:34]))
+	require.Equal(t, uint16(16), binary.LittleEndian.Uint16(data[34:36]))
+	require.Equal(t, "data", string(data[36:40]))
+	require.Equal(t, uint32(8), binary.LittleEndian.Uint32(data[40:44]))
+
+	for i, sample := range samples {
+		require.Equal(t, format.PCM16{}.ConvertSample(sample), data[44+2*i:46+2*i])
+	}
+}
+
+func TestWriter_FloatFormatCode(t *testing.T) {
+	var buf bytes.Buffer
+	_, err := NewWriter(format.Float32{}, 44100, 1).Write(&buf, []float64{0.1})
+	require.NoError(t, err)
+
+	require.Equal(t, formatIEEEFloat, binary.LittleEndian.Uint16(buf.Bytes()[20:22]))
+	require.Equal(t, uint16(32), binary.LittleEndian.Uint16(buf.Bytes()[34:36]))
+}
+
+type unknownFormat struct{ format.PCM16 }
+
+func TestWriter_UnsupportedFormat(t *testing.T) {
+	var buf bytes.Buffer
+	_, err := NewWriter(unknownFormat{}, 44100, 1).Write(&buf, []float64{0.1})
+	require.ErrorIs(t, err, ErrUnsupportedFormat)
+	require.Zero(t, buf.Len())
+}
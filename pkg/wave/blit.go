@@ -0,0 +1,99 @@
+package wave
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+// ErrNoHarmonics is returned when the frequency leaves no harmonic below
+// the Nyquist limit, or is not positive.
+var ErrNoHarmonics = errors.New("no harmonic below the Nyquist limit")
+
+// BLIT is a Band Limited Impulse Train: a periodic pulse made only of the
+// harmonics of Frequency that lie below the Nyquist limit, so it is free of
+// aliasing. Integrating it yields an alias-free sawtooth.
+type BLIT struct {
+	Oscillator
+}
+
+func NewBLIT(frequency float64, duration time.Duration, options ...Option) *BLIT {
+	return &BLIT{Oscillator: newOscillator(frequency, duration, options...)}
+}
+
+// Generate produces the impulse train using the closed form of the
+// Dirichlet kernel
+//
+//	x[n] = sin(N*π*f*n/fs) / (N*sin(π*f*n/fs))
+//
+// where N = 2H+1 and H is the number of harmonics strictly below Nyquist.
+// Pulses peak at Amplitude.
+func (b BLIT) Generate() ([]float64, error) {
+	harmonics := b.harmonics()
+	if harmonics < 1 {
+		return nil, ErrNoHarmonics
+	}
+
+	n := float64(2*harmonics + 1)
+	result := make([]float64, b.totalSamples())
+
+	for i := range result {
+		// The kernel has a period of one cycle, reducing the position to
+		// [-0.5, 0.5) keeps the phase accurate close to the pulses.
+		position := b.Frequency * float64(i) / b.SamplingRate
+		position -= math.Round(position)
+		phase := math.Pi * position
+
+		// Near a pulse both sines vanish, use the Taylor expansion of the
+		// kernel instead of dividing two tiny numbers.
+		if math.Abs(position) < 1e-6 {
+			result[i] = b.Amplitude * (1 - (n*n-1)*phase*phase/6)
+			continue
+		}
+		result[i] = b.Amplitude * math.Sin(n*phase) / (n * math.Sin(phase))
+	}
+
+	return result, nil
+}
+
+// Integrate turns the impulse train into a rising sawtooth spanning about
+// ±Amplitude. The DC component of the train is removed before a running
+// sum, which keeps the result bounded and centered on zero.
+func (b BLIT) Integrate() ([]float64, error) {
+	impulses, err := b.Generate()
+	if err != nil {
+		return nil, err
+	}
+
+	harmonics := float64(b.harmonics())
+	n := 2*harmonics + 1
+	period := b.SamplingRate / b.Frequency
+
+	// Each pulse carries Amplitude/N of DC, and the running sum of the
+	// remaining cosines settles around Amplitude*H/N.
+	dc := b.Amplitude / n
+	offset := b.Amplitude * harmonics / n
+	// Harmonic k of the running sum has an amplitude close to
+	// Amplitude*P/(N*π*k), rescale it to the 2*Amplitude/(π*k) of a
+	// sawtooth.
+	scale := 2 * n / period
+
+	result := make([]float64, len(impulses))
+	running := 0.0
+	for i, v := range impulses {
+		running += v - dc
+		result[i] = -(running - offset) * scale
+	}
+
+	return result, nil
+}
+
+// harmonics returns how many harmonics of Frequency lie strictly below the
+// Nyquist limit.
+func (b BLIT) harmonics() int {
+	if b.Frequency <= 0 || b.SamplingRate <= 0 {
+		return 0
+	}
+	period := b.SamplingRate / b.Frequency
+	return int(math.Ceil(period/2)) - 1
+}
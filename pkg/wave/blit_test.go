@@ -0,0 +1,138 @@
+package wave
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+	"time"
+
+	"github.com/ECecillo/lib.go.sound/pkg/analysis"
+	"github.com/stretchr/testify/require"
+)
+
+// fftOf returns the FFT of samples, whose length must be a power of two.
+func fftOf(t *testing.T, samples []float64) []complex128 {
+	t.Helper()
+
+	result, err := analysis.FFT(samples)
+	require.NoError(t, err)
+	return result
+}
+
+// harmonicDistortion returns the ratio between the energy found outside
+// the harmonics of a signal with the given period (in samples) and its
+// total energy. samples must hold a whole number of periods.
+func harmonicDistortion(t *testing.T, samples []float64, period int) float64 {
+	t.Helper()
+
+	spectrum := fftOf(t, samples)
+
+	binsPerHarmonic := len(samples) / period
+	var harmonic, other float64
+	for k := 1; k < len(spectrum)/2; k++ {
+		power := real(spectrum[k] * cmplx.Conj(spectrum[k]))
+		if k%binsPerHarmonic == 0 {
+			harmonic += power
+		} else {
+			other += power
+		}
+	}
+	return other / (harmonic + other)
+}
+
+// additiveSaw returns the Fourier series of a rising sawtooth truncated to
+// the given number of harmonics.
+func additiveSaw(frequency, samplingRate float64, harmonics, length int) []float64 {
+	result := make([]float64, length)
+	for i := range result {
+		t := float64(i) / samplingRate
+		for k := 1; k <= harmonics; k++ {
+			result[i] -= 2 / (math.Pi * float64(k)) * math.Sin(2*math.Pi*float64(k)*frequency*t)
+		}
+	}
+	return result
+}
+
+func TestBLIT_Generate(t *testing.T) {
+	blit := NewBLIT(375.0, 100*time.Millisecond, WithSamplingRate(48000.0), WithAmplitude(0.8))
+
+	samples, err := blit.Generate()
+	require.NoError(t, err)
+	require.Len(t, samples, 4800)
+
+	// Pulses land on every period (128 samples) and peak at Amplitude.
+	for i := 0; i < len(samples); i += 128 {
+		require.Equal(t, 0.8, samples[i])
+	}
+	for _, v := range samples {
+		require.LessOrEqual(t, math.Abs(v), 0.8+1e-12)
+	}
+}
+
+func TestBLIT_IntegratePeakToPeak(t *testing.T) {
+	blit := NewBLIT(375.0, 100*time.Millisecond, WithSamplingRate(48000.0), WithAmplitude(0.5))
+
+	saw, err := blit.Integrate()
+	require.NoError(t, err)
+
+	minVal, maxVal := saw[0], saw[0]
+	for _, v := range saw {
+		minVal = min(minVal, v)
+		maxVal = max(maxVal, v)
+	}
+
+	// When the period is a whole number of samples the Gibbs ripple is
+	// sampled on its zero crossings, leaving a ramp spanning ±Amplitude.
+	require.InDelta(t, 2*0.5, maxVal-minVal, 0.02)
+	require.InDelta(t, 0.0, maxVal+minVal, 0.01, "sawtooth should be centered on zero")
+}
+
+func TestBLIT_AliasFree(t *testing.T) {
+	samplingRate := 48000.0
+	blit := NewBLIT(375.0, time.Second, WithSamplingRate(samplingRate))
+
+	impulses, err := blit.Generate()
+	require.NoError(t, err)
+	saw, err := blit.Integrate()
+	require.NoError(t, err)
+
+	// 64 whole periods of 128 samples.
+	require.Less(t, harmonicDistortion(t, impulses[:8192], 128), 1e-4)
+	require.Less(t, harmonicDistortion(t, saw[:8192], 128), 1e-4, "integrated BLIT should only hold harmonics, below 0.01%")
+
+	// Same check on a naive saw at a frequency whose harmonics fold back in
+	// between the real ones.
+	naive := make([]float64, 8192)
+	for i := range naive {
+		phase := math.Mod(float64(i)*375.0/samplingRate*1.5, 1.0)
+		naive[i] = 2*phase - 1
+	}
+	require.Greater(t, harmonicDistortion(t, naive, 128), 1e-4, "a naive saw should alias")
+}
+
+func TestBLIT_IntegrateMatchesFourierSeries(t *testing.T) {
+	samplingRate := 48000.0
+	blit := NewBLIT(375.0, time.Second, WithSamplingRate(samplingRate))
+
+	saw, err := blit.Integrate()
+	require.NoError(t, err)
+	reference := additiveSaw(375.0, samplingRate, blit.harmonics(), 8192)
+
+	got := fftOf(t, saw[:8192])
+	want := fftOf(t, reference)
+
+	// The lowest harmonics carry most of the energy and must match the
+	// Fourier coefficients 2/(π*k).
+	for k := 1; k <= 5; k++ {
+		bin := 64 * k
+		require.InEpsilon(t, cmplx.Abs(want[bin]), cmplx.Abs(got[bin]), 5e-3, "harmonic %d", k)
+	}
+}
+
+func TestBLIT_Errors(t *testing.T) {
+	_, err := NewBLIT(30000.0, time.Second).Generate()
+	require.ErrorIs(t, err, ErrNoHarmonics)
+
+	_, err = NewBLIT(0, time.Second).Integrate()
+	require.ErrorIs(t, err, ErrNoHarmonics)
+}
@@ -0,0 +1,133 @@
+package wave
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// aliasPower returns, in dB, the power found between the harmonics of
+// frequency up to limit Hz relative to the power on the harmonics. A
+// Blackman-Harris window keeps the leakage of the harmonics, whose period
+// is not a whole number of samples, far below the aliases.
+func aliasPower(t *testing.T, samples []float64, frequency, samplingRate, limit float64) float64 {
+	t.Helper()
+
+	n := len(samples)
+	input := make([]float64, n)
+	for i, v := range samples {
+		x := 2 * math.Pi * float64(i) / float64(n)
+		w := 0.35875 - 0.48829*math.Cos(x) + 0.14128*math.Cos(2*x) - 0.01168*math.Cos(3*x)
+		input[i] = v * w
+	}
+	spectrum := fftOf(t, input)
+
+	binWidth := samplingRate / float64(n)
+	var harmonic, other float64
+	for k := 1; k < n/2 && float64(k)*binWidth <= limit; k++ {
+		power := real(spectrum[k] * cmplx.Conj(spectrum[k]))
+
+		// The window spreads each harmonic over about 4 bins.
+		ratio := float64(k) * binWidth / frequency
+		if math.Abs(ratio-math.Round(ratio))*frequency < 5*binWidth {
+			harmonic += power
+		} else {
+			other += power
+		}
+	}
+	return 10 * math.Log10(other/harmonic)
+}
+
+// naiveWave returns a trivially sampled waveform.
+func naiveWave(frequency, samplingRate float64, length int, shape func(phase float64) float64) []float64 {
+	result := make([]float64, length)
+	for i := range result {
+		phase := float64(i) * frequency / samplingRate
+		result[i] = shape(phase - math.Floor(phase))
+	}
+	return result
+}
+
+func TestDPWSaw_Shape(t *testing.T) {
+	saw, err := NewDPWSaw(375.0, time.Second, WithSamplingRate(48000.0), WithAmplitude(0.5)).Generate()
+	require.NoError(t, err)
+	require.Len(t, saw, 48000)
+
+	// Away from the resets the polynomial differences are an exact ramp
+	// rising by 2*A/P per sample, in phase with the naive sawtooth, and
+	// the decimation filter passes it unchanged.
+	for n := 32; n <= 96; n++ {
+		require.InDelta(t, 0.5*(2*float64(n)/128-1), saw[128+n], 1e-9, "sample %d", n)
+	}
+	require.InDelta(t, 2*0.5/128, saw[65]-saw[64], 1e-9)
+
+	// Like any band-limited sawtooth it overshoots at the resets (Gibbs
+	// phenomenon), by about 9%.
+	sum := 0.0
+	for _, v := range saw {
+		require.LessOrEqual(t, math.Abs(v), 0.5*1.1)
+		sum += v
+	}
+	require.InDelta(t, 0.0, sum/float64(len(saw)), 1e-3)
+}
+
+func TestDPWSaw_AliasRejection(t *testing.T) {
+	samplingRate := 44100.0
+	saw, err := NewDPWSaw(1000.0, time.Second, WithSamplingRate(samplingRate)).Generate()
+	require.NoError(t, err)
+	naive := naiveWave(1000.0, samplingRate, 32768, func(phase float64) float64 { return 2*phase - 1 })
+
+	// Oversampling removes the aliases near Nyquist, over 50 dB down from
+	// the naive sawtooth across the full band. Fourth-order DPW pushes the
+	// rest down by 24 dB per octave towards low frequencies, reaching
+	// nearly 80 dB below 5 kHz.
+	fullBand := aliasPower(t, naive, 1000.0, samplingRate, samplingRate/2) - aliasPower(t, saw[:32768], 1000.0, samplingRate, samplingRate/2)
+	lowBand := aliasPower(t, naive, 1000.0, samplingRate, 5000) - aliasPower(t, saw[:32768], 1000.0, samplingRate, 5000)
+
+	require.Greater(t, fullBand, 40.0)
+	require.Greater(t, lowBand, 60.0)
+	require.Less(t, aliasPower(t, saw[:32768], 1000.0, samplingRate, 5000), -80.0)
+}
+
+func TestDPWSquare_Shape(t *testing.T) {
+	square, err := NewDPWSquare(375.0, time.Second, WithSamplingRate(48000.0), WithAmplitude(0.8)).Generate()
+	require.NoError(t, err)
+
+	// High for the first half of the period, low for the second half.
+	require.InDelta(t, 0.8, square[32], 1e-9)
+	require.InDelta(t, -0.8, square[96], 1e-9)
+
+	sum := 0.0
+	for _, v := range square {
+		sum += v
+	}
+	require.InDelta(t, 0.0, sum/float64(len(square)), 1e-3)
+}
+
+func TestDPWSquare_AliasRejection(t *testing.T) {
+	samplingRate := 44100.0
+	square, err := NewDPWSquare(1000.0, time.Second, WithSamplingRate(samplingRate)).Generate()
+	require.NoError(t, err)
+	naive := naiveWave(1000.0, samplingRate, 32768, func(phase float64) float64 {
+		if phase < 0.5 {
+			return 1
+		}
+		return -1
+	})
+
+	fullBand := aliasPower(t, naive, 1000.0, samplingRate, samplingRate/2) - aliasPower(t, square[:32768], 1000.0, samplingRate, samplingRate/2)
+	lowBand := aliasPower(t, naive, 1000.0, samplingRate, 5000) - aliasPower(t, square[:32768], 1000.0, samplingRate, 5000)
+	require.Greater(t, fullBand, 40.0)
+	require.Greater(t, lowBand, 60.0)
+}
+
+func TestDPW_Errors(t *testing.T) {
+	_, err := NewDPWSaw(0, time.Second).Generate()
+	require.ErrorIs(t, err, ErrNoHarmonics)
+
+	_, err = NewDPWSquare(30000.0, time.Second).Generate()
+	require.ErrorIs(t, err, ErrNoHarmonics)
+}
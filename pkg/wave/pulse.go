@@ -0,0 +1,76 @@
+package wave
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+// ErrInvalidDuty is returned when a duty cycle lies outside (0, 1).
+var ErrInvalidDuty = errors.New("duty cycle must be in (0, 1)")
+
+// Pulse is a rectangular wave that stays high for Duty of each period. Its
+// edges are smoothed with PolyBLEP residuals, which removes most of the
+// aliasing of a naive pulse at a fraction of the cost of a BLIT.
+type Pulse struct {
+	Oscillator
+	Duty float64 // Fraction of the period spent high, in (0, 1)
+}
+
+func NewPulse(frequency, duty float64, duration time.Duration, options ...Option) *Pulse {
+	return &Pulse{Oscillator: newOscillator(frequency, duration, options...), Duty: duty}
+}
+
+// Generate runs a phase accumulator over [0, 1) and evaluates PulseAt on
+// each sample, scaled by Amplitude.
+func (p Pulse) Generate() ([]float64, error) {
+	if p.Duty <= 0 || p.Duty >= 1 {
+		return nil, ErrInvalidDuty
+	}
+
+	increment := p.Frequency / p.SamplingRate
+	result := make([]float64, p.totalSamples())
+
+	phase := 0.0
+	for i := range result {
+		result[i] = p.Amplitude * PulseAt(phase, increment, p.Duty)
+
+		phase += increment
+		phase -= math.Floor(phase)
+	}
+
+	return result, nil
+}
+
+// PulseAt returns the PolyBLEP pulse value, between about -1 and 1, at
+// phase in [0, 1) for an oscillator advancing by increment per sample. It
+// lets callers whose duty cycle changes from one sample to the next, such
+// as a PWM sweep, share the Pulse waveform.
+func PulseAt(phase, increment, duty float64) float64 {
+	value := -1.0
+	if phase < duty {
+		value = 1.0
+	}
+
+	// Rising edge at 0, falling edge at duty.
+	value += polyBLEP(phase, increment)
+	value -= polyBLEP(math.Mod(phase-duty+1, 1), increment)
+
+	return value
+}
+
+// polyBLEP returns the two-sample polynomial correction applied around a
+// unit step located at phase 0, for an oscillator advancing by increment
+// per sample.
+func polyBLEP(phase, increment float64) float64 {
+	switch {
+	case phase < increment:
+		t := phase / increment
+		return t + t - t*t - 1
+	case phase > 1-increment:
+		t := (phase - 1) / increment
+		return t*t + t + t + 1
+	default:
+		return 0
+	}
+}
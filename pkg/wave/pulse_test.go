@@ -0,0 +1,50 @@
+package wave
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPulse_Generate(t *testing.T) {
+	pulse := NewPulse(375.0, 0.25, time.Second, WithSamplingRate(48000.0), WithAmplitude(0.5))
+
+	samples, err := pulse.Generate()
+	require.NoError(t, err)
+	require.Len(t, samples, 48000)
+
+	sum := 0.0
+	for _, v := range samples {
+		require.LessOrEqual(t, math.Abs(v), 0.5+1e-12)
+		sum += v
+	}
+
+	// A pulse high for a quarter of the period averages A*(2*duty-1).
+	require.InDelta(t, 0.5*(2*0.25-1), sum/float64(len(samples)), 1e-3)
+}
+
+func TestPulse_SquareHasOddHarmonics(t *testing.T) {
+	pulse := NewPulse(375.0, 0.5, time.Second, WithSamplingRate(48000.0))
+
+	samples, err := pulse.Generate()
+	require.NoError(t, err)
+
+	spectrum := fftOf(t, samples[:8192])
+
+	// 64 periods of 128 samples: harmonic k lands on bin 64*k.
+	fundamental := cmplx.Abs(spectrum[64])
+	for k := 2; k <= 8; k += 2 {
+		require.Less(t, cmplx.Abs(spectrum[64*k])/fundamental, 1e-3, "even harmonic %d", k)
+	}
+	require.InDelta(t, 1.0/3.0, cmplx.Abs(spectrum[192])/fundamental, 0.01)
+}
+
+func TestPulse_InvalidDuty(t *testing.T) {
+	for _, duty := range []float64{0, 1, -0.2, 1.5} {
+		_, err := NewPulse(440.0, duty, time.Second).Generate()
+		require.ErrorIs(t, err, ErrInvalidDuty)
+	}
+}
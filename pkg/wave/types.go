@@ -0,0 +1,48 @@
+package wave
+
+import (
+	"time"
+)
+
+// Oscillator holds the parameters shared by every band-limited waveform of
+// this package.
+type Oscillator struct {
+	Duration     time.Duration // Duration of the signal
+	Frequency    float64       // Frequency in Hz
+	Amplitude    float64       // Amplitude (optional, default 1.0)
+	SamplingRate float64       // Sampling frequency in Hz
+}
+
+type Option func(*Oscillator)
+
+func newOscillator(frequency float64, duration time.Duration, options ...Option) Oscillator {
+	osc := Oscillator{
+		Frequency:    frequency,
+		Duration:     duration,
+		Amplitude:    1.0,
+		SamplingRate: 44100.0,
+	}
+
+	for _, opt := range options {
+		opt(&osc)
+	}
+
+	return osc
+}
+
+func WithAmplitude(amplitude float64) Option {
+	return func(o *Oscillator) {
+		o.Amplitude = amplitude
+	}
+}
+
+func WithSamplingRate(rate float64) Option {
+	return func(o *Oscillator) {
+		o.SamplingRate = rate
+	}
+}
+
+// totalSamples returns the number of samples covering Duration.
+func (o Oscillator) totalSamples() int {
+	return max(int(o.SamplingRate*o.Duration.Seconds()), 0)
+}
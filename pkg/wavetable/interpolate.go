@@ -0,0 +1,97 @@
+package wavetable
+
+import (
+	"math"
+)
+
+// LinearInterpolate reads table at a fractional phase by drawing a straight
+// line between the two surrounding entries. The phase wraps around
+// len(table), so the table is treated as one period of a waveform. An
+// empty table, or a NaN or infinite phase that cannot be wrapped, reads as
+// zero.
+func LinearInterpolate(table []float64, phase float64) float64 {
+	i, frac, ok := split(len(table), phase)
+	if !ok {
+		return 0.0
+	}
+
+	x0 := table[i]
+	x1 := at(table, i+1)
+
+	return x0 + frac*(x1-x0)
+}
+
+// CubicInterpolate reads table at a fractional phase with a four-point
+// Lagrange polynomial going through table[i-1], table[i], table[i+1] and
+// table[i+2]. Like LinearInterpolate, it reads zero for an empty table or a
+// non-finite phase.
+func CubicInterpolate(table []float64, phase float64) float64 {
+	i, frac, ok := split(len(table), phase)
+	if !ok {
+		return 0.0
+	}
+
+	xm1 := at(table, i-1)
+	x0 := table[i]
+	x1 := at(table, i+1)
+	x2 := at(table, i+2)
+
+	return -frac*(frac-1)*(frac-2)/6*xm1 +
+		(frac+1)*(frac-1)*(frac-2)/2*x0 -
+		(frac+1)*frac*(frac-2)/2*x1 +
+		(frac+1)*frac*(frac-1)/6*x2
+}
+
+// HermiteInterpolate reads table at a fractional phase with a four-point,
+// third-order Hermite spline (Catmull-Rom). Unlike CubicInterpolate the
+// slope is continuous from one segment to the next. Like LinearInterpolate,
+// it reads zero for an empty table or a non-finite phase.
+func HermiteInterpolate(table []float64, phase float64) float64 {
+	i, frac, ok := split(len(table), phase)
+	if !ok {
+		return 0.0
+	}
+
+	xm1 := at(table, i-1)
+	x0 := table[i]
+	x1 := at(table, i+1)
+	x2 := at(table, i+2)
+
+	c0 := x0
+	c1 := 0.5 * (x1 - xm1)
+	c2 := xm1 - 2.5*x0 + 2*x1 - 0.5*x2
+	c3 := 0.5*(x2-xm1) + 1.5*(x0-x1)
+
+	return ((c3*frac+c2)*frac+c1)*frac + c0
+}
+
+// split wraps phase into [0, size) and returns its integer index and its
+// fractional part. It reports false for an empty table or a non-finite
+// phase.
+func split(size int, phase float64) (int, float64, bool) {
+	if size == 0 || math.IsNaN(phase) || math.IsInf(phase, 0) {
+		return 0, 0.0, false
+	}
+
+	phase = math.Mod(phase, float64(size))
+	if phase < 0 {
+		phase += float64(size)
+	}
+
+	index := int(phase)
+	// Rounding can push a tiny negative phase up to exactly size.
+	if index >= size {
+		return 0, 0.0, true
+	}
+
+	return index, phase - float64(index), true
+}
+
+// at returns table[i] with i wrapped around the table length.
+func at(table []float64, i int) float64 {
+	i %= len(table)
+	if i < 0 {
+		i += len(table)
+	}
+	return table[i]
+}
@@ -0,0 +1,97 @@
+package wavetable
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type interpolator func(table []float64, phase float64) float64
+
+var interpolators = map[string]interpolator{
+	"linear":  LinearInterpolate,
+	"cubic":   CubicInterpolate,
+	"hermite": HermiteInterpolate,
+}
+
+func sineTable(size int) []float64 {
+	table := make([]float64, size)
+	for i := range table {
+		table[i] = math.Sin(2 * math.Pi * float64(i) / float64(size))
+	}
+	return table
+}
+
+func TestInterpolate_IntegerPhases(t *testing.T) {
+	table := []float64{0.1, -0.7, 0.4, 0.9, -0.2, 0.3}
+
+	for name, interpolate := range interpolators {
+		t.Run(name, func(t *testing.T) {
+			for i, expected := range table {
+				require.InDelta(t, expected, interpolate(table, float64(i)), 1e-12)
+			}
+		})
+	}
+}
+
+func TestInterpolate_Wraps(t *testing.T) {
+	table := sineTable(16)
+
+	for name, interpolate := range interpolators {
+		t.Run(name, func(t *testing.T) {
+			for _, phase := range []float64{0.25, 3.5, 15.75} {
+				expected := interpolate(table, phase)
+				require.InDelta(t, expected, interpolate(table, phase+16), 1e-12)
+				require.InDelta(t, expected, interpolate(table, phase-16), 1e-12)
+			}
+		})
+	}
+
+	// Between the last and first entries the table wraps around.
+	require.InDelta(t, (table[15]+table[0])/2, LinearInterpolate(table, 15.5), 1e-12)
+}
+
+func TestInterpolate_CubicBeatsLinear(t *testing.T) {
+	size := 32
+	table := sineTable(size)
+
+	maxError := func(interpolate interpolator) float64 {
+		worst := 0.0
+		for phase := 0.0; phase < float64(size); phase += 0.01 {
+			expected := math.Sin(2 * math.Pi * phase / float64(size))
+			worst = math.Max(worst, math.Abs(interpolate(table, phase)-expected))
+		}
+		return worst
+	}
+
+	linear := maxError(LinearInterpolate)
+	require.Less(t, maxError(CubicInterpolate), linear)
+	require.Less(t, maxError(HermiteInterpolate), linear)
+}
+
+func TestInterpolate_TinyTables(t *testing.T) {
+	for name, interpolate := range interpolators {
+		t.Run(name, func(t *testing.T) {
+			require.NotPanics(t, func() {
+				require.Equal(t, 0.5, interpolate([]float64{0.5}, 0.3))
+				require.Equal(t, 0.5, interpolate([]float64{0.5}, -2.7))
+				require.Equal(t, 0.0, interpolate(nil, 1.5))
+			})
+		})
+	}
+}
+
+func TestInterpolate_NonFinitePhase(t *testing.T) {
+	table := sineTable(16)
+
+	for name, interpolate := range interpolators {
+		t.Run(name, func(t *testing.T) {
+			for _, phase := range []float64{math.NaN(), math.Inf(1), math.Inf(-1)} {
+				require.NotPanics(t, func() {
+					require.Zero(t, interpolate(table, phase))
+				})
+			}
+		})
+	}
+}